@@ -0,0 +1,231 @@
+package main
+
+// dlqctl inspeciona a DLQ de operações EVM: lista e filtra mensagens, mostra o contexto completo da falha,
+// reenvia mensagens selecionadas para a fila principal e remove mensagens (com dry-run)
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/logger"
+	pkgconfig "github.com/gabrielksneiva/ChainEVM/pkg/config"
+	"go.uber.org/zap"
+)
+
+const usage = `usage: dlqctl <command> [flags]
+
+commands:
+  count     approximate number of messages in the DLQ
+  list      list DLQ messages (filters: -operation-id, -chain, -reason, -message-id)
+  show      print the full failure context (attempt history, timestamps, request ID) of the selected messages
+  redrive   send the selected original messages back to the main queue
+  purge     delete the selected messages from the DLQ
+
+redrive and purge without filters require -all; use -dry-run to preview
+`
+
+// errUsage comando ou flags inválidos
+var errUsage = errors.New("invalid usage")
+
+// dlqManager operações da DLQ usadas pela CLI
+type dlqManager interface {
+	Count(ctx context.Context) (int32, error)
+	List(ctx context.Context, filter eventbus.DeadLetterFilter) ([]eventbus.DeadLetterEntry, error)
+	Redrive(ctx context.Context, filter eventbus.DeadLetterFilter, mode eventbus.IdempotencyMode, dryRun bool) ([]eventbus.DeadLetterEntry, error)
+	Purge(ctx context.Context, filter eventbus.DeadLetterFilter, dryRun bool) ([]eventbus.DeadLetterEntry, error)
+}
+
+// options flags comuns aos comandos
+type options struct {
+	queueURL    string
+	dlqURL      string
+	maxMessages int
+	verbose     bool
+}
+
+// connectFunc cria o dlqManager com as opções da linha de comando
+type connectFunc func(ctx context.Context, opts options) (dlqManager, error)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, connect); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+		}
+		fmt.Fprintln(os.Stderr, "dlqctl:", err)
+		os.Exit(1)
+	}
+}
+
+// connect cria o DLQManager com a configuração do ambiente (SQS_QUEUE_URL, SQS_QUEUE_DLQ_URL) e as flags
+func connect(ctx context.Context, opts options) (dlqManager, error) {
+	if opts.dlqURL == "" {
+		return nil, fmt.Errorf("%w: DLQ URL not set (SQS_QUEUE_DLQ_URL or -dlq-url)", errUsage)
+	}
+
+	log := zap.NewNop()
+	if opts.verbose {
+		var err error
+		if log, err = logger.NewLogger(pkgconfig.LoadConfig().Environment); err != nil {
+			return nil, fmt.Errorf("failed to initialize logger: %w", err)
+		}
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	sqsAdapter := eventbus.NewSQSAdapter(sqs.NewFromConfig(awsCfg))
+	return eventbus.NewDLQManager(
+		eventbus.NewDLQHandler(sqsAdapter, opts.dlqURL, log),
+		opts.queueURL,
+		eventbus.DLQManagerConfig{MaxMessages: opts.maxMessages},
+		log,
+	), nil
+}
+
+// run executa o comando de args e escreve o resultado em out
+func run(ctx context.Context, args []string, out io.Writer, connect connectFunc) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", errUsage)
+	}
+	command := args[0]
+
+	cfg := pkgconfig.LoadConfig()
+	var opts options
+	var filter eventbus.DeadLetterFilter
+	var all, dryRun bool
+	var idempotency string
+
+	flags := flag.NewFlagSet("dlqctl "+command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(&opts.queueURL, "queue-url", cfg.SQSQueueURL, "main queue URL (redrive target)")
+	flags.StringVar(&opts.dlqURL, "dlq-url", cfg.SQSQueueDLQURL, "dead letter queue URL")
+	flags.IntVar(&opts.maxMessages, "max-messages", 1000, "maximum DLQ messages read")
+	flags.BoolVar(&opts.verbose, "verbose", false, "log SQS operations")
+	flags.StringVar(&filter.MessageID, "message-id", "", "select by SQS message ID")
+	flags.StringVar(&filter.OperationID, "operation-id", "", "select by operation ID")
+	flags.StringVar(&filter.ChainType, "chain", "", "select by chain type")
+	flags.StringVar(&filter.Reason, "reason", "", "select by failure reason (substring)")
+	flags.BoolVar(&all, "all", false, "select every message (redrive, purge)")
+	flags.BoolVar(&dryRun, "dry-run", false, "only print the selected messages (redrive, purge)")
+	flags.StringVar(&idempotency, "idempotency", string(eventbus.IdempotencyKeep),
+		"keep: finalized operations are not executed again | renew: new idempotency key, execute again (redrive)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	switch command {
+	case "count", "list", "show", "redrive", "purge":
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+	if command == "show" && filter.IsEmpty() {
+		return fmt.Errorf("%w: show requires a filter", errUsage)
+	}
+	if (command == "redrive" || command == "purge") && filter.IsEmpty() && !all {
+		return fmt.Errorf("%w: %s without filters requires -all", errUsage, command)
+	}
+	mode, err := eventbus.NewIdempotencyMode(idempotency)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if command == "redrive" && opts.queueURL == "" {
+		return fmt.Errorf("%w: main queue URL not set (SQS_QUEUE_URL or -queue-url)", errUsage)
+	}
+
+	manager, err := connect(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "count":
+		count, err := manager.Count(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, count)
+		return nil
+	case "list":
+		entries, err := manager.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		return printTable(out, entries)
+	case "show":
+		entries, err := manager.List(ctx, filter)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "redrive":
+		entries, err := manager.Redrive(ctx, filter, mode, dryRun)
+		printResult(out, "redriven", dryRun, entries)
+		return err
+	default:
+		entries, err := manager.Purge(ctx, filter, dryRun)
+		printResult(out, "purged", dryRun, entries)
+		return err
+	}
+}
+
+// printTable lista as entradas em colunas
+func printTable(out io.Writer, entries []eventbus.DeadLetterEntry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MESSAGE_ID\tOPERATION_ID\tCHAIN\tTYPE\tSENT_AT\tATTEMPTS\tERROR_CODE\tFAILURE_REASON")
+	for _, entry := range entries {
+		operationID, chainType, operationType := "-", "-", "-"
+		if entry.OriginalMessage != nil {
+			operationID, chainType, operationType = entry.OriginalMessage.OperationID, entry.OriginalMessage.ChainType, entry.OriginalMessage.OperationType
+		}
+		attempts, code := "-", "-"
+		if entry.RetryCount > 0 {
+			attempts = strconv.Itoa(entry.RetryCount)
+		}
+		if entry.ErrorCode != "" {
+			code = entry.ErrorCode
+		}
+		sentAt := "-"
+		if !entry.SentAt.IsZero() {
+			sentAt = entry.SentAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.MessageID, operationID, chainType, operationType, sentAt, attempts, code, entry.Reason)
+	}
+	return w.Flush()
+}
+
+// printResult uma linha por mensagem processada e o total
+func printResult(out io.Writer, action string, dryRun bool, entries []eventbus.DeadLetterEntry) {
+	prefix := action
+	if dryRun {
+		prefix = "would be " + action
+	}
+	for _, entry := range entries {
+		operationID := "-"
+		if entry.OriginalMessage != nil {
+			operationID = entry.OriginalMessage.OperationID
+		}
+		fmt.Fprintf(out, "%s: %s (operation %s)\n", prefix, entry.MessageID, operationID)
+	}
+	fmt.Fprintf(out, "%d message(s) %s\n", len(entries), prefix)
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDLQManager mock das operações da DLQ
+type MockDLQManager struct {
+	mock.Mock
+}
+
+func (m *MockDLQManager) Count(ctx context.Context) (int32, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (m *MockDLQManager) List(ctx context.Context, filter eventbus.DeadLetterFilter) ([]eventbus.DeadLetterEntry, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]eventbus.DeadLetterEntry), args.Error(1)
+}
+
+func (m *MockDLQManager) Redrive(ctx context.Context, filter eventbus.DeadLetterFilter, mode eventbus.IdempotencyMode, dryRun bool) ([]eventbus.DeadLetterEntry, error) {
+	args := m.Called(ctx, filter, mode, dryRun)
+	return args.Get(0).([]eventbus.DeadLetterEntry), args.Error(1)
+}
+
+func (m *MockDLQManager) Purge(ctx context.Context, filter eventbus.DeadLetterFilter, dryRun bool) ([]eventbus.DeadLetterEntry, error) {
+	args := m.Called(ctx, filter, dryRun)
+	return args.Get(0).([]eventbus.DeadLetterEntry), args.Error(1)
+}
+
+var entry = eventbus.DeadLetterEntry{
+	MessageID: "msg-1",
+	DeadLetterMessage: eventbus.DeadLetterMessage{
+		OriginalMessage: &eventbus.Message{OperationID: "op-1", ChainType: "ETHEREUM", OperationType: "TRANSFER"},
+		Reason:          "permanent failure: execution reverted",
+		ErrorCode:       "EXECUTION_REVERTED",
+		RetryCount:      2,
+		Attempts:        []eventbus.DeliveryAttempt{{Attempt: 2, Error: "execution reverted", ErrorCode: "EXECUTION_REVERTED"}},
+		RequestID:       "request-1",
+	},
+	SentAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	Body:   `{"original_message":{"operation_id":"op-1"}}`,
+}
+
+// runWith executa o comando com o mock e as URLs das filas definidas
+func runWith(manager *MockDLQManager, args ...string) (string, error) {
+	var out bytes.Buffer
+	args = append(args, "-queue-url", "queue-url", "-dlq-url", "dlq-url")
+	err := run(context.Background(), args, &out, func(ctx context.Context, opts options) (dlqManager, error) {
+		return manager, nil
+	})
+	return out.String(), err
+}
+
+func TestRun(t *testing.T) {
+	t.Run("count", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("Count", mock.Anything).Return(int32(3), nil)
+
+		out, err := runWith(manager, "count")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3\n", out)
+	})
+
+	t.Run("list with filters", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("List", mock.Anything, eventbus.DeadLetterFilter{ChainType: "ETHEREUM", Reason: "reverted"}).
+			Return([]eventbus.DeadLetterEntry{entry}, nil)
+
+		out, err := runWith(manager, "list", "-chain", "ETHEREUM", "-reason", "reverted")
+
+		assert.NoError(t, err)
+		assert.Contains(t, out, "MESSAGE_ID")
+		assert.Contains(t, out, "msg-1")
+		assert.Contains(t, out, "op-1")
+		assert.Contains(t, out, "2026-01-01T00:00:00Z")
+		assert.Contains(t, out, "EXECUTION_REVERTED")
+		assert.Contains(t, out, "permanent failure: execution reverted")
+	})
+
+	t.Run("show full failure context", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("List", mock.Anything, eventbus.DeadLetterFilter{OperationID: "op-1"}).
+			Return([]eventbus.DeadLetterEntry{entry}, nil)
+
+		out, err := runWith(manager, "show", "-operation-id", "op-1")
+
+		assert.NoError(t, err)
+		var shown []map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(out), &shown))
+		assert.Equal(t, "permanent failure: execution reverted", shown[0]["failure_reason"])
+		assert.Equal(t, entry.Body, shown[0]["body"])
+		assert.Equal(t, "request-1", shown[0]["request_id"])
+		assert.Len(t, shown[0]["attempts"], 1)
+	})
+
+	t.Run("show requires a filter", func(t *testing.T) {
+		_, err := runWith(new(MockDLQManager), "show")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("redrive with renewed idempotency", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("Redrive", mock.Anything, eventbus.DeadLetterFilter{OperationID: "op-1"}, eventbus.IdempotencyRenew, false).
+			Return([]eventbus.DeadLetterEntry{entry}, nil)
+
+		out, err := runWith(manager, "redrive", "-operation-id", "op-1", "-idempotency", "renew")
+
+		assert.NoError(t, err)
+		assert.Contains(t, out, "redriven: msg-1 (operation op-1)")
+		assert.Contains(t, out, "1 message(s) redriven")
+	})
+
+	t.Run("redrive reports partial result on failure", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("Redrive", mock.Anything, eventbus.DeadLetterFilter{}, eventbus.IdempotencyKeep, false).
+			Return([]eventbus.DeadLetterEntry{entry}, errors.New("throttled"))
+
+		out, err := runWith(manager, "redrive", "-all")
+
+		assert.EqualError(t, err, "throttled")
+		assert.Contains(t, out, "1 message(s) redriven")
+	})
+
+	t.Run("redrive without filters requires all", func(t *testing.T) {
+		manager := new(MockDLQManager)
+
+		_, err := runWith(manager, "redrive")
+
+		assert.ErrorIs(t, err, errUsage)
+		manager.AssertNotCalled(t, "Redrive", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("purge dry run", func(t *testing.T) {
+		manager := new(MockDLQManager)
+		manager.On("Purge", mock.Anything, eventbus.DeadLetterFilter{}, true).
+			Return([]eventbus.DeadLetterEntry{entry}, nil)
+
+		out, err := runWith(manager, "purge", "-all", "-dry-run")
+
+		assert.NoError(t, err)
+		assert.Contains(t, out, "would be purged: msg-1")
+	})
+
+	t.Run("invalid idempotency mode", func(t *testing.T) {
+		_, err := runWith(new(MockDLQManager), "redrive", "-all", "-idempotency", "reset")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, err := runWith(new(MockDLQManager), "replay")
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+
+	t.Run("missing command", func(t *testing.T) {
+		err := run(context.Background(), nil, &bytes.Buffer{}, nil)
+
+		assert.ErrorIs(t, err, errUsage)
+	})
+}
@@ -1,35 +1,29 @@
 package main
 
 // Lambda handler for EVM transaction execution
-// Updated: 2025-12-04 23:20 - Final E2E test with all IAM permissions
 
 import (
 	"context"
 	"encoding/json"
-	"math/big"
+	"fmt"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
-	"github.com/aws/aws-sdk-go-v2/service/sqs"
-	"github.com/gabrielksneiva/ChainEVM/internal/application/dtos"
-	"github.com/gabrielksneiva/ChainEVM/internal/application/usecases"
-	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainEVM/internal/bootstrap"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/logger"
-	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
 	pkgconfig "github.com/gabrielksneiva/ChainEVM/pkg/config"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"go.uber.org/zap"
 )
 
 var (
 	cfg            *pkgconfig.Config
 	log            *zap.Logger
-	executeUseCase *usecases.ExecuteEVMTransactionUseCase
-	sqsConsumer    *eventbus.SQSConsumer
-	dlqHandler     *eventbus.DLQHandler
-	retryManager   *eventbus.RetryManager
+	app            *bootstrap.App
+	batchProcessor *eventbus.BatchProcessor
 )
 
 func init() {
@@ -50,103 +44,103 @@ func init() {
 		log.Fatal("failed to load AWS config", zap.Error(err))
 	}
 
-	// Initialize SQS client
-	sqsClient := sqs.NewFromConfig(awsCfg)
-	sqsAdapter := eventbus.NewSQSAdapter(sqsClient)
-	sqsConsumer = eventbus.NewSQSConsumer(sqsAdapter, cfg.SQSQueueURL, log)
-
-	// Initialize DLQ Handler for failed messages
-	dlqHandler = eventbus.NewDLQHandler(sqsAdapter, cfg.SQSQueueDLQURL, log)
-
-	// Initialize Retry Manager with exponential backoff
-	retryManager = eventbus.NewRetryManager(dlqHandler, 3, log)
-
-	// Initialize DynamoDB client
-	dynamoDBClient := dynamodb.NewFromConfig(awsCfg)
-	dynamoDBAdapter := database.NewDynamoDBAdapter(dynamoDBClient)
-	transactionRepo := database.NewDynamoDBTransactionRepository(dynamoDBAdapter, cfg.DynamoDBTableName, log)
-
-	// Initialize RPC clients for each chain
-	rpcClients := make(map[string]rpc.RPCClient)
-	for chainName, rpcURL := range cfg.EVMRPCURLs {
-		if rpcURL != "" {
-			client, err := rpc.NewEVMRPCClient(rpcURL, cfg.RPCTimeout, log)
-			if err != nil {
-				log.Warn("failed to initialize RPC client for chain",
-					zap.String("chain", chainName),
-					zap.Error(err))
-				continue
-			}
-			rpcClients[chainName] = client
-		}
-	}
+	// Initialize SQS, DynamoDB, RPC clients, signers and use cases
+	app = bootstrap.New(context.Background(), cfg, awsCfg, log)
 
-	// Initialize TransactionSigner for signing and monitoring confirmations
-	var transactionSigner *rpc.TransactionSigner
-	if len(rpcClients) > 0 {
-		// Get first available RPC client and extract EthClient
-		var firstEthClient rpc.EthClient
-		for _, rpcclient := range rpcClients {
-			// EVMRPCClient has a client field that implements EthClient
-			if evmClient, ok := rpcclient.(*rpc.EVMRPCClient); ok {
-				// Access the embedded EthClient (need public getter or direct access)
-				// For now, use adapter approach: create a new adapter from EVMRPCClient
-				firstEthClient = evmClient.GetEthClient()
-				break
-			}
-		}
-		if firstEthClient != nil {
-			// Use required confirmations from config
-			chainID := big.NewInt(11155111) // Sepolia chain ID
-			transactionSigner = rpc.NewTransactionSigner(
-				firstEthClient,
-				chainID,
-				log,
-				cfg.RPCTimeout,
-			)
-			log.Info("TransactionSigner initialized", zap.Int64("chain_id", chainID.Int64()))
-		}
-	}
-
-	// Initialize use cases
-	executeUseCase = usecases.NewExecuteEVMTransactionUseCase(
-		rpcClients,
-		transactionRepo,
-		transactionSigner,
-		log,
-	)
+	// Initialize batch processor (registros do lote em paralelo, em ordem por remetente)
+	batchProcessor = eventbus.NewBatchProcessor(eventbus.BatchConfig{
+		Concurrency:    cfg.Batch.Concurrency,
+		DeadlineMargin: cfg.Batch.DeadlineMargin,
+	}, log)
 
 	log.Info("Lambda function initialized successfully",
 		zap.String("environment", cfg.Environment),
 		zap.String("sqs_queue_url", cfg.SQSQueueURL),
 		zap.String("sqs_dlq_url", cfg.SQSQueueDLQURL),
 		zap.String("dynamodb_table", cfg.DynamoDBTableName),
-		zap.Int("rpc_clients_initialized", len(rpcClients)),
+		zap.Int("rpc_clients_initialized", len(app.RPCClients)),
+		zap.Int("signers_initialized", len(app.Signers)),
 	)
 }
 
 func main() {
-	lambda.Start(handler)
+	lambda.Start(route)
+}
+
+// route encaminha o evento recebido: agendamento do EventBridge (varredura de confirmações) ou lote SQS,
+// que responde com as mensagens que falharam (ReportBatchItemFailures)
+func route(ctx context.Context, payload json.RawMessage) (any, error) {
+	var scheduled events.CloudWatchEvent
+	if err := json.Unmarshal(payload, &scheduled); err == nil && scheduled.Source == "aws.events" {
+		return nil, confirmationSweep(ctx)
+	}
+
+	var sqsEvent events.SQSEvent
+	if err := json.Unmarshal(payload, &sqsEvent); err != nil {
+		log.Error("failed to unmarshal lambda event", zap.Error(err))
+		return nil, fmt.Errorf("unsupported lambda event: %w", err)
+	}
+	return handler(ctx, sqsEvent)
+}
+
+// confirmationSweep verifica as operações SUBMITTED e finaliza as que atingiram a profundidade da chain
+func confirmationSweep(ctx context.Context) error {
+	finalized, err := app.ConfirmUseCase.ConfirmPending(ctx, cfg.ConfirmationSweepLimit)
+	if err != nil {
+		log.Error("confirmation sweep failed", zap.Error(err))
+		return err
+	}
+
+	log.Info("confirmation sweep completed", zap.Int("finalized", finalized))
+	return nil
 }
 
-// handler processa eventos SQS
-func handler(ctx context.Context, event events.SQSEvent) error {
+// handler processa eventos SQS com os registros em paralelo (em sequência por remetente ou MessageGroupId);
+// as mensagens com falha são devolvidas em BatchItemFailures e permanecem na fila, as demais são removidas
+// pela própria Lambda
+func handler(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error) {
 	log.Info("processing SQS event", zap.Int("message_count", len(event.Records)))
 
-	for _, record := range event.Records {
-		if err := processMessage(ctx, record); err != nil {
-			log.Error("failed to process message",
-				zap.String("message_id", record.MessageId),
-				zap.Error(err))
-			// Não retorna erro para não descartar a mensagem automaticamente
-			// A visibilidade será alterada para retry
+	items := make([]eventbus.BatchItem, len(event.Records))
+	for i, record := range event.Records {
+		items[i] = batchItem(record)
+	}
+
+	results := batchProcessor.Process(ctx, items, func(ctx context.Context, index int) error {
+		return processMessage(ctx, event.Records[index])
+	})
+
+	response := events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{}}
+	for i, err := range results {
+		if err == nil {
+			continue
 		}
+		log.Error("failed to process message",
+			zap.String("message_id", event.Records[i].MessageId),
+			zap.Error(err))
+		response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+			ItemIdentifier: event.Records[i].MessageId,
+		})
 	}
 
-	return nil
+	return response, nil
 }
 
-// processMessage processa uma única mensagem com retry automático
+// batchItem chave de ordenação do registro: o MessageGroupId em filas FIFO ou o remetente na chain
+func batchItem(record events.SQSMessage) eventbus.BatchItem {
+	if groupID := record.Attributes["MessageGroupId"]; groupID != "" {
+		return eventbus.BatchItem{ID: record.MessageId, OrderKey: "group:" + groupID, FIFO: true}
+	}
+
+	var msgBody eventbus.Message
+	if err := json.Unmarshal([]byte(record.Body), &msgBody); err != nil {
+		return eventbus.BatchItem{ID: record.MessageId}
+	}
+	return eventbus.BatchItem{ID: record.MessageId, OrderKey: eventbus.SenderOrderKey(msgBody.ChainType, msgBody.FromAddress)}
+}
+
+// processMessage processa uma única entrega da mensagem; retorna erro quando ela deve continuar na fila:
+// falhas transitórias (com visibilidade em backoff) ou corpo inválido, que fica para a redrive policy da fila
 func processMessage(ctx context.Context, record events.SQSMessage) error {
 	log.Info("processing SQS message",
 		zap.String("message_id", record.MessageId),
@@ -159,47 +153,34 @@ func processMessage(ctx context.Context, record events.SQSMessage) error {
 		return err
 	}
 
-	// Converter para DTO
-	req := &dtos.ExecuteTransactionRequest{
-		OperationID:    msgBody.OperationID,
-		ChainType:      msgBody.ChainType,
-		OperationType:  msgBody.OperationType,
-		FromAddress:    msgBody.FromAddress,
-		ToAddress:      msgBody.ToAddress,
-		Payload:        msgBody.Payload,
-		IdempotencyKey: msgBody.IdempotencyKey,
-	}
-
-	// Executar transação com retry automático
-	err := retryManager.ProcessWithRetry(ctx, &msgBody, func(ctx context.Context) error {
-		response, err := executeUseCase.Execute(ctx, req)
-		if err != nil {
-			return err
-		}
-
-		log.Info("transaction executed successfully",
-			zap.String("operation_id", response.OperationID),
-			zap.String("status", response.Status))
-
-		return nil
+	result := app.RetryManager.ProcessDelivery(ctx, &msgBody, delivery(ctx, record), func(ctx context.Context) error {
+		return app.Execute(ctx, &msgBody)
 	})
 
-	if err != nil {
+	switch result.Outcome {
+	case eventbus.DeliveryRetry:
+		receiptHandle := record.ReceiptHandle
+		if err := app.SQSConsumer.ChangeMessageVisibility(ctx, &receiptHandle, int32(result.Visibility.Seconds())); err != nil {
+			log.Warn("failed to change message visibility, using queue default",
+				zap.String("message_id", record.MessageId),
+				zap.Error(err))
+		}
+		return fmt.Errorf("message will be retried: %w", result.Err)
+	case eventbus.DeliveryDeadLettered:
 		log.Error("transaction processing failed (sent to DLQ)",
-			zap.String("operation_id", req.OperationID),
-			zap.Error(err))
-		// Erro já foi tratado pelo RetryManager (mensagem enviada para DLQ)
-		// Agora deletar da fila principal
-	}
-
-	// Deletar mensagem da fila após processamento (bem-sucedido ou falha)
-	receiptHandle := record.ReceiptHandle
-	if err := sqsConsumer.DeleteMessage(ctx, &receiptHandle); err != nil {
-		log.Error("failed to delete message from SQS",
-			zap.String("message_id", record.MessageId),
-			zap.Error(err))
-		return err
+			zap.String("operation_id", msgBody.OperationID),
+			zap.String("category", string(pkgerrors.CategoryOfError(result.Err))),
+			zap.Error(result.Err))
 	}
 
 	return nil
 }
+
+// delivery dados da entrega do record, com o request ID da invocação Lambda
+func delivery(ctx context.Context, record events.SQSMessage) eventbus.Delivery {
+	d := eventbus.NewDelivery(record.MessageId, record.Attributes)
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		d.RequestID = lc.AwsRequestID
+	}
+	return d
+}
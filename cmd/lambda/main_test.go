@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,8 +26,9 @@ func TestHandler(t *testing.T) {
 			Records: []events.SQSMessage{},
 		}
 
-		err := handler(ctx, event)
+		response, err := handler(ctx, event)
 		assert.NoError(t, err)
+		assert.Empty(t, response.BatchItemFailures)
 	})
 
 	t.Run("handler with invalid message", func(t *testing.T) {
@@ -40,8 +43,10 @@ func TestHandler(t *testing.T) {
 			},
 		}
 
-		err := handler(ctx, event)
-		assert.NoError(t, err) // Handler não retorna erro, apenas loga
+		response, err := handler(ctx, event)
+		assert.NoError(t, err)
+		// Corpo inválido fica na fila para a redrive policy
+		assert.Equal(t, []events.SQSBatchItemFailure{{ItemIdentifier: "msg-123"}}, response.BatchItemFailures)
 	})
 
 	t.Run("handler with valid message but missing fields", func(t *testing.T) {
@@ -64,9 +69,23 @@ func TestHandler(t *testing.T) {
 			},
 		}
 
-		err := handler(ctx, event)
+		_, err := handler(ctx, event)
+		assert.NoError(t, err)
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Run("route SQS event to handler", func(t *testing.T) {
+		payload, _ := json.Marshal(events.SQSEvent{Records: []events.SQSMessage{}})
+
+		_, err := route(context.Background(), payload)
 		assert.NoError(t, err)
 	})
+
+	t.Run("reject unsupported payload", func(t *testing.T) {
+		_, err := route(context.Background(), json.RawMessage(`"not an event"`))
+		assert.Error(t, err)
+	})
 }
 
 func TestProcessMessage(t *testing.T) {
@@ -150,22 +169,23 @@ func TestHandlerWithMultipleRecords(t *testing.T) {
 		},
 	}
 
-	err := handler(ctx, event)
+	response, err := handler(ctx, event)
 	assert.NoError(t, err)
+	assert.Len(t, response.BatchItemFailures, 2)
 }
 
 func TestHandlerEdgeCasesOriginal(t *testing.T) {
 	t.Run("empty records array", func(t *testing.T) {
 		ctx := context.Background()
 		event := events.SQSEvent{Records: []events.SQSMessage{}}
-		err := handler(ctx, event)
+		_, err := handler(ctx, event)
 		assert.NoError(t, err)
 	})
 
 	t.Run("nil context", func(t *testing.T) {
 		event := events.SQSEvent{Records: []events.SQSMessage{}}
 		// Should handle nil context gracefully
-		err := handler(context.Background(), event)
+		_, err := handler(context.Background(), event)
 		assert.NoError(t, err)
 	})
 }
@@ -196,7 +216,7 @@ func TestHandlerEdgeCases(t *testing.T) {
 		event := events.SQSEvent{
 			Records: []events.SQSMessage{},
 		}
-		err := handler(ctx, event)
+		_, err := handler(ctx, event)
 		assert.NoError(t, err)
 	})
 
@@ -227,7 +247,7 @@ func TestHandlerEdgeCases(t *testing.T) {
 			},
 		}
 
-		err := handler(ctx, event)
+		_, err := handler(ctx, event)
 		assert.NoError(t, err)
 	})
 
@@ -242,7 +262,7 @@ func TestHandlerEdgeCases(t *testing.T) {
 		}
 
 		event := events.SQSEvent{Records: records}
-		err := handler(ctx, event)
+		_, err := handler(ctx, event)
 		assert.NoError(t, err)
 	})
 }
@@ -312,3 +332,50 @@ func TestAllChainTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestDelivery(t *testing.T) {
+	record := events.SQSMessage{
+		MessageId:  "msg-1",
+		Attributes: map[string]string{"ApproximateReceiveCount": "3", "ApproximateFirstReceiveTimestamp": "1767225600000"},
+	}
+
+	t.Run("with lambda context", func(t *testing.T) {
+		ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{AwsRequestID: "request-1"})
+
+		d := delivery(ctx, record)
+
+		assert.Equal(t, "msg-1", d.MessageID)
+		assert.Equal(t, 3, d.ReceiveCount)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), d.FirstReceivedAt)
+		assert.Equal(t, "request-1", d.RequestID)
+	})
+
+	t.Run("without lambda context", func(t *testing.T) {
+		d := delivery(context.Background(), events.SQSMessage{})
+
+		assert.Equal(t, 1, d.ReceiveCount)
+		assert.Empty(t, d.RequestID)
+	})
+}
+
+func TestBatchItem(t *testing.T) {
+	t.Run("order by sender", func(t *testing.T) {
+		body, _ := json.Marshal(eventbus.Message{ChainType: "ETHEREUM", FromAddress: "0xABC"})
+
+		item := batchItem(events.SQSMessage{MessageId: "msg-1", Body: string(body)})
+
+		assert.Equal(t, eventbus.BatchItem{ID: "msg-1", OrderKey: "ETHEREUM:0xabc"}, item)
+	})
+
+	t.Run("order by message group on fifo queues", func(t *testing.T) {
+		item := batchItem(events.SQSMessage{MessageId: "msg-1", Body: "{}", Attributes: map[string]string{"MessageGroupId": "wallet-1"}})
+
+		assert.Equal(t, eventbus.BatchItem{ID: "msg-1", OrderKey: "group:wallet-1", FIFO: true}, item)
+	})
+
+	t.Run("invalid body has no order key", func(t *testing.T) {
+		item := batchItem(events.SQSMessage{MessageId: "msg-1", Body: "invalid"})
+
+		assert.Equal(t, eventbus.BatchItem{ID: "msg-1"}, item)
+	})
+}
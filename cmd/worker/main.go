@@ -0,0 +1,133 @@
+package main
+
+// Worker de longa duração que consome a fila SQS fora da Lambda (ECS, Kubernetes ou local com ElasticMQ)
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/bootstrap"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/logger"
+	pkgconfig "github.com/gabrielksneiva/ChainEVM/pkg/config"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"go.uber.org/zap"
+)
+
+func main() {
+	cfg := pkgconfig.LoadConfig()
+
+	log, err := logger.NewLogger(cfg.Environment)
+	if err != nil {
+		panic("failed to initialize logger: " + err.Error())
+	}
+	defer func() { _ = log.Sync() }()
+
+	// SIGTERM (ECS/Kubernetes) ou Ctrl+C interrompe o recebimento; as mensagens em andamento terminam
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	// Initialize AWS config (AWS_ENDPOINT_URL_SQS/AWS_ENDPOINT_URL_DYNAMODB apontam para ElasticMQ/LocalStack)
+	awsCfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		log.Fatal("failed to load AWS config", zap.Error(err))
+	}
+
+	app := bootstrap.New(ctx, cfg, awsCfg, log)
+
+	poller := eventbus.NewPoller(app.SQSConsumer, eventbus.PollerConfig{
+		Concurrency:       cfg.Worker.Concurrency,
+		WaitTime:          cfg.Worker.WaitTime,
+		VisibilityTimeout: cfg.Worker.VisibilityTimeout,
+		HeartbeatInterval: cfg.Worker.HeartbeatInterval,
+		ShutdownTimeout:   cfg.Worker.ShutdownTimeout,
+	}, log)
+
+	log.Info("worker initialized successfully",
+		zap.String("environment", cfg.Environment),
+		zap.String("sqs_queue_url", cfg.SQSQueueURL),
+		zap.String("sqs_dlq_url", cfg.SQSQueueDLQURL),
+		zap.String("dynamodb_table", cfg.DynamoDBTableName),
+		zap.Int("rpc_clients_initialized", len(app.RPCClients)),
+		zap.Int("signers_initialized", len(app.Signers)),
+		zap.Int("concurrency", cfg.Worker.Concurrency),
+	)
+
+	var wg sync.WaitGroup
+	if cfg.Worker.ConfirmationSweepInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runConfirmationSweep(ctx, cfg.Worker.ConfirmationSweepInterval, cfg.ConfirmationSweepLimit, app, log)
+		}()
+	}
+
+	err = poller.Run(ctx, newDeliveryFunc(app.SQSConsumer, app.RetryManager, app.Execute, log))
+	wg.Wait()
+	if err != nil {
+		log.Error("worker stopped with messages in flight", zap.Error(err))
+		os.Exit(1)
+	}
+
+	log.Info("worker stopped")
+}
+
+// newDeliveryFunc processa uma entrega da mensagem: sucesso e falhas terminais (enviadas à DLQ) são
+// removidos da fila pelo poller, falhas transitórias voltam com a visibilidade do backoff e corpo inválido
+// fica para a redrive policy da fila
+func newDeliveryFunc(
+	consumer *eventbus.SQSConsumer,
+	retryManager *eventbus.RetryManager,
+	execute func(ctx context.Context, msg *eventbus.Message) error,
+	log *zap.Logger,
+) eventbus.DeliveryFunc {
+	return func(ctx context.Context, message types.Message) eventbus.DeliveryResult {
+		log.Info("processing SQS message", zap.String("message_id", aws.ToString(message.MessageId)))
+
+		msg, err := consumer.ParseMessage(message)
+		if err != nil {
+			return eventbus.DeliveryResult{Outcome: eventbus.DeliveryRetry, Err: err}
+		}
+
+		result := retryManager.ProcessDelivery(ctx, msg, eventbus.NewDelivery(aws.ToString(message.MessageId), message.Attributes), func(ctx context.Context) error {
+			return execute(ctx, msg)
+		})
+		if result.Outcome == eventbus.DeliveryDeadLettered {
+			log.Error("transaction processing failed (sent to DLQ)",
+				zap.String("operation_id", msg.OperationID),
+				zap.String("category", string(pkgerrors.CategoryOfError(result.Err))),
+				zap.Error(result.Err))
+		}
+		return result
+	}
+}
+
+// runConfirmationSweep verifica as operações SUBMITTED a cada interval até ctx terminar
+func runConfirmationSweep(ctx context.Context, interval time.Duration, limit int, app *bootstrap.App, log *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			finalized, err := app.ConfirmUseCase.ConfirmPending(ctx, limit)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					log.Error("confirmation sweep failed", zap.Error(err))
+				}
+				continue
+			}
+			log.Info("confirmation sweep completed", zap.Int("finalized", finalized))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockDLQSender mock do envio para a DLQ
+type MockDLQSender struct {
+	mock.Mock
+}
+
+func (m *MockDLQSender) SendMessage(ctx context.Context, deadLetter *eventbus.DeadLetterMessage) error {
+	args := m.Called(ctx, deadLetter)
+	return args.Error(0)
+}
+
+func sqsMessage(body string, receiveCount string) types.Message {
+	id, receipt := "msg-1", "receipt-1"
+	return types.Message{
+		MessageId:     &id,
+		ReceiptHandle: &receipt,
+		Body:          &body,
+		Attributes:    map[string]string{"ApproximateReceiveCount": receiveCount},
+	}
+}
+
+func TestNewDeliveryFunc(t *testing.T) {
+	log := zap.NewNop()
+	consumer := eventbus.NewSQSConsumer(nil, "queue-url", log)
+	body := `{"operation_id":"op-1","chain_type":"ETHEREUM","operation_type":"TRANSFER"}`
+
+	t.Run("execute message", func(t *testing.T) {
+		dlq := new(MockDLQSender)
+		var executed *eventbus.Message
+		deliver := newDeliveryFunc(consumer, eventbus.NewRetryManager(dlq, 3, log), func(ctx context.Context, msg *eventbus.Message) error {
+			executed = msg
+			return nil
+		}, log)
+
+		result := deliver(context.Background(), sqsMessage(body, "1"))
+
+		assert.Equal(t, eventbus.DeliverySucceeded, result.Outcome)
+		assert.Equal(t, "op-1", executed.OperationID)
+		dlq.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("retry transient failure with backoff", func(t *testing.T) {
+		dlq := new(MockDLQSender)
+		deliver := newDeliveryFunc(consumer, eventbus.NewRetryManager(dlq, 3, log), func(ctx context.Context, msg *eventbus.Message) error {
+			return pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "rpc timeout", nil)
+		}, log)
+
+		result := deliver(context.Background(), sqsMessage(body, "2"))
+
+		assert.Equal(t, eventbus.DeliveryRetry, result.Outcome)
+		assert.Positive(t, result.Visibility)
+		dlq.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dead letter permanent failure", func(t *testing.T) {
+		dlq := new(MockDLQSender)
+		dlq.On("SendMessage", mock.Anything, mock.Anything).Return(nil).Once()
+		deliver := newDeliveryFunc(consumer, eventbus.NewRetryManager(dlq, 3, log), func(ctx context.Context, msg *eventbus.Message) error {
+			return pkgerrors.NewAppError(pkgerrors.ErrExecutionReverted.Code, "execution reverted", nil)
+		}, log)
+
+		result := deliver(context.Background(), sqsMessage(body, "1"))
+
+		assert.Equal(t, eventbus.DeliveryDeadLettered, result.Outcome)
+		dlq.AssertExpectations(t)
+	})
+
+	t.Run("leave invalid body to the redrive policy", func(t *testing.T) {
+		dlq := new(MockDLQSender)
+		deliver := newDeliveryFunc(consumer, eventbus.NewRetryManager(dlq, 3, log), func(ctx context.Context, msg *eventbus.Message) error {
+			return errors.New("should not be called")
+		}, log)
+
+		result := deliver(context.Background(), sqsMessage("invalid json", "1"))
+
+		assert.Equal(t, eventbus.DeliveryRetry, result.Outcome)
+		assert.Zero(t, result.Visibility)
+		assert.Error(t, result.Err)
+	})
+}
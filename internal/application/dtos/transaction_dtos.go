@@ -13,16 +13,23 @@ type ExecuteTransactionRequest struct {
 
 // ExecuteTransactionResponse resposta quando transação é executada
 type ExecuteTransactionResponse struct {
-	OperationID     string  `json:"operation_id"`
-	ChainType       string  `json:"chain_type"`
-	TransactionHash string  `json:"transaction_hash,omitempty"`
-	Status          string  `json:"status"`
-	BlockNumber     *int64  `json:"block_number,omitempty"`
-	GasUsed         *int64  `json:"gas_used,omitempty"`
-	GasPrice        *string `json:"gas_price,omitempty"`
-	ErrorMessage    string  `json:"error_message,omitempty"`
-	CreatedAt       string  `json:"created_at"`
-	ExecutedAt      *string `json:"executed_at,omitempty"`
+	OperationID          string   `json:"operation_id"`
+	ChainType            string   `json:"chain_type"`
+	TransactionHash      string   `json:"transaction_hash,omitempty"`
+	Status               string   `json:"status"`
+	BlockNumber          *int64   `json:"block_number,omitempty"`
+	BlockHash            *string  `json:"block_hash,omitempty"`
+	GasUsed              *int64   `json:"gas_used,omitempty"`
+	GasPrice             *string  `json:"gas_price,omitempty"`
+	MaxFeePerGas         *string  `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *string  `json:"max_priority_fee_per_gas,omitempty"`
+	GasEstimate          *int64   `json:"gas_estimate,omitempty"`
+	ReplacementHashes    []string `json:"replacement_hashes,omitempty"`
+	ErrorMessage         string   `json:"error_message,omitempty"`
+	CreatedAt            string   `json:"created_at"`
+	ExecutedAt           *string  `json:"executed_at,omitempty"`
+	// QueryResult resultado de operações de leitura (GET_BALANCE, GET_NONCE, ESTIMATE_GAS, QUERY)
+	QueryResult *QueryResultResponse `json:"query_result,omitempty"`
 }
 
 // QueryResultResponse resposta para operações de leitura
@@ -0,0 +1,335 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/application/dtos"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// defaultConfirmationDepth confirmações exigidas quando a chain não tem profundidade configurada
+const defaultConfirmationDepth = 12
+
+// ConfirmTransactionUseCase acompanha as transações SUBMITTED até a profundidade de confirmação da chain
+type ConfirmTransactionUseCase struct {
+	rpcClients         map[string]rpc.RPCClient
+	transactionRepo    database.TransactionRepository
+	txMonitors         map[string]rpc.TransactionMonitor
+	confirmationDepths map[string]int
+	publisher          events.EventPublisher
+	logger             *zap.Logger
+
+	// cursor posição da próxima varredura no índice de SUBMITTED (vazio = mais antigas)
+	mu     sync.Mutex
+	cursor string
+}
+
+// NewConfirmTransactionUseCase cria o caso de uso; publisher pode ser nil
+func NewConfirmTransactionUseCase(
+	rpcClients map[string]rpc.RPCClient,
+	transactionRepo database.TransactionRepository,
+	txMonitors map[string]rpc.TransactionMonitor,
+	confirmationDepths map[string]int,
+	publisher events.EventPublisher,
+	logger *zap.Logger,
+) *ConfirmTransactionUseCase {
+	return &ConfirmTransactionUseCase{
+		rpcClients:         rpcClients,
+		transactionRepo:    transactionRepo,
+		txMonitors:         txMonitors,
+		confirmationDepths: confirmationDepths,
+		publisher:          publisher,
+		logger:             logger,
+	}
+}
+
+// ConfirmPending verifica até limit operações SUBMITTED, continuando de onde a varredura anterior parou e
+// voltando às mais antigas ao chegar ao fim, para que operações presas não impeçam as mais novas de serem
+// confirmadas; retorna quantas foram finalizadas
+func (uc *ConfirmTransactionUseCase) ConfirmPending(ctx context.Context, limit int) (int, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	transactions, next, err := uc.transactionRepo.ListByStatus(ctx, entities.TransactionStatusSubmitted, limit, uc.cursor)
+	if err != nil {
+		uc.logger.Error("failed to list submitted transactions", zap.Error(err))
+		// Cursor inválido ou expirado recomeça pelas mais antigas
+		uc.cursor = ""
+		return 0, pkgerrors.FromDatabaseError("failed to list submitted transactions", err)
+	}
+	uc.cursor = next
+
+	finalized := 0
+	for _, transaction := range transactions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		done, appErr := uc.confirm(ctx, transaction)
+		if appErr != nil {
+			uc.logger.Error("failed to check transaction confirmations",
+				zap.String("operation_id", transaction.OperationID().String()),
+				zap.Error(appErr))
+			continue
+		}
+		if done {
+			finalized++
+		}
+	}
+
+	uc.logger.Info("confirmation sweep finished",
+		zap.Int("submitted", len(transactions)),
+		zap.Int("finalized", finalized),
+		zap.Bool("more", next != ""))
+	return finalized, nil
+}
+
+// Confirm verifica uma única operação (ex.: mensagem SQS com atraso)
+func (uc *ConfirmTransactionUseCase) Confirm(ctx context.Context, operationID string) (*dtos.ExecuteTransactionResponse, error) {
+	transaction, err := uc.transactionRepo.GetByOperationID(ctx, operationID)
+	if err != nil || transaction == nil {
+		uc.logger.Error("transaction not found", zap.String("operation_id", operationID), zap.Error(err))
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrOperationNotFound.Code, "transaction not found", err)
+	}
+
+	if transaction.Status() == entities.TransactionStatusSubmitted {
+		if _, appErr := uc.confirm(ctx, transaction); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return buildResponse(transaction), nil
+}
+
+// confirm consulta o receipt e finaliza a operação quando atinge a profundidade exigida;
+// finalized indica que a operação saiu de SUBMITTED (CONFIRMED ou FAILED)
+func (uc *ConfirmTransactionUseCase) confirm(ctx context.Context, transaction *entities.EVMTransaction) (finalized bool, appErr *pkgerrors.AppError) {
+	chainType := transaction.ChainType().String()
+	operationID := transaction.OperationID().String()
+
+	rpcClient, ok := uc.rpcClients[chainType]
+	if !ok {
+		return false, pkgerrors.NewAppError(pkgerrors.ErrChainNotSupported.Code, "chain not supported", nil)
+	}
+
+	receipt, minedHash, err := uc.findReceipt(ctx, rpcClient, transaction)
+	if err != nil {
+		// Falha transitória: sem certeza de que o receipt sumiu, a operação fica como está
+		uc.logger.Error("failed to get transaction receipt", zap.String("operation_id", operationID), zap.Error(err))
+		return false, rpcAppError("failed to get transaction receipt", err)
+	}
+	if receipt == nil {
+		if transaction.BlockHash() != nil {
+			// Minerada antes, mas o receipt sumiu: o bloco saiu da chain canônica
+			if appErr := uc.reorg(ctx, transaction); appErr != nil {
+				return false, appErr
+			}
+		}
+		uc.replaceIfStuck(ctx, transaction)
+		return false, nil
+	}
+
+	blockNumber := receipt.BlockNumber.Uint64()
+	blockHash := receipt.BlockHash.Hex()
+	if recorded := transaction.BlockHash(); recorded == nil || *recorded != blockHash {
+		if recorded != nil {
+			// Minerada novamente em outro bloco após um reorg
+			if appErr := uc.reorg(ctx, transaction); appErr != nil {
+				return false, appErr
+			}
+		}
+		transaction.SetInclusion(int64(blockNumber), blockHash)
+		if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
+			uc.logger.Error("failed to save transaction inclusion", zap.Error(err))
+			return false, pkgerrors.FromDatabaseError("failed to update transaction", err)
+		}
+	}
+
+	head, err := rpcClient.GetBlockNumber(ctx)
+	if err != nil {
+		uc.logger.Error("failed to get block number", zap.Error(err))
+		return false, rpcAppError("failed to get block number", err)
+	}
+
+	confirmations := 0
+	if head >= blockNumber {
+		confirmations = int(head-blockNumber) + 1
+	}
+	required := uc.confirmationDepth(chainType)
+	if confirmations < required {
+		uc.logger.Debug("waiting for confirmations",
+			zap.String("operation_id", operationID),
+			zap.String("tx_hash", minedHash),
+			zap.Int("confirmations", confirmations),
+			zap.Int("required", required))
+		return false, nil
+	}
+
+	// Confere se o bloco ainda é canônico antes de finalizar
+	canonicalHash, err := rpcClient.GetBlockHash(ctx, blockNumber)
+	if err != nil {
+		uc.logger.Error("failed to get canonical block hash", zap.Error(err))
+		return false, rpcAppError("failed to get block hash", err)
+	}
+	if canonicalHash != blockHash {
+		return false, uc.reorg(ctx, transaction)
+	}
+
+	txHash, hashErr := valueobjects.NewTransactionHash(minedHash)
+	if hashErr != nil {
+		uc.logger.Error("failed to create transaction hash", zap.Error(hashErr))
+	}
+
+	// Registra hash, bloco e gas da versão minerada (original ou substituta), inclusive em reverts
+	transaction.MarkAsSuccess(txHash, int64(blockNumber), int64(receipt.GasUsed))
+
+	var event events.DomainEvent
+	if receipt.Status == types.ReceiptStatusFailed {
+		transaction.MarkAsFailed("transaction reverted")
+		event = events.NewTransactionFailedEvent(operationID, chainType, "transaction reverted")
+	} else {
+		transaction.MarkAsConfirmed()
+		event = events.NewTransactionConfirmedEvent(operationID, chainType, confirmations)
+	}
+
+	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
+		uc.logger.Error("failed to update transaction", zap.Error(err))
+		return false, pkgerrors.FromDatabaseError("failed to update transaction", err)
+	}
+	if monitor, ok := uc.txMonitors[chainType]; ok {
+		monitor.Forget(operationID)
+	}
+
+	uc.logger.Info("transaction finalized",
+		zap.String("operation_id", operationID),
+		zap.String("tx_hash", minedHash),
+		zap.String("status", string(transaction.Status())),
+		zap.Int("confirmations", confirmations))
+	uc.publish(ctx, event)
+	return true, nil
+}
+
+// reorg devolve a operação para SUBMITTED sem o bloco que saiu da chain canônica; a transação volta ao
+// mempool do node e, se não for minerada novamente, é reenviada pela substituição de transações presas
+func (uc *ConfirmTransactionUseCase) reorg(ctx context.Context, transaction *entities.EVMTransaction) *pkgerrors.AppError {
+	event := events.NewTransactionReorgedEvent(
+		transaction.OperationID().String(),
+		transaction.ChainType().String(),
+		transaction.TxHash().String(),
+		*transaction.BlockNumber(),
+		*transaction.BlockHash(),
+	)
+
+	transaction.MarkAsReorged()
+	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
+		uc.logger.Error("failed to save reorged transaction", zap.Error(err))
+		return pkgerrors.FromDatabaseError("failed to update transaction", err)
+	}
+
+	uc.logger.Warn("transaction block reorged out of canonical chain",
+		zap.String("operation_id", event.OperationID),
+		zap.String("chain_type", event.ChainType),
+		zap.Int64("block_number", event.BlockNumber),
+		zap.String("block_hash", event.BlockHash))
+	uc.publish(ctx, event)
+	return nil
+}
+
+// findReceipt procura o receipt de todas as versões enviadas, da mais recente à original; usa o monitor
+// quando ele acompanha a operação nesta instância e, caso contrário, os hashes persistidos. Só retorna
+// "sem receipt" quando todos os hashes respondem ethereum.NotFound; outras falhas de RPC retornam erro
+func (uc *ConfirmTransactionUseCase) findReceipt(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	transaction *entities.EVMTransaction,
+) (*types.Receipt, string, error) {
+	if monitor, ok := uc.txMonitors[transaction.ChainType().String()]; ok {
+		receipt, txHash, err := monitor.Receipt(ctx, transaction.OperationID().String())
+		if !errors.Is(err, rpc.ErrTransactionNotTracked) {
+			return receipt, txHash, err
+		}
+	}
+
+	var rpcErr error
+	hashes := append([]string{transaction.TxHash().String()}, transaction.ReplacementHashes()...)
+	for i := len(hashes) - 1; i >= 0; i-- {
+		if hashes[i] == "" {
+			continue
+		}
+		receipt, err := rpcClient.GetTransactionReceipt(ctx, hashes[i])
+		if err == nil && receipt != nil {
+			return receipt, hashes[i], nil
+		}
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			rpcErr = err
+		}
+	}
+	return nil, "", rpcErr
+}
+
+// replaceIfStuck substitui a transação presa no mempool e registra o novo hash na operação
+func (uc *ConfirmTransactionUseCase) replaceIfStuck(ctx context.Context, transaction *entities.EVMTransaction) {
+	monitor, ok := uc.txMonitors[transaction.ChainType().String()]
+	if !ok {
+		return
+	}
+
+	operationID := transaction.OperationID().String()
+	// Enviada por outra instância (ou antes de um cold start): reconstrói a partir do registro persistido
+	if err := restoreTracking(monitor, transaction); err != nil {
+		uc.logger.Warn("untracked transaction cannot be replaced",
+			zap.String("operation_id", operationID),
+			zap.Error(err))
+		return
+	}
+
+	replacementHash, replaced, err := monitor.ReplaceIfStuck(ctx, operationID)
+	if err != nil {
+		uc.logger.Error("failed to replace stuck transaction",
+			zap.String("operation_id", operationID),
+			zap.Error(err))
+		return
+	}
+	if !replaced {
+		return
+	}
+
+	transaction.AddReplacementHash(replacementHash)
+	if pending, ok := monitor.Pending(operationID); ok {
+		recordBroadcast(transaction, pending.Tx, pending.LastBroadcastAt, uc.logger)
+	}
+	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
+		uc.logger.Error("failed to save replacement transaction", zap.Error(err))
+	}
+}
+
+// confirmationDepth confirmações exigidas pela chain
+func (uc *ConfirmTransactionUseCase) confirmationDepth(chainType string) int {
+	if depth, ok := uc.confirmationDepths[chainType]; ok && depth > 0 {
+		return depth
+	}
+	return defaultConfirmationDepth
+}
+
+// publish publica o evento; falhas não desfazem a transição já persistida
+func (uc *ConfirmTransactionUseCase) publish(ctx context.Context, event events.DomainEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("failed to publish event",
+			zap.String("event_type", event.EventType()),
+			zap.String("operation_id", event.AggregateID()),
+			zap.Error(err))
+	}
+}
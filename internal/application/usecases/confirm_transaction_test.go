@@ -0,0 +1,541 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// MockEventPublisher implementa events.EventPublisher
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestConfirmTransactionUseCase_Confirm(t *testing.T) {
+	logger := zap.NewNop()
+	operationID := "550e8400-e29b-41d4-a716-446655440600"
+	fromAddress := "0x1234567890123456789012345678901234567890"
+	hashA := "0x00000000000000000000000000000000000000000000000000000000000000aa"
+	hashB := "0x00000000000000000000000000000000000000000000000000000000000000bb"
+	blockHash := common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000b01")
+	reorgedBlockHash := common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000b02")
+
+	newSubmittedTransaction := func(chain string, replacementHashes ...string) *entities.EVMTransaction {
+		opID, _ := valueobjects.NewOperationID(operationID)
+		chainType, _ := valueobjects.NewChainType(chain)
+		opType, _ := valueobjects.NewOperationType("TRANSFER")
+		fromAddr, _ := valueobjects.NewEVMAddress(fromAddress)
+		toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+		tx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, nil, "key")
+		tx.SetTxMetadata("10000000000", 4)
+		txHash, _ := valueobjects.NewTransactionHash(hashA)
+		tx.MarkAsSubmitted(txHash)
+		for _, replacementHash := range replacementHashes {
+			tx.AddReplacementHash(replacementHash)
+		}
+		return tx
+	}
+
+	t.Run("confirm at chain depth and publish event", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(
+			map[string]rpc.RPCClient{"POLYGON": mockRPC},
+			mockRepo,
+			nil,
+			map[string]int{"POLYGON": 3},
+			publisher,
+			logger,
+		)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newSubmittedTransaction("POLYGON"), nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+			BlockHash:   blockHash,
+			GasUsed:     21000,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(102), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(blockHash.Hex(), nil)
+		publisher.On("Publish", mock.Anything, mock.MatchedBy(func(event *events.TransactionConfirmedEvent) bool {
+			return event.OperationID == operationID && event.ChainType == "POLYGON" && event.Confirmations == 3
+		})).Return(nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusConfirmed), resp.Status)
+		assert.Equal(t, hashA, resp.TransactionHash)
+		assert.Equal(t, int64(100), *resp.BlockNumber)
+		assert.Equal(t, int64(21000), *resp.GasUsed)
+		assert.Equal(t, blockHash.Hex(), *resp.BlockHash)
+		mockRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("record inclusion and stay submitted below required depth", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, logger)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newSubmittedTransaction("ETHEREUM"), nil)
+		mockRepo.On("Save", mock.Anything, mock.MatchedBy(func(tx *entities.EVMTransaction) bool {
+			return tx.Status() == entities.TransactionStatusSubmitted && *tx.BlockHash() == blockHash.Hex()
+		})).Return(nil).Once()
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+			BlockHash:   blockHash,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(110), nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Equal(t, int64(100), *resp.BlockNumber)
+		mockRepo.AssertExpectations(t)
+		mockRPC.AssertNotCalled(t, "GetBlockHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skip saving inclusion already recorded", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, logger)
+
+		transaction := newSubmittedTransaction("ETHEREUM")
+		transaction.SetInclusion(100, blockHash.Hex())
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+			BlockHash:   blockHash,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(105), nil)
+
+		_, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("revert to submitted when block is no longer canonical", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"POLYGON": mockRPC}, mockRepo, nil, map[string]int{"POLYGON": 3}, publisher, logger)
+
+		transaction := newSubmittedTransaction("POLYGON")
+		transaction.SetInclusion(100, blockHash.Hex())
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+			BlockHash:   blockHash,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(110), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(reorgedBlockHash.Hex(), nil)
+		publisher.On("Publish", mock.Anything, mock.MatchedBy(func(event *events.TransactionReorgedEvent) bool {
+			return event.BlockNumber == 100 && event.BlockHash == blockHash.Hex() && event.TransactionHash == hashA
+		})).Return(nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Nil(t, resp.BlockNumber)
+		assert.Nil(t, resp.BlockHash)
+		mockRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("revert to submitted when receipt disappears", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"BSC": mockRPC}, mockRepo, nil, nil, publisher, logger)
+
+		transaction := newSubmittedTransaction("BSC")
+		transaction.SetInclusion(100, blockHash.Hex())
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(nil, ethereum.NotFound)
+		publisher.On("Publish", mock.Anything, mock.AnythingOfType("*events.TransactionReorgedEvent")).Return(nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Nil(t, resp.BlockHash)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("keep inclusion on transient receipt error", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"BSC": mockRPC}, mockRepo, nil, nil, publisher, logger)
+
+		transaction := newSubmittedTransaction("BSC", hashB)
+		transaction.SetInclusion(100, blockHash.Hex())
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashB).Return(nil, ethereum.NotFound)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(nil, errors.New("connection reset by peer"))
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrRPCFailed.Code, appErr.Code)
+		assert.Equal(t, blockHash.Hex(), *transaction.BlockHash())
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		publisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+
+	t.Run("record new inclusion when mined again in another block", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"POLYGON": mockRPC}, mockRepo, nil, map[string]int{"POLYGON": 3}, publisher, logger)
+
+		transaction := newSubmittedTransaction("POLYGON")
+		transaction.SetInclusion(100, reorgedBlockHash.Hex())
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Times(2)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(101),
+			BlockHash:   blockHash,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(101), nil)
+		publisher.On("Publish", mock.Anything, mock.MatchedBy(func(event *events.TransactionReorgedEvent) bool {
+			return event.BlockNumber == 100 && event.BlockHash == reorgedBlockHash.Hex()
+		})).Return(nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Equal(t, int64(101), *resp.BlockNumber)
+		assert.Equal(t, blockHash.Hex(), *resp.BlockHash)
+		mockRepo.AssertExpectations(t)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("fail reverted transaction", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		publisher := new(MockEventPublisher)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, map[string]int{"ETHEREUM": 1}, publisher, logger)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newSubmittedTransaction("ETHEREUM"), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(&types.Receipt{
+			Status:      types.ReceiptStatusFailed,
+			BlockNumber: big.NewInt(100),
+			GasUsed:     30000,
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(100), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(common.Hash{}.Hex(), nil)
+		publisher.On("Publish", mock.Anything, mock.AnythingOfType("*events.TransactionFailedEvent")).Return(nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusFailed), resp.Status)
+		assert.Equal(t, "transaction reverted", resp.ErrorMessage)
+		assert.Equal(t, int64(30000), *resp.GasUsed)
+		publisher.AssertExpectations(t)
+	})
+
+	t.Run("find mined replacement from persisted hashes", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, map[string]int{"ETHEREUM": 1}, nil, logger)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newSubmittedTransaction("ETHEREUM", hashB), nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashB).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(100), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(common.Hash{}.Hex(), nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusConfirmed), resp.Status)
+		assert.Equal(t, hashB, resp.TransactionHash)
+		mockRPC.AssertNotCalled(t, "GetTransactionReceipt", mock.Anything, hashA)
+	})
+
+	t.Run("replace stuck transaction and confirm replacement", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		monitor := rpc.NewPendingTxMonitor(mockRPC, mockSigner, nil, rpc.ReplacementPolicy{MaxReplacements: 1}, logger)
+		to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+		monitor.Track(operationID, fromAddress, types.NewTx(&types.LegacyTx{
+			Nonce:    4,
+			GasPrice: big.NewInt(10000000000),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}), hashA)
+
+		useCase := NewConfirmTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.TransactionMonitor{"ETHEREUM": monitor},
+			map[string]int{"ETHEREUM": 2},
+			nil,
+			logger,
+		)
+
+		transaction := newSubmittedTransaction("ETHEREUM")
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(nil, ethereum.NotFound)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashB).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(20),
+			GasUsed:     21000,
+		}, nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(21), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(20)).Return(common.Hash{}.Hex(), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4 && tx.GasPrice().Cmp(big.NewInt(11000000000)) >= 0
+		}), fromAddress).Return(hashB, nil).Once()
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Equal(t, []string{hashB}, resp.ReplacementHashes)
+
+		resp, err = useCase.Confirm(context.Background(), operationID)
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusConfirmed), resp.Status)
+		assert.Equal(t, hashB, resp.TransactionHash)
+		_, tracked := monitor.Pending(operationID)
+		assert.False(t, tracked)
+	})
+
+	t.Run("replace stuck transaction sent by another instance from persisted record", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		// Monitor desta instância não acompanha a operação (ex.: cold start)
+		monitor := rpc.NewPendingTxMonitor(mockRPC, mockSigner, nil, rpc.ReplacementPolicy{StuckAfter: time.Minute, MaxReplacements: 1}, logger)
+		useCase := NewConfirmTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.TransactionMonitor{"ETHEREUM": monitor},
+			nil,
+			nil,
+			logger,
+		)
+
+		to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+		raw, err := rpc.EncodeTransaction(types.NewTx(&types.LegacyTx{
+			Nonce:    4,
+			GasPrice: big.NewInt(10000000000),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}))
+		require.NoError(t, err)
+		transaction := newSubmittedTransaction("ETHEREUM")
+		transaction.SetBroadcast(raw, time.Now().Add(-time.Hour))
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(nil, ethereum.NotFound)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4 && tx.Value().Int64() == 1 && tx.GasPrice().Cmp(big.NewInt(11000000000)) >= 0
+		}), fromAddress).Return(hashB, nil).Once()
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{hashB}, resp.ReplacementHashes)
+		// A substituta é persistida para a próxima instância
+		assert.NotEqual(t, raw, *transaction.RawTransaction())
+		assert.WithinDuration(t, time.Now(), *transaction.LastBroadcastAt(), time.Minute)
+		assert.Equal(t, "11000000000", *transaction.GasPrice())
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("log untracked transaction without persisted record", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		core, logs := observer.New(zap.WarnLevel)
+		monitor := rpc.NewPendingTxMonitor(mockRPC, mockSigner, nil, rpc.ReplacementPolicy{MaxReplacements: 1}, logger)
+		useCase := NewConfirmTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.TransactionMonitor{"ETHEREUM": monitor},
+			nil,
+			nil,
+			zap.New(core),
+		)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newSubmittedTransaction("ETHEREUM"), nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, hashA).Return(nil, ethereum.NotFound)
+
+		_, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, logs.FilterMessage("untracked transaction cannot be replaced").Len())
+		mockSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("return finalized operation unchanged", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(nil, mockRepo, nil, nil, nil, logger)
+
+		transaction := newSubmittedTransaction("ETHEREUM")
+		transaction.MarkAsConfirmed()
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(transaction, nil)
+
+		resp, err := useCase.Confirm(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusConfirmed), resp.Status)
+	})
+
+	t.Run("operation not found", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(nil, mockRepo, nil, nil, nil, logger)
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(nil, errors.New("not found"))
+
+		_, err := useCase.Confirm(context.Background(), operationID)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrOperationNotFound.Code, appErr.Code)
+	})
+}
+
+func TestConfirmTransactionUseCase_ConfirmPending(t *testing.T) {
+	logger := zap.NewNop()
+
+	newSubmittedTransaction := func(operationID string, hash string) *entities.EVMTransaction {
+		opID, _ := valueobjects.NewOperationID(operationID)
+		chainType, _ := valueobjects.NewChainType("ETHEREUM")
+		opType, _ := valueobjects.NewOperationType("TRANSFER")
+		fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+		toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+		tx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, nil, "key-"+operationID)
+		txHash, _ := valueobjects.NewTransactionHash(hash)
+		tx.MarkAsSubmitted(txHash)
+		return tx
+	}
+
+	t.Run("finalize confirmed operations and keep the rest", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, map[string]int{"ETHEREUM": 12}, nil, logger)
+
+		minedHash := "0x00000000000000000000000000000000000000000000000000000000000000aa"
+		pendingHash := "0x00000000000000000000000000000000000000000000000000000000000000bb"
+		mockRepo.On("ListByStatus", mock.Anything, entities.TransactionStatusSubmitted, 50, "").Return([]*entities.EVMTransaction{
+			newSubmittedTransaction("550e8400-e29b-41d4-a716-446655440701", minedHash),
+			newSubmittedTransaction("550e8400-e29b-41d4-a716-446655440702", pendingHash),
+		}, "", nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Times(2)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, minedHash).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+		}, nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, pendingHash).Return(nil, ethereum.NotFound)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(111), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(common.Hash{}.Hex(), nil)
+
+		finalized, err := useCase.ConfirmPending(context.Background(), 50)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, finalized)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rotate through submitted operations between sweeps", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, map[string]int{"ETHEREUM": 12}, nil, logger)
+
+		stuckHash := "0x00000000000000000000000000000000000000000000000000000000000000cc"
+		newerHash := "0x00000000000000000000000000000000000000000000000000000000000000dd"
+		mockRepo.On("ListByStatus", mock.Anything, entities.TransactionStatusSubmitted, 1, "").Return([]*entities.EVMTransaction{
+			newSubmittedTransaction("550e8400-e29b-41d4-a716-446655440703", stuckHash),
+		}, "cursor-1", nil).Once()
+		mockRepo.On("ListByStatus", mock.Anything, entities.TransactionStatusSubmitted, 1, "cursor-1").Return([]*entities.EVMTransaction{
+			newSubmittedTransaction("550e8400-e29b-41d4-a716-446655440704", newerHash),
+		}, "", nil).Once()
+		mockRepo.On("ListByStatus", mock.Anything, entities.TransactionStatusSubmitted, 1, "").Return([]*entities.EVMTransaction{}, "", nil).Once()
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, stuckHash).Return(nil, ethereum.NotFound)
+		mockRPC.On("GetTransactionReceipt", mock.Anything, newerHash).Return(&types.Receipt{
+			Status:      types.ReceiptStatusSuccessful,
+			BlockNumber: big.NewInt(100),
+		}, nil)
+		mockRPC.On("GetBlockNumber", mock.Anything).Return(uint64(111), nil)
+		mockRPC.On("GetBlockHash", mock.Anything, uint64(100)).Return(common.Hash{}.Hex(), nil)
+
+		// A operação presa ocupa a primeira varredura; a seguinte continua pela mais nova
+		first, err := useCase.ConfirmPending(context.Background(), 1)
+		require.NoError(t, err)
+		second, err := useCase.ConfirmPending(context.Background(), 1)
+		require.NoError(t, err)
+		// Ao chegar ao fim volta às mais antigas
+		_, err = useCase.ConfirmPending(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, first)
+		assert.Equal(t, 1, second)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("fail when listing fails", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewConfirmTransactionUseCase(nil, mockRepo, nil, nil, nil, logger)
+		mockRepo.On("ListByStatus", mock.Anything, entities.TransactionStatusSubmitted, 50, "").Return(nil, "", errors.New("throttled"))
+
+		_, err := useCase.ConfirmPending(context.Background(), 50)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrDatabaseError.Code, appErr.Code)
+	})
+}
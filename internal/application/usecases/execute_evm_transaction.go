@@ -2,12 +2,19 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"math/big"
+	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gabrielksneiva/ChainEVM/internal/application/dtos"
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/entities"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/nonce"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
 	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"go.uber.org/zap"
@@ -17,21 +24,39 @@ import (
 type ExecuteEVMTransactionUseCase struct {
 	rpcClients      map[string]rpc.RPCClient
 	transactionRepo database.TransactionRepository
-	signer          rpc.SignedTransactionClient
+	signers         map[string]rpc.SignedTransactionClient
+	feeEstimators   map[string]rpc.FeeEstimator
+	gasEstimators   map[string]rpc.GasEstimator
+	nonceManager    nonce.Manager
+	txMonitors      map[string]rpc.TransactionMonitor
+	publisher       events.EventPublisher
 	logger          *zap.Logger
 }
 
-// NewExecuteEVMTransactionUseCase cria uma nova instância do caso de uso
+// maxNonceResyncs reenvios após ressincronizar o nonce com o node ("nonce too low")
+const maxNonceResyncs = 2
+
+// NewExecuteEVMTransactionUseCase cria uma nova instância do caso de uso; publisher pode ser nil
 func NewExecuteEVMTransactionUseCase(
 	rpcClients map[string]rpc.RPCClient,
 	transactionRepo database.TransactionRepository,
-	signer rpc.SignedTransactionClient,
+	signers map[string]rpc.SignedTransactionClient,
+	feeEstimators map[string]rpc.FeeEstimator,
+	gasEstimators map[string]rpc.GasEstimator,
+	nonceManager nonce.Manager,
+	txMonitors map[string]rpc.TransactionMonitor,
+	publisher events.EventPublisher,
 	logger *zap.Logger,
 ) *ExecuteEVMTransactionUseCase {
 	return &ExecuteEVMTransactionUseCase{
 		rpcClients:      rpcClients,
 		transactionRepo: transactionRepo,
-		signer:          signer,
+		signers:         signers,
+		feeEstimators:   feeEstimators,
+		gasEstimators:   gasEstimators,
+		nonceManager:    nonceManager,
+		txMonitors:      txMonitors,
+		publisher:       publisher,
 		logger:          logger,
 	}
 }
@@ -78,12 +103,19 @@ func (uc *ExecuteEVMTransactionUseCase) Execute(
 		return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
 	}
 
-	// Verificar idempotência - check se a transação já foi processada
+	// Verificar idempotência - check se a transação já foi processada; PENDING indica que a tentativa
+	// anterior falhou por erro transitório e a transação deve ser reprocessada
 	existingTx, err := uc.transactionRepo.GetByIdempotencyKey(ctx, req.IdempotencyKey)
-	if err == nil && existingTx != nil {
-		uc.logger.Info("transaction already processed (idempotent)",
-			zap.String("idempotency_key", req.IdempotencyKey))
-		return buildResponse(existingTx), nil
+	retrying := err == nil && existingTx != nil
+	if retrying {
+		if existingTx.Status() != entities.TransactionStatusPending {
+			uc.logger.Info("transaction already processed (idempotent)",
+				zap.String("idempotency_key", req.IdempotencyKey))
+			return buildResponse(existingTx), nil
+		}
+		uc.logger.Info("retrying transaction after transient failure",
+			zap.String("idempotency_key", req.IdempotencyKey),
+			zap.String("last_error", existingTx.ErrorMessage()))
 	}
 
 	// Criar entidade de domínio
@@ -103,22 +135,27 @@ func (uc *ExecuteEVMTransactionUseCase) Execute(
 	// Salvar transação no banco
 	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
 		uc.logger.Error("failed to save transaction", zap.Error(err))
-		transaction.MarkAsFailed("database error")
-		if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-			uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-		}
-		return nil, pkgerrors.NewAppError(pkgerrors.ErrDatabaseError.Code, "failed to save transaction", err)
+		return nil, uc.failTransaction(ctx, transaction, "database error",
+			pkgerrors.FromDatabaseError("failed to save transaction", err))
 	}
+	if !retrying {
+		uc.publish(ctx, events.NewTransactionCreatedEvent(
+			operationID.String(),
+			chainType.String(),
+			operationType.String(),
+			fromAddr.String(),
+			toAddr.String(),
+			req.IdempotencyKey,
+		))
+	}
+	uc.publish(ctx, events.NewTransactionProcessingEvent(operationID.String(), chainType.String()))
 
 	// Executar operação
 	rpcClient, ok := uc.rpcClients[chainType.String()]
 	if !ok {
 		uc.logger.Error("RPC client not found for chain", zap.String("chain", chainType.String()))
-		transaction.MarkAsFailed("RPC client not found")
-		if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-			uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-		}
-		return nil, pkgerrors.NewAppError(pkgerrors.ErrChainNotSupported.Code, "chain not supported", nil)
+		return nil, uc.failTransaction(ctx, transaction, "RPC client not found",
+			pkgerrors.NewAppError(pkgerrors.ErrChainNotSupported.Code, "chain not supported", nil))
 	}
 
 	// Executar baseado no tipo de operação
@@ -130,99 +167,31 @@ func (uc *ExecuteEVMTransactionUseCase) Execute(
 		// Executar transação de escrita
 		uc.logger.Info("executing write operation", zap.String("operation_type", operationType.String()))
 
-		if uc.signer == nil {
-			uc.logger.Error("transaction signer not configured")
-			transaction.MarkAsFailed("transaction signer not configured")
-			if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-				uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-			}
-			return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, "signer not configured", nil)
+		signer, ok := uc.signers[chainType.String()]
+		if !ok || signer == nil {
+			uc.logger.Error("transaction signer not configured", zap.String("chain", chainType.String()))
+			return nil, uc.failTransaction(ctx, transaction, "transaction signer not configured",
+				pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, "signer not configured", nil))
 		}
 
-		// Get nonce
-		nonce, err := rpcClient.GetNonce(ctx, fromAddr.String())
-		if err != nil {
-			uc.logger.Error("failed to get nonce", zap.Error(err))
-			transaction.MarkAsFailed("failed to get nonce")
-			if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-				uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-			}
-			return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get nonce", err)
+		txHashStr, appErr := uc.sendTransaction(ctx, rpcClient, signer, transaction, req.Payload)
+		if appErr != nil {
+			return nil, uc.failTransaction(ctx, transaction, appErr.Message, appErr)
 		}
 
-		// Get gas price
-		gasPrice, err := rpcClient.GetGasPrice(ctx)
-		if err != nil {
-			uc.logger.Error("failed to get gas price", zap.Error(err))
-			transaction.MarkAsFailed("failed to get gas price")
-			if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-				uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-			}
-			return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get gas price", err)
-		}
-
-		transaction.SetTxMetadata(gasPrice.String(), int64(nonce))
-
-		// Sign and send transaction - extract private key from payload or environment
-		// NOTE: In production, private keys should come from AWS Secrets Manager or similar
-		txHashStr, err := uc.signer.SignAndSendTransaction(ctx, nil, "")
-		if err != nil {
-			uc.logger.Error("failed to sign and send transaction", zap.Error(err))
-			transaction.MarkAsFailed(err.Error())
-			if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-				uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-			}
-			return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to sign and send transaction", err)
-		}
-
-		// Wait for confirmations
-		receipt, err := uc.signer.WaitForConfirmations(ctx, txHashStr, 12) // 12 confirmations
-		if err != nil {
-			uc.logger.Error("transaction confirmation timeout", zap.Error(err), zap.String("tx_hash", txHashStr))
-			transaction.MarkAsFailed("confirmation timeout")
-			if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-				uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-			}
-			return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "transaction not confirmed", err)
-		}
-
-		// Create transaction hash from result
+		// As confirmações são acompanhadas fora da execução (ConfirmTransactionUseCase)
 		txHash, hashErr := valueobjects.NewTransactionHash(txHashStr)
 		if hashErr != nil {
 			uc.logger.Error("failed to create transaction hash", zap.Error(hashErr))
 		}
-
-		// Mark as success with confirmation data
-		transaction.MarkAsSuccess(txHash, int64(receipt.BlockNumber.Uint64()), int64(receipt.GasUsed))
+		transaction.MarkAsSubmitted(txHash)
 
 	} else {
 		// Executar query (read-only)
 		uc.logger.Info("executing read operation", zap.String("operation_type", operationType.String()))
 
-		switch operationType {
-		case valueobjects.OperationTypeGetBalance:
-			balance, err := rpcClient.GetBalance(ctx, toAddr.String())
-			if err != nil {
-				uc.logger.Error("failed to get balance", zap.Error(err))
-				transaction.MarkAsFailed("failed to get balance")
-				if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-					uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-				}
-				return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get balance", err)
-			}
-			_ = balance.String()
-
-		case valueobjects.OperationTypeGetNonce:
-			nonce, err := rpcClient.GetNonce(ctx, fromAddr.String())
-			if err != nil {
-				uc.logger.Error("failed to get nonce", zap.Error(err))
-				transaction.MarkAsFailed("failed to get nonce")
-				if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
-					uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
-				}
-				return nil, pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get nonce", err)
-			}
-			_ = nonce
+		if appErr := uc.executeQuery(ctx, rpcClient, transaction, req.Payload); appErr != nil {
+			return nil, uc.failTransaction(ctx, transaction, appErr.Message, appErr)
 		}
 
 		transaction.MarkAsSuccess(txHash, blockNumber, gasUsed)
@@ -231,8 +200,9 @@ func (uc *ExecuteEVMTransactionUseCase) Execute(
 	// Salvar transação com resultado
 	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
 		uc.logger.Error("failed to update transaction", zap.Error(err))
-		return nil, pkgerrors.NewAppError(pkgerrors.ErrDatabaseError.Code, "failed to update transaction", err)
+		return nil, pkgerrors.FromDatabaseError("failed to update transaction", err)
 	}
+	uc.publish(ctx, resultEvent(transaction))
 
 	uc.logger.Info("transaction executed successfully",
 		zap.String("operation_id", operationID.String()),
@@ -243,22 +213,541 @@ func (uc *ExecuteEVMTransactionUseCase) Execute(
 	return response, nil
 }
 
+// failTransaction registra a falha da execução: erros permanentes encerram a transação como FAILED;
+// transitórios a devolvem para PENDING para que a próxima tentativa a reprocesse
+func (uc *ExecuteEVMTransactionUseCase) failTransaction(
+	ctx context.Context,
+	transaction *entities.EVMTransaction,
+	reason string,
+	appErr *pkgerrors.AppError,
+) *pkgerrors.AppError {
+	if appErr.IsRetryable() {
+		transaction.MarkAsPendingRetry(reason)
+	} else {
+		transaction.MarkAsFailed(reason)
+	}
+
+	if saveErr := uc.transactionRepo.Save(ctx, transaction); saveErr != nil {
+		uc.logger.Error("failed to save failed transaction", zap.Error(saveErr))
+	} else if appErr.IsRetryable() {
+		uc.publish(ctx, events.NewTransactionPendingRetryEvent(
+			transaction.OperationID().String(), transaction.ChainType().String(), reason, appErr.Code))
+	} else {
+		event := events.NewTransactionFailedEvent(transaction.OperationID().String(), transaction.ChainType().String(), reason)
+		event.ErrorCode = appErr.Code
+		uc.publish(ctx, event)
+	}
+
+	appErr.WithDetails(pkgerrors.Details{
+		Chain:       transaction.ChainType().String(),
+		OperationID: transaction.OperationID().String(),
+		TxHash:      transaction.TxHash().String(),
+	})
+	uc.logger.Warn("transaction execution failed",
+		zap.String("operation_id", transaction.OperationID().String()),
+		zap.String("category", string(appErr.Category)),
+		zap.Bool("retryable", appErr.IsRetryable()),
+		zap.Error(appErr))
+	return appErr
+}
+
+// sendTransaction monta, assina e envia a transação; com nonce reservado, devolve-o em caso de falha
+// ou ressincroniza e reenvia quando o node responde "nonce too low"
+func (uc *ExecuteEVMTransactionUseCase) sendTransaction(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	signer rpc.SignedTransactionClient,
+	transaction *entities.EVMTransaction,
+	payload map[string]interface{},
+) (string, *pkgerrors.AppError) {
+	chainType := transaction.ChainType()
+	fromAddr := transaction.FromAddress()
+
+	for attempt := 0; ; attempt++ {
+		unsignedTx, reserved, appErr := uc.buildTransaction(ctx, rpcClient, chainType, transaction.OperationType(), fromAddr, transaction.ToAddress(), payload)
+		if appErr != nil {
+			return "", appErr
+		}
+
+		setFeeMetadata(transaction, unsignedTx)
+
+		// Sign and send transaction; a chave é resolvida pelo KeyProvider a partir do from_address
+		txHashStr, err := signer.SignAndSendTransaction(ctx, unsignedTx, fromAddr.String())
+		// "already known": a transação assinada já está no mempool e ocupa o nonce; conta como enviada,
+		// pois reenviar com outro nonce (agora ou na reentrega da mensagem) duplicaria a operação
+		if err != nil && rpc.IsAlreadyKnown(err) {
+			if txHashStr == "" {
+				return "", pkgerrors.NewAppError(pkgerrors.ErrTransactionFailed.Code, "transaction already known by the node without a hash", err)
+			}
+			uc.logger.Info("transaction already known by the node", zap.String("tx_hash", txHashStr), zap.Uint64("nonce", unsignedTx.Nonce()))
+			err = nil
+		}
+		if err == nil {
+			recordBroadcast(transaction, unsignedTx, time.Now(), uc.logger)
+			if monitor, ok := uc.txMonitors[chainType.String()]; ok {
+				monitor.Track(transaction.OperationID().String(), fromAddr.String(), unsignedTx, txHashStr)
+			}
+			return txHashStr, nil
+		}
+		uc.logger.Error("failed to sign and send transaction", zap.Error(err), zap.Uint64("nonce", unsignedTx.Nonce()))
+
+		if reserved {
+			if !nonce.IsNonceTooLow(err) {
+				uc.releaseNonce(ctx, chainType, fromAddr, unsignedTx.Nonce())
+			} else if resyncErr := uc.nonceManager.Resync(ctx, chainType.String(), fromAddr.String(), rpcClient); resyncErr != nil {
+				uc.logger.Error("failed to resync nonce", zap.Error(resyncErr))
+			} else if attempt < maxNonceResyncs {
+				continue
+			}
+		}
+
+		return "", rpcAppError("failed to sign and send transaction", err)
+	}
+}
+
+// CancelTransaction cancela uma transação de escrita ainda não minerada, ocupando seu nonce com uma
+// transferência de valor zero para o próprio remetente
+func (uc *ExecuteEVMTransactionUseCase) CancelTransaction(
+	ctx context.Context,
+	operationID string,
+) (*dtos.ExecuteTransactionResponse, error) {
+	transaction, err := uc.transactionRepo.GetByOperationID(ctx, operationID)
+	if err != nil || transaction == nil {
+		uc.logger.Error("transaction not found", zap.String("operation_id", operationID), zap.Error(err))
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrOperationNotFound.Code, "transaction not found", err)
+	}
+
+	if !transaction.OperationType().IsWriteOperation() || transaction.Nonce() == nil {
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, "transaction has no pending nonce", nil)
+	}
+	switch transaction.Status() {
+	case entities.TransactionStatusSuccess, entities.TransactionStatusConfirmed, entities.TransactionStatusCancelled:
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, "transaction already finalized", nil)
+	}
+
+	monitor, ok := uc.txMonitors[transaction.ChainType().String()]
+	if !ok {
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrChainNotSupported.Code, "transaction monitor not configured", nil)
+	}
+	// Sem a transação persistida, o cancelamento usa o nonce e as taxas registrados na operação
+	if err := restoreTracking(monitor, transaction); err != nil {
+		uc.logger.Debug("cancelling from stored nonce and fees", zap.String("operation_id", operationID), zap.Error(err))
+	}
+
+	cancelHash, err := monitor.Cancel(ctx, operationID, transaction.FromAddress().String(), uint64(*transaction.Nonce()), storedFees(transaction))
+	if err != nil {
+		uc.logger.Error("failed to cancel transaction", zap.String("operation_id", operationID), zap.Error(err))
+		return nil, rpcAppError("failed to cancel transaction", err)
+	}
+
+	transaction.AddReplacementHash(cancelHash)
+	if pending, ok := monitor.Pending(operationID); ok {
+		recordBroadcast(transaction, pending.Tx, pending.LastBroadcastAt, uc.logger)
+	}
+	transaction.MarkAsCancelled()
+	if err := uc.transactionRepo.Save(ctx, transaction); err != nil {
+		uc.logger.Error("failed to update transaction", zap.Error(err))
+		return nil, pkgerrors.FromDatabaseError("failed to update transaction", err)
+	}
+	uc.publish(ctx, events.NewTransactionCancelledEvent(operationID, transaction.ChainType().String(), cancelHash))
+
+	uc.logger.Info("transaction cancelled",
+		zap.String("operation_id", operationID),
+		zap.String("cancel_tx_hash", cancelHash))
+	return buildResponse(transaction), nil
+}
+
+// resultEvent evento do resultado da execução: SUBMITTED para escritas, SUCCESS para leituras
+func resultEvent(transaction *entities.EVMTransaction) events.DomainEvent {
+	operationID := transaction.OperationID().String()
+	chainType := transaction.ChainType().String()
+	if transaction.Status() == entities.TransactionStatusSubmitted {
+		return events.NewTransactionSubmittedEvent(operationID, chainType, transaction.TxHash().String(), transaction.Nonce())
+	}
+
+	gasUsed := int64(0)
+	if transaction.GasUsed() != nil {
+		gasUsed = *transaction.GasUsed()
+	}
+	blockNumber := int64(0)
+	if transaction.BlockNumber() != nil {
+		blockNumber = *transaction.BlockNumber()
+	}
+	return events.NewTransactionSucceededEvent(operationID, chainType, transaction.TxHash().String(), blockNumber, gasUsed)
+}
+
+// publish publica o evento; falhas não desfazem a transição já persistida
+func (uc *ExecuteEVMTransactionUseCase) publish(ctx context.Context, event events.DomainEvent) {
+	if uc.publisher == nil {
+		return
+	}
+	if err := uc.publisher.Publish(ctx, event); err != nil {
+		uc.logger.Error("failed to publish event",
+			zap.String("event_type", event.EventType()),
+			zap.String("operation_id", event.AggregateID()),
+			zap.Error(err))
+	}
+}
+
+// storedFees taxas persistidas da transação (nil se ausentes)
+func storedFees(transaction *entities.EVMTransaction) *rpc.FeeData {
+	parse := func(value *string) *big.Int {
+		if value == nil {
+			return nil
+		}
+		fee, ok := new(big.Int).SetString(*value, 10)
+		if !ok {
+			return nil
+		}
+		return fee
+	}
+
+	if maxFee, tip := parse(transaction.MaxFeePerGas()), parse(transaction.MaxPriorityFeePerGas()); maxFee != nil && tip != nil {
+		return &rpc.FeeData{MaxFeePerGas: maxFee, MaxPriorityFeePerGas: tip}
+	}
+	if gasPrice := parse(transaction.GasPrice()); gasPrice != nil {
+		return &rpc.FeeData{GasPrice: gasPrice}
+	}
+	return nil
+}
+
+// setFeeMetadata registra as taxas e o nonce da transação na operação
+func setFeeMetadata(transaction *entities.EVMTransaction, tx *types.Transaction) {
+	if tx.Type() == types.DynamicFeeTxType {
+		transaction.SetDynamicFeeMetadata(tx.GasFeeCap().String(), tx.GasTipCap().String(), int64(tx.Nonce()))
+	} else {
+		transaction.SetTxMetadata(tx.GasPrice().String(), int64(tx.Nonce()))
+	}
+}
+
+// recordBroadcast registra na operação a versão enviada (taxas, nonce e transação serializada) para que
+// outra instância consiga reconstruí-la e substituí-la
+func recordBroadcast(transaction *entities.EVMTransaction, tx *types.Transaction, broadcastAt time.Time, logger *zap.Logger) {
+	setFeeMetadata(transaction, tx)
+	raw, err := rpc.EncodeTransaction(tx)
+	if err != nil {
+		logger.Error("failed to encode sent transaction",
+			zap.String("operation_id", transaction.OperationID().String()),
+			zap.Error(err))
+		return
+	}
+	transaction.SetBroadcast(raw, broadcastAt)
+}
+
+// restoreTracking retoma no monitor a transação persistida quando esta instância não a acompanha
+func restoreTracking(monitor rpc.TransactionMonitor, transaction *entities.EVMTransaction) error {
+	operationID := transaction.OperationID().String()
+	if _, ok := monitor.Pending(operationID); ok {
+		return nil
+	}
+	if transaction.RawTransaction() == nil || transaction.LastBroadcastAt() == nil {
+		return errors.New("transaction has no persisted raw transaction")
+	}
+
+	tx, err := rpc.DecodeTransaction(*transaction.RawTransaction())
+	if err != nil {
+		return err
+	}
+	monitor.Restore(rpc.PendingTransaction{
+		OperationID:     operationID,
+		FromAddress:     transaction.FromAddress().String(),
+		Tx:              tx,
+		Hashes:          append([]string{transaction.TxHash().String()}, transaction.ReplacementHashes()...),
+		Replacements:    len(transaction.ReplacementHashes()),
+		Cancelled:       transaction.Status() == entities.TransactionStatusCancelled,
+		LastBroadcastAt: *transaction.LastBroadcastAt(),
+	})
+	return nil
+}
+
+// releaseNonce devolve ao gerenciador um nonce reservado que não foi usado
+func (uc *ExecuteEVMTransactionUseCase) releaseNonce(
+	ctx context.Context,
+	chainType valueobjects.ChainType,
+	fromAddr valueobjects.EVMAddress,
+	reservedNonce uint64,
+) {
+	if err := uc.nonceManager.Release(ctx, chainType.String(), fromAddr.String(), reservedNonce); err != nil {
+		uc.logger.Error("failed to release nonce", zap.Error(err), zap.Uint64("nonce", reservedNonce))
+	}
+}
+
+// buildTransaction monta a transação não assinada a partir do payload, buscando no node o que não foi informado;
+// reserved indica que o nonce foi reservado no nonceManager
+func (uc *ExecuteEVMTransactionUseCase) buildTransaction(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	chainType valueobjects.ChainType,
+	operationType valueobjects.OperationType,
+	fromAddr valueobjects.EVMAddress,
+	toAddr valueobjects.EVMAddress,
+	payload map[string]interface{},
+) (tx *types.Transaction, reserved bool, appErr *pkgerrors.AppError) {
+	params, err := ParseTransactionParams(operationType, toAddr, payload)
+	if err != nil {
+		uc.logger.Error("invalid transaction payload", zap.Error(err))
+		return nil, false, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
+	}
+
+	// Get nonce
+	var txNonce uint64
+	switch {
+	case params.Nonce != nil:
+		txNonce = *params.Nonce
+	case uc.nonceManager != nil:
+		txNonce, err = uc.nonceManager.Acquire(ctx, chainType.String(), fromAddr.String(), rpcClient)
+		if err != nil {
+			uc.logger.Error("failed to reserve nonce", zap.Error(err))
+			return nil, false, rpcAppError("failed to reserve nonce", err)
+		}
+		// Falhas até o envio devolvem o nonce para não deixar lacuna
+		defer func() {
+			if appErr != nil {
+				uc.releaseNonce(ctx, chainType, fromAddr, txNonce)
+			}
+		}()
+		reserved = true
+	default:
+		txNonce, err = rpcClient.GetNonce(ctx, fromAddr.String())
+		if err != nil {
+			uc.logger.Error("failed to get nonce", zap.Error(err))
+			return nil, false, rpcAppError("failed to get nonce", err)
+		}
+	}
+
+	// Get fees
+	fees := params.PayloadFees()
+	if fees == nil {
+		if estimator, ok := uc.feeEstimators[chainType.String()]; ok {
+			fees, err = estimator.EstimateFees(ctx, params.FeeSpeed)
+			if err != nil {
+				uc.logger.Error("failed to estimate fees", zap.Error(err))
+				return nil, false, rpcAppError("failed to estimate fees", err)
+			}
+		} else {
+			// Sem estimador configurado para a chain: gas price legacy do node
+			gasPrice, err := rpcClient.GetGasPrice(ctx)
+			if err != nil {
+				uc.logger.Error("failed to get gas price", zap.Error(err))
+				return nil, false, rpcAppError("failed to get gas price", err)
+			}
+			fees = &rpc.FeeData{GasPrice: gasPrice}
+		}
+	}
+
+	// Get gas limit
+	gasLimit := params.KnownGasLimit(operationType)
+	if gasLimit == 0 {
+		gasLimit, appErr = uc.estimateGasLimit(ctx, rpcClient, chainType, params.CallMsg(fromAddr))
+		if appErr != nil {
+			return nil, false, appErr
+		}
+	}
+
+	tx, err = params.BuildTransaction(txNonce, fees, gasLimit)
+	if err != nil {
+		uc.logger.Error("failed to build transaction", zap.Error(err))
+		return nil, false, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
+	}
+
+	uc.logger.Info("transaction built",
+		zap.String("operation_type", operationType.String()),
+		zap.Uint64("nonce", tx.Nonce()),
+		zap.Uint8("tx_type", tx.Type()),
+		zap.String("gas_fee_cap", tx.GasFeeCap().String()),
+		zap.String("gas_tip_cap", tx.GasTipCap().String()),
+		zap.Uint64("gas_limit", tx.Gas()),
+		zap.String("value", tx.Value().String()))
+
+	return tx, reserved, nil
+}
+
+// executeQuery executa uma operação de leitura e registra o resultado na transação
+func (uc *ExecuteEVMTransactionUseCase) executeQuery(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	transaction *entities.EVMTransaction,
+	payload map[string]interface{},
+) *pkgerrors.AppError {
+	result, appErr := uc.queryResult(ctx, rpcClient, transaction, payload)
+	if appErr != nil {
+		return appErr
+	}
+
+	transaction.SetQueryResult(result)
+	return nil
+}
+
+// queryResult consulta o node conforme o tipo da operação de leitura
+func (uc *ExecuteEVMTransactionUseCase) queryResult(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	transaction *entities.EVMTransaction,
+	payload map[string]interface{},
+) (map[string]interface{}, *pkgerrors.AppError) {
+	fromAddr := transaction.FromAddress()
+	toAddr := transaction.ToAddress()
+
+	switch operationType := transaction.OperationType(); operationType {
+	case valueobjects.OperationTypeGetBalance:
+		balance, err := rpcClient.GetBalance(ctx, toAddr.String())
+		if err != nil {
+			uc.logger.Error("failed to get balance", zap.Error(err))
+			return nil, rpcAppError("failed to get balance", err)
+		}
+		return map[string]interface{}{
+			resultKeyAddress:          toAddr.String(),
+			resultKeyBalance:          balance.String(),
+			resultKeyBalanceFormatted: formatUnits(balance, nativeDecimals),
+		}, nil
+
+	case valueobjects.OperationTypeGetNonce:
+		nonce, err := rpcClient.GetNonce(ctx, fromAddr.String())
+		if err != nil {
+			uc.logger.Error("failed to get nonce", zap.Error(err))
+			return nil, rpcAppError("failed to get nonce", err)
+		}
+		return map[string]interface{}{
+			resultKeyAddress: fromAddr.String(),
+			resultKeyNonce:   strconv.FormatUint(nonce, 10),
+		}, nil
+
+	case valueobjects.OperationTypeEstimateGas:
+		msg, err := ParseCallMsg(fromAddr, toAddr, payload)
+		if err != nil {
+			uc.logger.Error("invalid estimate gas payload", zap.Error(err))
+			return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
+		}
+
+		gasLimit, appErr := uc.estimateGasLimit(ctx, rpcClient, transaction.ChainType(), msg)
+		if appErr != nil {
+			return nil, appErr
+		}
+		transaction.SetGasEstimate(int64(gasLimit))
+		return map[string]interface{}{
+			resultKeyGasEstimate: strconv.FormatUint(gasLimit, 10),
+		}, nil
+
+	case valueobjects.OperationTypeQuery:
+		query, err := ParseQueryParams(fromAddr, toAddr, payload)
+		if err != nil {
+			uc.logger.Error("invalid query payload", zap.Error(err))
+			return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
+		}
+
+		output, err := rpcClient.CallContract(ctx, query.Msg)
+		if err != nil {
+			uc.logger.Error("failed to call contract", zap.Error(err))
+
+			var revertErr *rpc.RevertError
+			if errors.As(err, &revertErr) {
+				return nil, pkgerrors.NewAppError(pkgerrors.ErrTransactionFailed.Code, revertErr.Error(), err).
+					WithDetails(pkgerrors.Details{RevertData: revertData(revertErr)})
+			}
+			return nil, rpcAppError("failed to call contract", err)
+		}
+
+		result, err := query.DecodeOutput(output)
+		if err != nil {
+			uc.logger.Error("failed to decode call output", zap.Error(err))
+			return nil, pkgerrors.NewAppError(pkgerrors.ErrValidationFailed.Code, err.Error(), err)
+		}
+		return result, nil
+
+	default:
+		return nil, pkgerrors.NewAppError(pkgerrors.ErrNotImplemented.Code, "read operation not supported: "+operationType.String(), nil)
+	}
+}
+
+// estimateGasLimit estima o gas limit com a estratégia da chain; reverts incluem o motivo decodificado
+func (uc *ExecuteEVMTransactionUseCase) estimateGasLimit(
+	ctx context.Context,
+	rpcClient rpc.RPCClient,
+	chainType valueobjects.ChainType,
+	msg rpc.CallMsg,
+) (uint64, *pkgerrors.AppError) {
+	estimator, ok := uc.gasEstimators[chainType.String()]
+	if !ok {
+		estimator = rpc.NewNodeGasEstimator(rpcClient, rpc.DefaultGasStrategy(), uc.logger)
+	}
+
+	gasLimit, err := estimator.EstimateGasLimit(ctx, msg)
+	if err != nil {
+		uc.logger.Error("failed to estimate gas", zap.Error(err))
+
+		var revertErr *rpc.RevertError
+		if errors.As(err, &revertErr) {
+			return 0, pkgerrors.NewAppError(pkgerrors.ErrGasEstimationFailed.Code, revertErr.Error(), err).
+				WithDetails(pkgerrors.Details{RevertData: revertData(revertErr)})
+		}
+		if errors.Is(err, rpc.ErrRPCUnavailable) {
+			return 0, rpcAppError("failed to estimate gas", err)
+		}
+		// Erros conhecidos do node (revert, saldo insuficiente) mantêm a categoria; os demais são de comunicação
+		category := pkgerrors.CategoryRPCTransient
+		if nodeErr, ok := pkgerrors.FromNodeError("failed to estimate gas", err); ok {
+			category = nodeErr.Category
+		}
+		return 0, pkgerrors.NewAppError(pkgerrors.ErrGasEstimationFailed.Code, "failed to estimate gas", err).
+			WithCategory(category)
+	}
+
+	return gasLimit, nil
+}
+
+// rpcAppError erro de chamada RPC; circuit breaker aberto vira ErrRPCUnavailable para o retry aguardar o endpoint
+// e erros conhecidos do node (nonce too low, insufficient funds, ...) viram erros tipados
+func rpcAppError(message string, err error) *pkgerrors.AppError {
+	if errors.Is(err, rpc.ErrRPCUnavailable) {
+		return pkgerrors.NewAppError(pkgerrors.ErrRPCUnavailable.Code, message, err)
+	}
+	if appErr, ok := pkgerrors.FromNodeError(message, err); ok {
+		return appErr
+	}
+	return pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, message, err)
+}
+
+// revertData dados brutos do revert em hex (vazio quando o node não os retorna)
+func revertData(revertErr *rpc.RevertError) string {
+	if len(revertErr.Data) == 0 {
+		return ""
+	}
+	return hexutil.Encode(revertErr.Data)
+}
+
 func buildResponse(tx *entities.EVMTransaction) *dtos.ExecuteTransactionResponse {
 	executedAt := ""
 	if tx.ExecutedAt() != nil {
 		executedAt = tx.ExecutedAt().Format(time.RFC3339)
 	}
 
+	var queryResult *dtos.QueryResultResponse
+	if !tx.OperationType().IsWriteOperation() && tx.QueryResult() != nil {
+		queryResult = &dtos.QueryResultResponse{
+			OperationID: tx.OperationID().String(),
+			ChainType:   tx.ChainType().String(),
+			Result:      tx.QueryResult(),
+			Status:      string(tx.Status()),
+			CreatedAt:   tx.CreatedAt().Format(time.RFC3339),
+		}
+	}
+
 	return &dtos.ExecuteTransactionResponse{
-		OperationID:     tx.OperationID().String(),
-		ChainType:       tx.ChainType().String(),
-		TransactionHash: tx.TxHash().String(),
-		Status:          string(tx.Status()),
-		BlockNumber:     tx.BlockNumber(),
-		GasUsed:         tx.GasUsed(),
-		GasPrice:        tx.GasPrice(),
-		ErrorMessage:    tx.ErrorMessage(),
-		CreatedAt:       tx.CreatedAt().Format(time.RFC3339),
-		ExecutedAt:      &executedAt,
+		OperationID:          tx.OperationID().String(),
+		ChainType:            tx.ChainType().String(),
+		TransactionHash:      tx.TxHash().String(),
+		Status:               string(tx.Status()),
+		BlockNumber:          tx.BlockNumber(),
+		BlockHash:            tx.BlockHash(),
+		GasUsed:              tx.GasUsed(),
+		GasPrice:             tx.GasPrice(),
+		MaxFeePerGas:         tx.MaxFeePerGas(),
+		MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas(),
+		GasEstimate:          tx.GasEstimate(),
+		ReplacementHashes:    tx.ReplacementHashes(),
+		QueryResult:          queryResult,
+		ErrorMessage:         tx.ErrorMessage(),
+		CreatedAt:            tx.CreatedAt().Format(time.RFC3339),
+		ExecutedAt:           &executedAt,
 	}
 }
@@ -2,15 +2,22 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gabrielksneiva/ChainEVM/internal/application/dtos"
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/entities"
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/nonce"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/publisher"
 	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -40,11 +47,19 @@ func (m *MockRPCClient) SendTransaction(ctx context.Context, tx *types.Transacti
 	return args.Error(0)
 }
 
-func (m *MockRPCClient) EstimateGas(ctx context.Context, msg interface{}) (uint64, error) {
+func (m *MockRPCClient) EstimateGas(ctx context.Context, msg rpc.CallMsg) (uint64, error) {
 	args := m.Called(ctx, msg)
 	return args.Get(0).(uint64), args.Error(1)
 }
 
+func (m *MockRPCClient) CallContract(ctx context.Context, msg rpc.CallMsg) ([]byte, error) {
+	args := m.Called(ctx, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockRPCClient) GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
 	args := m.Called(ctx, txHash)
 	if args.Get(0) == nil {
@@ -53,6 +68,16 @@ func (m *MockRPCClient) GetTransactionReceipt(ctx context.Context, txHash string
 	return args.Get(0).(*types.Receipt), args.Error(1)
 }
 
+func (m *MockRPCClient) GetBlockNumber(ctx context.Context) (uint64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *MockRPCClient) GetBlockHash(ctx context.Context, blockNumber uint64) (string, error) {
+	args := m.Called(ctx, blockNumber)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockRPCClient) GetChainID(ctx context.Context) (*big.Int, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -69,11 +94,48 @@ func (m *MockRPCClient) GetGasPrice(ctx context.Context) (*big.Int, error) {
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockRPCClient) GetBaseFee(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockRPCClient) GetMaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockRPCClient) GetFeeHistory(ctx context.Context, blockCount uint64, percentiles []float64) (*ethereum.FeeHistory, error) {
+	args := m.Called(ctx, blockCount, percentiles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ethereum.FeeHistory), args.Error(1)
+}
+
 func (m *MockRPCClient) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
 
+// MockFeeEstimator implementa rpc.FeeEstimator
+type MockFeeEstimator struct {
+	mock.Mock
+}
+
+func (m *MockFeeEstimator) EstimateFees(ctx context.Context, speed rpc.FeeSpeed) (*rpc.FeeData, error) {
+	args := m.Called(ctx, speed)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*rpc.FeeData), args.Error(1)
+}
+
 // MockTransactionRepository implementa database.TransactionRepository
 type MockTransactionRepository struct {
 	mock.Mock
@@ -105,13 +167,21 @@ func (m *MockTransactionRepository) UpdateStatus(ctx context.Context, operationI
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) ListByStatus(ctx context.Context, status entities.TransactionStatus, limit int, cursor string) ([]*entities.EVMTransaction, string, error) {
+	args := m.Called(ctx, status, limit, cursor)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).([]*entities.EVMTransaction), args.String(1), args.Error(2)
+}
+
 // MockTransactionSigner implements rpc.SignedTransactionClient interface
 type MockTransactionSigner struct {
 	mock.Mock
 }
 
-func (m *MockTransactionSigner) SignAndSendTransaction(ctx context.Context, tx *types.Transaction, privateKeyHex string) (string, error) {
-	args := m.Called(ctx, tx, privateKeyHex)
+func (m *MockTransactionSigner) SignAndSendTransaction(ctx context.Context, tx *types.Transaction, fromAddress string) (string, error) {
+	args := m.Called(ctx, tx, fromAddress)
 	return args.String(0), args.Error(1)
 }
 
@@ -134,7 +204,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 			"ETHEREUM": mockRPC,
 		}
 
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440001",
@@ -167,7 +237,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 			"ETHEREUM": mockRPC,
 		}
 
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440003",
@@ -200,7 +270,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 			"ETHEREUM": mockRPC,
 		}
 
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440005",
@@ -217,10 +288,6 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
 		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
 		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("0xabc123def456", nil)
-		mockSigner.On("WaitForConfirmations", mock.Anything, "0xabc123def456", 12).Return(&types.Receipt{
-			BlockNumber: big.NewInt(1000),
-			GasUsed:     21000,
-		}, nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -238,7 +305,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 			"ETHEREUM": mockRPC,
 		}
 
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		chainType, _ := valueobjects.NewChainType("ETHEREUM")
 		opType, _ := valueobjects.NewOperationType("GET_BALANCE")
@@ -247,6 +314,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
 
 		existingTx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, map[string]interface{}{}, "550e8400-e29b-41d4-a716-446655440008")
+		existingTx.MarkAsSuccess("", 0, 0)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440007",
@@ -273,7 +341,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440009",
@@ -296,7 +364,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "invalid",
@@ -319,7 +387,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440018",
@@ -345,7 +413,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440020",
@@ -372,7 +440,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440022",
@@ -396,13 +464,45 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRPC.AssertExpectations(t)
 	})
 
+	t.Run("return RPC_UNAVAILABLE when circuit breaker is open", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		req := &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440024",
+			ChainType:      "ETHEREUM",
+			OperationType:  "GET_BALANCE",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x1234567890123456789012345678901234567890",
+			Payload:        map[string]interface{}{},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440025",
+		}
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).
+			Return(nil, fmt.Errorf("failed to get balance: %w", rpc.ErrRPCUnavailable))
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		appErr, ok := err.(*pkgerrors.AppError)
+		require.True(t, ok)
+		assert.Equal(t, pkgerrors.ErrRPCUnavailable.Code, appErr.Code)
+	})
+
 	t.Run("fail when GetNonce fails in write operation", func(t *testing.T) {
 		mockRPC := new(MockRPCClient)
 		mockRepo := new(MockTransactionRepository)
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440026",
@@ -432,7 +532,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440028",
@@ -463,7 +564,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440030",
@@ -490,13 +592,14 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockSigner.AssertExpectations(t)
 	})
 
-	t.Run("fail when WaitForConfirmations times out", func(t *testing.T) {
+	t.Run("return SUBMITTED without waiting for confirmations", func(t *testing.T) {
 		mockRPC := new(MockRPCClient)
 		mockRepo := new(MockTransactionRepository)
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440040",
@@ -512,13 +615,15 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
 		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
 		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
-		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("0xabc123", nil)
-		mockSigner.On("WaitForConfirmations", mock.Anything, "0xabc123", 12).Return(nil, errors.New("timeout"))
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("0x00000000000000000000000000000000000000000000000000000000000abc12", nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
-		require.Error(t, err)
-		assert.Nil(t, resp)
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSubmitted), resp.Status)
+		assert.Equal(t, "0x00000000000000000000000000000000000000000000000000000000000abc12", resp.TransactionHash)
+		assert.Nil(t, resp.BlockNumber)
+		mockSigner.AssertNotCalled(t, "WaitForConfirmations", mock.Anything, mock.Anything, mock.Anything)
 		mockRepo.AssertExpectations(t)
 		mockRPC.AssertExpectations(t)
 		mockSigner.AssertExpectations(t)
@@ -530,7 +635,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440032",
@@ -546,11 +652,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
 		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(15), nil)
 		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(30000000000), nil)
+		mockRPC.On("EstimateGas", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return(uint64(45000), nil)
 		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("0xdef789abc123", nil)
-		mockSigner.On("WaitForConfirmations", mock.Anything, "0xdef789abc123", 12).Return(&types.Receipt{
-			BlockNumber: big.NewInt(2000),
-			GasUsed:     45000,
-		}, nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -565,7 +668,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440034",
@@ -573,12 +676,13 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 			OperationType:  "QUERY",
 			FromAddress:    "0x1234567890123456789012345678901234567890",
 			ToAddress:      "0x0987654321098765432109876543210987654321",
-			Payload:        map[string]interface{}{},
+			Payload:        map[string]interface{}{"data": "0x18160ddd"},
 			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440035",
 		}
 
 		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("CallContract", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return([]byte{0x01}, nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -593,15 +697,19 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockSigner := new(MockTransactionSigner)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, mockSigner, logger)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
-			OperationID:    "550e8400-e29b-41d4-a716-446655440036",
-			ChainType:      "ETHEREUM",
-			OperationType:  "APPROVE",
-			FromAddress:    "0x1234567890123456789012345678901234567890",
-			ToAddress:      "0x0987654321098765432109876543210987654321",
-			Payload:        map[string]interface{}{"amount": "1000"},
+			OperationID:   "550e8400-e29b-41d4-a716-446655440036",
+			ChainType:     "ETHEREUM",
+			OperationType: "APPROVE",
+			FromAddress:   "0x1234567890123456789012345678901234567890",
+			ToAddress:     "0x0987654321098765432109876543210987654321",
+			Payload: map[string]interface{}{
+				"amount":  "1000",
+				"spender": "0x1111111111111111111111111111111111111111",
+			},
 			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440037",
 		}
 
@@ -609,11 +717,8 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
 		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(20), nil)
 		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(25000000000), nil)
+		mockRPC.On("EstimateGas", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return(uint64(46000), nil)
 		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("0x999888777666", nil)
-		mockSigner.On("WaitForConfirmations", mock.Anything, "0x999888777666", 12).Return(&types.Receipt{
-			BlockNumber: big.NewInt(3000),
-			GasUsed:     35000,
-		}, nil)
 
 		resp, err := useCase.Execute(context.Background(), req)
 
@@ -628,7 +733,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440038",
@@ -651,7 +756,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440040",
@@ -674,7 +779,7 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		mockRepo := new(MockTransactionRepository)
 
 		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
-		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, logger)
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, nil, nil, nil, nil, nil, nil, logger)
 
 		req := &dtos.ExecuteTransactionRequest{
 			OperationID:    "550e8400-e29b-41d4-a716-446655440042",
@@ -692,3 +797,1247 @@ func TestExecuteEVMTransactionUseCase_Execute(t *testing.T) {
 		assert.Nil(t, resp)
 	})
 }
+
+func TestExecuteEVMTransactionUseCase_BuildTransaction(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("sign transaction built from payload and node values", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440050",
+			ChainType:      "ETHEREUM",
+			OperationType:  "TRANSFER",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        map[string]interface{}{"amount": "1000000000000000000"},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440051",
+		}
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(7), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 7 &&
+				tx.GasPrice().Cmp(big.NewInt(20000000000)) == 0 &&
+				tx.Gas() == 21000 &&
+				tx.Value().String() == "1000000000000000000" &&
+				tx.To() != nil && tx.To().Hex() == "0x0987654321098765432109876543210987654321"
+		}), req.FromAddress).Return("0xabc", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		require.NotNil(t, resp.GasPrice)
+		assert.Equal(t, "20000000000", *resp.GasPrice)
+		mockRPC.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("payload nonce, gas price and gas limit skip node lookups", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := &dtos.ExecuteTransactionRequest{
+			OperationID:   "550e8400-e29b-41d4-a716-446655440052",
+			ChainType:     "ETHEREUM",
+			OperationType: "CALL",
+			FromAddress:   "0x1234567890123456789012345678901234567890",
+			ToAddress:     "0x0987654321098765432109876543210987654321",
+			Payload: map[string]interface{}{
+				"data":      "0xa9059cbb",
+				"nonce":     float64(3),
+				"gas_price": "0x3b9aca00",
+				"gas_limit": "90000",
+			},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440053",
+		}
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 3 &&
+				tx.GasPrice().Cmp(big.NewInt(1000000000)) == 0 &&
+				tx.Gas() == 90000 &&
+				len(tx.Data()) == 4
+		}), mock.Anything).Return("0xdef", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		mockRPC.AssertNotCalled(t, "GetNonce", mock.Anything, mock.Anything)
+		mockRPC.AssertNotCalled(t, "GetGasPrice", mock.Anything)
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("fail with invalid payload", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440054",
+			ChainType:      "ETHEREUM",
+			OperationType:  "DEPLOY",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        map[string]interface{}{},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440055",
+		}
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "VALIDATION_FAILED")
+		mockSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail when gas estimation fails", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": mockRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440056",
+			ChainType:      "ETHEREUM",
+			OperationType:  "MINT",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        map[string]interface{}{"data": "0x40c10f19"},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440057",
+		}
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(1), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1), nil)
+		mockRPC.On("EstimateGas", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return(uint64(0), errors.New("execution reverted"))
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "GAS_ESTIMATION_FAILED")
+		mockRPC.AssertExpectations(t)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_SignerSelection(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(chain string) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440060",
+			ChainType:      chain,
+			OperationType:  "TRANSFER",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        map[string]interface{}{"amount": "1"},
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440061",
+		}
+	}
+
+	t.Run("use signer of the request chain", func(t *testing.T) {
+		ethRPC := new(MockRPCClient)
+		polygonRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		ethSigner := new(MockTransactionSigner)
+		polygonSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"ETHEREUM": ethRPC, "POLYGON": polygonRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": ethSigner, "POLYGON": polygonSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("POLYGON")
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		polygonRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(1), nil)
+		polygonRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(30000000000), nil)
+		polygonSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, req.FromAddress).Return("0xpolygon", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		polygonSigner.AssertExpectations(t)
+		ethSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+		ethRPC.AssertNotCalled(t, "GetNonce", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail when chain has no signer", func(t *testing.T) {
+		bscRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		ethSigner := new(MockTransactionSigner)
+
+		rpcClients := map[string]rpc.RPCClient{"BSC": bscRPC}
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": ethSigner}
+		useCase := NewExecuteEVMTransactionUseCase(rpcClients, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("BSC")
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		ethSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_FeeEstimation(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(payload map[string]interface{}) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440070",
+			ChainType:      "ETHEREUM",
+			OperationType:  "TRANSFER",
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        payload,
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440071",
+		}
+	}
+
+	t.Run("estimated dynamic fees produce EIP-1559 transaction", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		mockEstimator := new(MockFeeEstimator)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			map[string]rpc.FeeEstimator{"ETHEREUM": mockEstimator},
+			nil,
+			nil,
+			nil,
+			nil,
+			logger,
+		)
+
+		req := newRequest(map[string]interface{}{"amount": "1", "fee_speed": "fast"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(3), nil)
+		mockEstimator.On("EstimateFees", mock.Anything, rpc.FeeSpeedFast).Return(&rpc.FeeData{
+			MaxFeePerGas:         big.NewInt(42000000000),
+			MaxPriorityFeePerGas: big.NewInt(2000000000),
+		}, nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Type() == types.DynamicFeeTxType &&
+				tx.GasFeeCap().Cmp(big.NewInt(42000000000)) == 0 &&
+				tx.GasTipCap().Cmp(big.NewInt(2000000000)) == 0
+		}), req.FromAddress).Return("0xdynamic", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.MaxFeePerGas)
+		require.NotNil(t, resp.MaxPriorityFeePerGas)
+		assert.Equal(t, "42000000000", *resp.MaxFeePerGas)
+		assert.Equal(t, "2000000000", *resp.MaxPriorityFeePerGas)
+		assert.Nil(t, resp.GasPrice)
+		mockRPC.AssertNotCalled(t, "GetGasPrice", mock.Anything)
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("payload fees skip estimation", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		mockEstimator := new(MockFeeEstimator)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			map[string]rpc.FeeEstimator{"ETHEREUM": mockEstimator},
+			nil,
+			nil,
+			nil,
+			nil,
+			logger,
+		)
+
+		req := newRequest(map[string]interface{}{
+			"amount":                   "1",
+			"max_fee_per_gas":          "30000000000",
+			"max_priority_fee_per_gas": "1000000000",
+		})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(3), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Type() == types.DynamicFeeTxType && tx.GasFeeCap().Cmp(big.NewInt(30000000000)) == 0
+		}), req.FromAddress).Return("0xpayload", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		mockEstimator.AssertNotCalled(t, "EstimateFees", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail when fee estimation fails", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		mockEstimator := new(MockFeeEstimator)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			map[string]rpc.FeeEstimator{"ETHEREUM": mockEstimator},
+			nil,
+			nil,
+			nil,
+			nil,
+			logger,
+		)
+
+		req := newRequest(map[string]interface{}{"amount": "1"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(3), nil)
+		mockEstimator.On("EstimateFees", mock.Anything, rpc.FeeSpeed("")).Return(nil, rpc.ErrFeeCapExceeded)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		mockSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// MockGasEstimator implementa rpc.GasEstimator
+type MockGasEstimator struct {
+	mock.Mock
+}
+
+func (m *MockGasEstimator) EstimateGasLimit(ctx context.Context, msg rpc.CallMsg) (uint64, error) {
+	args := m.Called(ctx, msg)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func TestExecuteEVMTransactionUseCase_EstimateGas(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(operationType string, payload map[string]interface{}) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440080",
+			ChainType:      "ETHEREUM",
+			OperationType:  operationType,
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        payload,
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440081",
+		}
+	}
+
+	t.Run("ESTIMATE_GAS returns estimate with default margin", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("ESTIMATE_GAS", map[string]interface{}{"data": "0xa9059cbb"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("EstimateGas", mock.Anything, mock.MatchedBy(func(msg rpc.CallMsg) bool {
+			return len(msg.Data) == 4 && msg.To != nil
+		})).Return(uint64(50000), nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.GasEstimate)
+		assert.Equal(t, int64(60000), *resp.GasEstimate)
+		assert.Equal(t, "SUCCESS", resp.Status)
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("ESTIMATE_GAS uses chain gas estimator", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockEstimator := new(MockGasEstimator)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			nil,
+			nil,
+			map[string]rpc.GasEstimator{"ETHEREUM": mockEstimator},
+			nil,
+			nil,
+			nil,
+			logger,
+		)
+
+		req := newRequest("ESTIMATE_GAS", map[string]interface{}{})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockEstimator.On("EstimateGasLimit", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return(uint64(33000), nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(33000), *resp.GasEstimate)
+		mockRPC.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ESTIMATE_GAS surfaces revert reason", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("ESTIMATE_GAS", map[string]interface{}{"data": "0xa9059cbb"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("EstimateGas", mock.Anything, mock.Anything).
+			Return(uint64(0), &rpc.RevertError{Reason: "ERC20: transfer amount exceeds balance", Data: []byte{0x08, 0xc3, 0x79, 0xa0}})
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		var appErr *pkgerrors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, pkgerrors.ErrGasEstimationFailed.Code, appErr.Code)
+		assert.Equal(t, "execution reverted: ERC20: transfer amount exceeds balance", appErr.Message)
+		assert.Equal(t, pkgerrors.Details{Chain: "ETHEREUM", OperationID: req.OperationID, RevertData: "0x08c379a0"}, appErr.Details)
+	})
+
+	t.Run("write operation fails with revert reason", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("CALL", map[string]interface{}{"data": "0xa9059cbb"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(1), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1), nil)
+		mockRPC.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(0), &rpc.RevertError{Reason: "paused"})
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Contains(t, err.Error(), "execution reverted: paused")
+		mockSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_ReadResults(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(operationType string, payload map[string]interface{}) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440090",
+			ChainType:      "ETHEREUM",
+			OperationType:  operationType,
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        payload,
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440091",
+		}
+	}
+
+	setup := func(req *dtos.ExecuteTransactionRequest) (*MockRPCClient, *MockTransactionRepository, *ExecuteEVMTransactionUseCase) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		return mockRPC, mockRepo, useCase
+	}
+
+	t.Run("GET_BALANCE returns wei and formatted balance", func(t *testing.T) {
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+		mockRPC, mockRepo, useCase := setup(req)
+		balance, _ := new(big.Int).SetString("1500000000000000000", 10)
+		mockRPC.On("GetBalance", mock.Anything, req.ToAddress).Return(balance, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.QueryResult)
+		assert.Equal(t, req.OperationID, resp.QueryResult.OperationID)
+		assert.Equal(t, "SUCCESS", resp.QueryResult.Status)
+		assert.Equal(t, map[string]interface{}{
+			"address":           req.ToAddress,
+			"balance":           "1500000000000000000",
+			"balance_formatted": "1.5",
+		}, resp.QueryResult.Result)
+
+		// Resultado persistido junto com a transação
+		mockRepo.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(tx *entities.EVMTransaction) bool {
+			return tx.QueryResult() != nil && tx.QueryResult()["balance"] == "1500000000000000000"
+		}))
+	})
+
+	t.Run("GET_NONCE returns nonce", func(t *testing.T) {
+		req := newRequest("GET_NONCE", map[string]interface{}{})
+		mockRPC, _, useCase := setup(req)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(42), nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.QueryResult)
+		result := resp.QueryResult.Result.(map[string]interface{})
+		assert.Equal(t, "42", result["nonce"])
+		assert.Equal(t, req.FromAddress, result["address"])
+	})
+
+	t.Run("ESTIMATE_GAS returns estimate in query result", func(t *testing.T) {
+		req := newRequest("ESTIMATE_GAS", map[string]interface{}{})
+		mockRPC, _, useCase := setup(req)
+		mockRPC.On("EstimateGas", mock.Anything, mock.AnythingOfType("rpc.CallMsg")).Return(uint64(21000), nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		result := resp.QueryResult.Result.(map[string]interface{})
+		assert.Equal(t, "25200", result["gas_estimate"])
+	})
+
+	t.Run("QUERY calls contract and decodes output", func(t *testing.T) {
+		req := newRequest("QUERY", map[string]interface{}{
+			"abi":    `[{"name":"balanceOf","type":"function","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}]`,
+			"method": "balanceOf",
+			"args":   []interface{}{"0x1111111111111111111111111111111111111111"},
+		})
+		mockRPC, _, useCase := setup(req)
+		output := make([]byte, 32)
+		output[31] = 0x64
+		mockRPC.On("CallContract", mock.Anything, mock.MatchedBy(func(msg rpc.CallMsg) bool {
+			return len(msg.Data) == 36 && msg.To != nil && msg.To.Hex() == req.ToAddress
+		})).Return(output, nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		result := resp.QueryResult.Result.(map[string]interface{})
+		assert.Equal(t, "balanceOf", result["method"])
+		assert.Equal(t, []interface{}{"100"}, result["outputs"])
+		mockRPC.AssertExpectations(t)
+	})
+
+	t.Run("QUERY surfaces revert reason", func(t *testing.T) {
+		req := newRequest("QUERY", map[string]interface{}{"data": "0x18160ddd"})
+		mockRPC, _, useCase := setup(req)
+		mockRPC.On("CallContract", mock.Anything, mock.Anything).Return(nil, &rpc.RevertError{Reason: "not initialized"})
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		var appErr *pkgerrors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, pkgerrors.ErrTransactionFailed.Code, appErr.Code)
+		assert.Equal(t, "execution reverted: not initialized", appErr.Message)
+	})
+
+	t.Run("QUERY fails without calldata", func(t *testing.T) {
+		req := newRequest("QUERY", map[string]interface{}{})
+		mockRPC, _, useCase := setup(req)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		mockRPC.AssertNotCalled(t, "CallContract", mock.Anything, mock.Anything)
+	})
+
+	t.Run("write operations have no query result", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		signers := map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, signers, nil, nil, nil, nil, nil, logger)
+
+		req := newRequest("TRANSFER", map[string]interface{}{"amount": "1"})
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil).Times(2)
+		mockRPC.On("GetNonce", mock.Anything, req.FromAddress).Return(uint64(1), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, req.FromAddress).Return("0xabc", nil)
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Nil(t, resp.QueryResult)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_NonceManager(t *testing.T) {
+	logger := zap.NewNop()
+	fromAddress := "0x1234567890123456789012345678901234567890"
+
+	newRequest := func(operationID string, idempotencyKey string) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    operationID,
+			ChainType:      "ETHEREUM",
+			OperationType:  "TRANSFER",
+			FromAddress:    fromAddress,
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        map[string]interface{}{"amount": "1"},
+			IdempotencyKey: idempotencyKey,
+		}
+	}
+
+	t.Run("reserve sequential nonces for the same sender", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nonce.NewInMemoryManager(logger),
+			nil,
+			nil,
+			logger,
+		)
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		var nonces []uint64
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, fromAddress).
+			Run(func(args mock.Arguments) { nonces = append(nonces, args.Get(1).(*types.Transaction).Nonce()) }).
+			Return("0xabc", nil)
+
+		_, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440300", "550e8400-e29b-41d4-a716-446655440301"))
+		require.NoError(t, err)
+		_, err = useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440302", "550e8400-e29b-41d4-a716-446655440303"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []uint64{4, 5}, nonces)
+	})
+
+	t.Run("release nonce when send fails", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		manager := nonce.NewInMemoryManager(logger)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			manager,
+			nil,
+			nil,
+			logger,
+		)
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, fromAddress).
+			Return("", errors.New("insufficient funds for gas * price + value"))
+
+		_, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440304", "550e8400-e29b-41d4-a716-446655440305"))
+		require.Error(t, err)
+
+		next, err := manager.Acquire(context.Background(), "ETHEREUM", fromAddress, mockRPC)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), next)
+	})
+
+	t.Run("release nonce when build fails after reservation", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		manager := nonce.NewInMemoryManager(logger)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			manager,
+			nil,
+			nil,
+			logger,
+		)
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(nil, errors.New("timeout"))
+
+		_, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440306", "550e8400-e29b-41d4-a716-446655440307"))
+		require.Error(t, err)
+
+		next, err := manager.Acquire(context.Background(), "ETHEREUM", fromAddress, mockRPC)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), next)
+		mockSigner.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("resync and resend on nonce too low", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nonce.NewInMemoryManager(logger),
+			nil,
+			nil,
+			logger,
+		)
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		// Outro processo enviou com o mesmo endereço: o node avança de 4 para 9 após a primeira reserva
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil).Once()
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(9), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4
+		}), fromAddress).Return("", errors.New("nonce too low: next nonce 9, tx nonce 4")).Once()
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 9
+		}), fromAddress).Return("0xdef", nil).Once()
+
+		resp, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440308", "550e8400-e29b-41d4-a716-446655440309"))
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		mockSigner.AssertExpectations(t)
+	})
+
+	t.Run("give up after repeated nonce too low", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nonce.NewInMemoryManager(logger),
+			nil,
+			nil,
+			logger,
+		)
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, fromAddress).Return("", errors.New("nonce too low"))
+
+		_, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440310", "550e8400-e29b-41d4-a716-446655440311"))
+
+		require.Error(t, err)
+		mockSigner.AssertNumberOfCalls(t, "SignAndSendTransaction", maxNonceResyncs+1)
+	})
+
+	t.Run("persist sent transaction for replacement by other instances", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nonce.NewInMemoryManager(logger),
+			nil,
+			nil,
+			logger,
+		)
+
+		var saved *entities.EVMTransaction
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entities.EVMTransaction) }).
+			Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, fromAddress).Return("0xdef", nil)
+
+		_, err := useCase.Execute(context.Background(), newRequest("550e8400-e29b-41d4-a716-446655440314", "550e8400-e29b-41d4-a716-446655440315"))
+
+		require.NoError(t, err)
+		require.NotNil(t, saved.RawTransaction())
+		require.NotNil(t, saved.LastBroadcastAt())
+		sent, err := rpc.DecodeTransaction(*saved.RawTransaction())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), sent.Nonce())
+	})
+
+	t.Run("already known counts as sent and redelivery consumes no other nonce", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		nonceManager := nonce.NewInMemoryManager(logger)
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nonceManager,
+			nil,
+			nil,
+			logger,
+		)
+
+		signedHash := "0x" + strings.Repeat("ab", 32)
+		var saved *entities.EVMTransaction
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(nil, errors.New("not found")).Once()
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entities.EVMTransaction) }).
+			Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, fromAddress).Return(uint64(4), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, fromAddress).
+			Return(signedHash, errors.New("already known"))
+
+		request := newRequest("550e8400-e29b-41d4-a716-446655440312", "550e8400-e29b-41d4-a716-446655440313")
+		response, err := useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		assert.Equal(t, signedHash, response.TransactionHash)
+		assert.Equal(t, entities.TransactionStatusSubmitted, saved.Status())
+
+		// Reentrega da mesma mensagem: a operação já foi enviada e não é assinada de novo
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, mock.Anything).Return(saved, nil)
+		_, err = useCase.Execute(context.Background(), request)
+
+		require.NoError(t, err)
+		mockSigner.AssertNumberOfCalls(t, "SignAndSendTransaction", 1)
+		mockRPC.AssertNumberOfCalls(t, "GetNonce", 1)
+		next, err := nonceManager.Acquire(context.Background(), "ETHEREUM", fromAddress, mockRPC)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), next)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_CancelTransaction(t *testing.T) {
+	logger := zap.NewNop()
+	operationID := "550e8400-e29b-41d4-a716-446655440500"
+
+	newStoredTransaction := func(t *testing.T) *entities.EVMTransaction {
+		opID, _ := valueobjects.NewOperationID(operationID)
+		chainType, _ := valueobjects.NewChainType("ETHEREUM")
+		opType, _ := valueobjects.NewOperationType("TRANSFER")
+		fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+		toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+		tx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, nil, "key")
+		tx.SetDynamicFeeMetadata("30000000000", "1000000000", 6)
+		tx.MarkAsFailed("confirmation timeout")
+		return tx
+	}
+
+	t.Run("cancel pending transaction with self transfer", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		monitor := rpc.NewPendingTxMonitor(mockRPC, mockSigner, nil, rpc.DefaultReplacementPolicy(), logger)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+
+		useCase := NewExecuteEVMTransactionUseCase(
+			map[string]rpc.RPCClient{"ETHEREUM": mockRPC},
+			mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner},
+			nil,
+			nil,
+			nil,
+			map[string]rpc.TransactionMonitor{"ETHEREUM": monitor},
+			eventPublisher,
+			logger,
+		)
+
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(newStoredTransaction(t), nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(1000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 6 &&
+				tx.To().Hex() == "0x1234567890123456789012345678901234567890" &&
+				tx.Value().Sign() == 0 &&
+				tx.GasFeeCap().Cmp(big.NewInt(33000000000)) >= 0 &&
+				tx.GasTipCap().Cmp(big.NewInt(1100000000)) >= 0
+		}), "0x1234567890123456789012345678901234567890").Return("0xcancel", nil)
+
+		resp, err := useCase.CancelTransaction(context.Background(), operationID)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusCancelled), resp.Status)
+		assert.Equal(t, []string{"0xcancel"}, resp.ReplacementHashes)
+		mockRepo.AssertCalled(t, "Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction"))
+		assert.Equal(t, []string{"transaction.cancelled"}, eventPublisher.Types())
+	})
+
+	t.Run("reject finalized transaction", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewExecuteEVMTransactionUseCase(nil, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+
+		tx := newStoredTransaction(t)
+		txHash, _ := valueobjects.NewTransactionHash("0x00000000000000000000000000000000000000000000000000000000000000aa")
+		tx.MarkAsSuccess(txHash, 10, 21000)
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(tx, nil)
+
+		_, err := useCase.CancelTransaction(context.Background(), operationID)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrValidationFailed.Code, appErr.Code)
+	})
+
+	t.Run("operation not found", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewExecuteEVMTransactionUseCase(nil, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		mockRepo.On("GetByOperationID", mock.Anything, operationID).Return(nil, errors.New("not found"))
+
+		_, err := useCase.CancelTransaction(context.Background(), operationID)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrOperationNotFound.Code, appErr.Code)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_ErrorClassification(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(operationType string, payload map[string]interface{}) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440090",
+			ChainType:      "ETHEREUM",
+			OperationType:  operationType,
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        payload,
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440091",
+		}
+	}
+
+	// recordStatuses registra o status de cada Save
+	recordStatuses := func(mockRepo *MockTransactionRepository) *[]entities.TransactionStatus {
+		statuses := &[]entities.TransactionStatus{}
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Run(func(args mock.Arguments) {
+			*statuses = append(*statuses, args.Get(1).(*entities.EVMTransaction).Status())
+		}).Return(nil)
+		return statuses
+	}
+
+	t.Run("insufficient funds fails permanently", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}, nil, nil, nil, nil, nil, logger)
+		req := newRequest("TRANSFER", map[string]interface{}{"amount": "1000000000000000000"})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		statuses := recordStatuses(mockRepo)
+		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).
+			Return("", errors.New("insufficient funds for gas * price + value"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrInsufficientFunds.Code, appErr.Code)
+		assert.False(t, appErr.IsRetryable())
+		assert.Equal(t, entities.TransactionStatusFailed, (*statuses)[len(*statuses)-1])
+	})
+
+	t.Run("transient rpc failure returns transaction to pending", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		statuses := recordStatuses(mockRepo)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("i/o timeout"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.CategoryRPCTransient, appErr.Category)
+		assert.True(t, appErr.IsRetryable())
+		assert.Equal(t, entities.TransactionStatusPending, (*statuses)[len(*statuses)-1])
+	})
+
+	t.Run("nonce too low after resyncs is a nonce conflict", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}, nil, nil, nil, nil, nil, logger)
+		req := newRequest("TRANSFER", map[string]interface{}{"amount": "1000000000000000000"})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		recordStatuses(mockRepo)
+		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("nonce too low"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		var appErr *pkgerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, pkgerrors.ErrNonceTooLow.Code, appErr.Code)
+		assert.Equal(t, pkgerrors.CategoryNonceConflict, appErr.Category)
+	})
+
+	t.Run("reprocess pending transaction left by a transient failure", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, nil, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		chainType, _ := valueobjects.NewChainType(req.ChainType)
+		opType, _ := valueobjects.NewOperationType(req.OperationType)
+		opID, _ := valueobjects.NewOperationID(req.OperationID)
+		fromAddr, _ := valueobjects.NewEVMAddress(req.FromAddress)
+		toAddr, _ := valueobjects.NewEVMAddress(req.ToAddress)
+		pendingTx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, req.Payload, req.IdempotencyKey)
+		pendingTx.MarkAsPendingRetry("failed to get balance")
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(pendingTx, nil)
+		statuses := recordStatuses(mockRepo)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(big.NewInt(1), nil).Once()
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSuccess), resp.Status)
+		assert.Equal(t, entities.TransactionStatusSuccess, (*statuses)[len(*statuses)-1])
+		mockRPC.AssertExpectations(t)
+	})
+}
+
+func TestExecuteEVMTransactionUseCase_Events(t *testing.T) {
+	logger := zap.NewNop()
+
+	newRequest := func(operationType string, payload map[string]interface{}) *dtos.ExecuteTransactionRequest {
+		return &dtos.ExecuteTransactionRequest{
+			OperationID:    "550e8400-e29b-41d4-a716-446655440700",
+			ChainType:      "ETHEREUM",
+			OperationType:  operationType,
+			FromAddress:    "0x1234567890123456789012345678901234567890",
+			ToAddress:      "0x0987654321098765432109876543210987654321",
+			Payload:        payload,
+			IdempotencyKey: "550e8400-e29b-41d4-a716-446655440701",
+		}
+	}
+
+	// eventData campos do evento no envelope
+	eventData := func(t *testing.T, eventPublisher *publisher.InMemoryPublisher, index int) map[string]interface{} {
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal(eventPublisher.Envelopes()[index].Data, &data))
+		return data
+	}
+
+	t.Run("read operation publishes created, processing and succeeded", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(big.NewInt(1), nil)
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"transaction.created", "transaction.processing", "transaction.succeeded"}, eventPublisher.Types())
+		for _, envelope := range eventPublisher.Envelopes() {
+			assert.Equal(t, req.OperationID, envelope.AggregateID)
+			assert.Equal(t, "tests", envelope.Source)
+		}
+		assert.Equal(t, req.IdempotencyKey, eventData(t, eventPublisher, 0)["idempotency_key"])
+	})
+
+	t.Run("write operation publishes submitted with hash and nonce", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("TRANSFER", map[string]interface{}{"amount": "1000000000000000000"})
+		txHash := "0x00000000000000000000000000000000000000000000000000000000000000ab"
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).Return(txHash, nil)
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"transaction.created", "transaction.processing", "transaction.submitted"}, eventPublisher.Types())
+		submitted := eventData(t, eventPublisher, 2)
+		assert.Equal(t, txHash, submitted["transaction_hash"])
+		assert.Equal(t, float64(10), submitted["nonce"])
+	})
+
+	t.Run("permanent failure publishes failed with error code", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		mockSigner := new(MockTransactionSigner)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo,
+			map[string]rpc.SignedTransactionClient{"ETHEREUM": mockSigner}, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("TRANSFER", map[string]interface{}{"amount": "1000000000000000000"})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetNonce", mock.Anything, mock.AnythingOfType("string")).Return(uint64(10), nil)
+		mockRPC.On("GetGasPrice", mock.Anything).Return(big.NewInt(20000000000), nil)
+		mockSigner.On("SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything).
+			Return("", errors.New("insufficient funds for gas * price + value"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"transaction.created", "transaction.processing", "transaction.failed"}, eventPublisher.Types())
+		assert.Equal(t, pkgerrors.ErrInsufficientFunds.Code, eventData(t, eventPublisher, 2)["error_code"])
+	})
+
+	t.Run("transient failure publishes pending retry", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("i/o timeout"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"transaction.created", "transaction.processing", "transaction.pending_retry"}, eventPublisher.Types())
+		assert.Equal(t, "failed to get balance", eventData(t, eventPublisher, 2)["error_message"])
+	})
+
+	t.Run("retry of pending transaction does not publish created again", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		chainType, _ := valueobjects.NewChainType(req.ChainType)
+		opType, _ := valueobjects.NewOperationType(req.OperationType)
+		opID, _ := valueobjects.NewOperationID(req.OperationID)
+		fromAddr, _ := valueobjects.NewEVMAddress(req.FromAddress)
+		toAddr, _ := valueobjects.NewEVMAddress(req.ToAddress)
+		pendingTx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, req.Payload, req.IdempotencyKey)
+		pendingTx.MarkAsPendingRetry("failed to get balance")
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(pendingTx, nil)
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(big.NewInt(1), nil)
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"transaction.processing", "transaction.succeeded"}, eventPublisher.Types())
+	})
+
+	t.Run("publish failure does not fail the execution", func(t *testing.T) {
+		mockRPC := new(MockRPCClient)
+		mockRepo := new(MockTransactionRepository)
+		eventPublisher := new(MockEventPublisher)
+		useCase := NewExecuteEVMTransactionUseCase(map[string]rpc.RPCClient{"ETHEREUM": mockRPC}, mockRepo, nil, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(nil)
+		mockRPC.On("GetBalance", mock.Anything, mock.AnythingOfType("string")).Return(big.NewInt(1), nil)
+		eventPublisher.On("Publish", mock.Anything, mock.Anything).Return(errors.New("topic not found"))
+
+		resp, err := useCase.Execute(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(entities.TransactionStatusSuccess), resp.Status)
+		eventPublisher.AssertNumberOfCalls(t, "Publish", 3)
+	})
+
+	t.Run("database failure on save publishes nothing", func(t *testing.T) {
+		mockRepo := new(MockTransactionRepository)
+		eventPublisher := publisher.NewInMemoryPublisher("tests")
+		useCase := NewExecuteEVMTransactionUseCase(nil, mockRepo, nil, nil, nil, nil, nil, eventPublisher, logger)
+		req := newRequest("GET_BALANCE", map[string]interface{}{})
+
+		mockRepo.On("GetByIdempotencyKey", mock.Anything, req.IdempotencyKey).Return(nil, errors.New("not found"))
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entities.EVMTransaction")).Return(errors.New("throttled"))
+
+		_, err := useCase.Execute(context.Background(), req)
+
+		require.Error(t, err)
+		assert.Empty(t, eventPublisher.Types())
+	})
+}
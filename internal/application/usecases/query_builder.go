@@ -0,0 +1,331 @@
+package usecases
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+)
+
+// Chaves aceitas no payload de QUERY
+const (
+	payloadKeyABI    = "abi"
+	payloadKeyMethod = "method"
+	payloadKeyArgs   = "args"
+)
+
+// Chaves do resultado de operações de leitura
+const (
+	resultKeyAddress          = "address"
+	resultKeyBalance          = "balance"
+	resultKeyBalanceFormatted = "balance_formatted"
+	resultKeyNonce            = "nonce"
+	resultKeyGasEstimate      = "gas_estimate"
+	resultKeyReturnData       = "return_data"
+	resultKeyMethod           = "method"
+	resultKeyOutputs          = "outputs"
+)
+
+// nativeDecimals casas decimais da moeda nativa das chains EVM suportadas
+const nativeDecimals = 18
+
+// QueryParams chamada eth_call extraída do payload de QUERY
+type QueryParams struct {
+	Msg rpc.CallMsg
+	// Method método do ABI usado para decodificar a saída (nil com calldata bruta)
+	Method *abi.Method
+}
+
+// ParseQueryParams monta a chamada a partir de "abi" + "method" + "args" ou de "data" já codificada
+func ParseQueryParams(
+	fromAddress valueobjects.EVMAddress,
+	toAddress valueobjects.EVMAddress,
+	payload map[string]interface{},
+) (*QueryParams, error) {
+	to := common.HexToAddress(toAddress.String())
+	params := &QueryParams{
+		Msg: rpc.CallMsg{
+			From: common.HexToAddress(fromAddress.String()),
+			To:   &to,
+		},
+	}
+
+	if _, ok := payload[payloadKeyABI]; !ok {
+		data, err := payloadBytes(payload, payloadKeyData)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("payload field %q with %q, or %q with calldata, is required for %s",
+				payloadKeyABI, payloadKeyMethod, payloadKeyData, valueobjects.OperationTypeQuery)
+		}
+		params.Msg.Data = data
+		return params, nil
+	}
+
+	contractABI, err := payloadABI(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	methodName, ok := payload[payloadKeyMethod].(string)
+	if !ok || methodName == "" {
+		return nil, fmt.Errorf("payload field %q is required with %q", payloadKeyMethod, payloadKeyABI)
+	}
+	method, ok := contractABI.Methods[methodName]
+	if !ok {
+		return nil, fmt.Errorf("method %q not found in ABI", methodName)
+	}
+
+	var rawArgs []interface{}
+	if raw, ok := payload[payloadKeyArgs]; ok && raw != nil {
+		if rawArgs, ok = raw.([]interface{}); !ok {
+			return nil, fmt.Errorf("invalid payload field %q: expected array", payloadKeyArgs)
+		}
+	}
+	if len(rawArgs) != len(method.Inputs) {
+		return nil, fmt.Errorf("method %q expects %d args, got %d", methodName, len(method.Inputs), len(rawArgs))
+	}
+
+	args := make([]interface{}, len(rawArgs))
+	for i, input := range method.Inputs {
+		if args[i], err = abiValue(input.Type, rawArgs[i]); err != nil {
+			return nil, fmt.Errorf("invalid arg %d (%s) for %q: %w", i, input.Type, methodName, err)
+		}
+	}
+
+	packed, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode args for %q: %w", methodName, err)
+	}
+
+	params.Msg.Data = append(append([]byte{}, method.ID...), packed...)
+	params.Method = &method
+	return params, nil
+}
+
+// DecodeOutput monta o resultado da chamada; com ABI, inclui as saídas decodificadas
+func (q *QueryParams) DecodeOutput(output []byte) (map[string]interface{}, error) {
+	result := map[string]interface{}{
+		resultKeyReturnData: hexutil.Encode(output),
+	}
+	if q.Method == nil {
+		return result, nil
+	}
+
+	values, err := q.Method.Outputs.Unpack(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode output of %q: %w", q.Method.Name, err)
+	}
+
+	outputs := make([]interface{}, len(values))
+	for i, value := range values {
+		outputs[i] = jsonValue(reflect.ValueOf(value))
+	}
+	result[resultKeyMethod] = q.Method.Name
+	result[resultKeyOutputs] = outputs
+	return result, nil
+}
+
+// payloadABI aceita o ABI como string JSON ou como array JSON já decodificado
+func payloadABI(payload map[string]interface{}) (abi.ABI, error) {
+	var raw []byte
+	switch v := payload[payloadKeyABI].(type) {
+	case string:
+		raw = []byte(v)
+	case []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return abi.ABI{}, fmt.Errorf("invalid payload field %q: %w", payloadKeyABI, err)
+		}
+		raw = encoded
+	default:
+		return abi.ABI{}, fmt.Errorf("invalid payload field %q: expected JSON ABI", payloadKeyABI)
+	}
+
+	contractABI, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("invalid payload field %q: %w", payloadKeyABI, err)
+	}
+	return contractABI, nil
+}
+
+// abiValue converte um argumento JSON para o tipo Go exigido pelo encoder do ABI
+func abiValue(typ abi.Type, raw interface{}) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		s, ok := raw.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("expected address, got %v", raw)
+		}
+		return common.HexToAddress(s), nil
+
+	case abi.UintTy, abi.IntTy:
+		value, err := toBigInt(raw)
+		if err != nil {
+			return nil, err
+		}
+		if typ.T == abi.UintTy && value.Sign() < 0 {
+			return nil, fmt.Errorf("expected unsigned integer, got %s", value)
+		}
+		if typ.Size > 64 {
+			return value, nil
+		}
+		// Inteiros de até 64 bits usam os tipos nativos (uint8, int32, ...)
+		target := reflect.New(typ.GetType()).Elem()
+		if typ.T == abi.UintTy {
+			if !value.IsUint64() || target.OverflowUint(value.Uint64()) {
+				return nil, fmt.Errorf("value %s overflows %s", value, typ)
+			}
+			target.SetUint(value.Uint64())
+		} else {
+			if !value.IsInt64() || target.OverflowInt(value.Int64()) {
+				return nil, fmt.Errorf("value %s overflows %s", value, typ)
+			}
+			target.SetInt(value.Int64())
+		}
+		return target.Interface(), nil
+
+	case abi.BoolTy:
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+		return nil, fmt.Errorf("expected bool, got %v", raw)
+
+	case abi.StringTy:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %v", raw)
+		}
+		return s, nil
+
+	case abi.BytesTy, abi.FixedBytesTy:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string, got %v", raw)
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+		if err != nil {
+			return nil, err
+		}
+		if typ.T == abi.BytesTy {
+			return data, nil
+		}
+		if len(data) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(data))
+		}
+		target := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(target, reflect.ValueOf(data))
+		return target.Interface(), nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %v", raw)
+		}
+		if typ.T == abi.ArrayTy && len(items) != typ.Size {
+			return nil, fmt.Errorf("expected %d items, got %d", typ.Size, len(items))
+		}
+
+		var target reflect.Value
+		if typ.T == abi.SliceTy {
+			target = reflect.MakeSlice(typ.GetType(), len(items), len(items))
+		} else {
+			target = reflect.New(typ.GetType()).Elem()
+		}
+		for i, item := range items {
+			value, err := abiValue(*typ.Elem, item)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			target.Index(i).Set(reflect.ValueOf(value))
+		}
+		return target.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported ABI type %s", typ)
+	}
+}
+
+// jsonValue converte valores decodificados do ABI em tipos que serializam sem perda (inteiros como string decimal)
+func jsonValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch value := v.Interface().(type) {
+	case *big.Int:
+		return value.String()
+	case common.Address:
+		return value.Hex()
+	case []byte:
+		return hexutil.Encode(value)
+	case string, bool:
+		return value
+	}
+
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			return hexutil.Encode(data)
+		}
+		fallthrough
+	case reflect.Slice:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = jsonValue(v.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		fields := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			fields[v.Type().Field(i).Name] = jsonValue(v.Field(i))
+		}
+		return fields
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// formatUnits formata um valor inteiro com casas decimais (ex.: wei -> ether)
+func formatUnits(value *big.Int, decimals int) string {
+	if value == nil {
+		return "0"
+	}
+
+	negative := value.Sign() < 0
+	digits := new(big.Int).Abs(value).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integer := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	formatted := integer
+	if fraction != "" {
+		formatted += "." + fraction
+	}
+	if negative {
+		formatted = "-" + formatted
+	}
+	return formatted
+}
@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const erc20TestABI = `[
+	{"name":"balanceOf","type":"function","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"name":"decimals","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"name":"allowanceInfo","type":"function","stateMutability":"view","inputs":[{"name":"owners","type":"address[]"},{"name":"tag","type":"bytes4"},{"name":"active","type":"bool"}],"outputs":[{"name":"symbol","type":"string"},{"name":"ok","type":"bool"},{"name":"id","type":"bytes32"}]}
+]`
+
+const queryFromAddress = valueobjects.EVMAddress("0x1234567890123456789012345678901234567890")
+
+func TestParseQueryParams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encode method call from ABI", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi":    erc20TestABI,
+			"method": "balanceOf",
+			"args":   []interface{}{"0x1111111111111111111111111111111111111111"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, query.Method)
+		assert.Equal(t, "0x70a08231", hexutil.Encode(query.Msg.Data[:4]))
+		assert.Len(t, query.Msg.Data, 4+32)
+		assert.Equal(t, common.HexToAddress("0x1111111111111111111111111111111111111111").Bytes(), query.Msg.Data[16:36])
+		assert.Equal(t, string(builderToAddress), query.Msg.To.Hex())
+		assert.Equal(t, string(queryFromAddress), query.Msg.From.Hex())
+	})
+
+	t.Run("encode arrays, fixed bytes and bool", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi":    erc20TestABI,
+			"method": "allowanceInfo",
+			"args": []interface{}{
+				[]interface{}{"0x1111111111111111111111111111111111111111", "0x2222222222222222222222222222222222222222"},
+				"0xdeadbeef",
+				"true",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, query.Msg.Data)
+	})
+
+	t.Run("raw calldata without ABI", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{"data": "0x18160ddd"})
+
+		require.NoError(t, err)
+		assert.Nil(t, query.Method)
+		assert.Equal(t, []byte{0x18, 0x16, 0x0d, 0xdd}, query.Msg.Data)
+	})
+
+	t.Run("ABI as decoded JSON array", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi": []interface{}{
+				map[string]interface{}{
+					"name": "decimals", "type": "function", "inputs": []interface{}{},
+					"outputs": []interface{}{map[string]interface{}{"name": "", "type": "uint8"}},
+				},
+			},
+			"method": "decimals",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "0x313ce567", hexutil.Encode(query.Msg.Data))
+	})
+
+	errorCases := []struct {
+		name    string
+		payload map[string]interface{}
+	}{
+		{"missing data and abi", map[string]interface{}{}},
+		{"invalid abi", map[string]interface{}{"abi": "not json", "method": "balanceOf"}},
+		{"missing method", map[string]interface{}{"abi": erc20TestABI}},
+		{"unknown method", map[string]interface{}{"abi": erc20TestABI, "method": "transfer"}},
+		{"wrong arg count", map[string]interface{}{"abi": erc20TestABI, "method": "balanceOf", "args": []interface{}{}}},
+		{"invalid address arg", map[string]interface{}{"abi": erc20TestABI, "method": "balanceOf", "args": []interface{}{"0x123"}}},
+		{"args not an array", map[string]interface{}{"abi": erc20TestABI, "method": "balanceOf", "args": "0x1111111111111111111111111111111111111111"}},
+		{"fixed bytes size mismatch", map[string]interface{}{
+			"abi": erc20TestABI, "method": "allowanceInfo",
+			"args": []interface{}{[]interface{}{}, "0xdead", true},
+		}},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := ParseQueryParams(queryFromAddress, builderToAddress, tc.payload)
+
+			assert.Error(t, err)
+			assert.Nil(t, query)
+		})
+	}
+}
+
+func TestQueryParams_DecodeOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decode uint256 output", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi":    erc20TestABI,
+			"method": "balanceOf",
+			"args":   []interface{}{"0x1111111111111111111111111111111111111111"},
+		})
+		require.NoError(t, err)
+
+		output := common.LeftPadBytes(big.NewInt(1500).Bytes(), 32)
+		result, err := query.DecodeOutput(output)
+
+		require.NoError(t, err)
+		assert.Equal(t, "balanceOf", result["method"])
+		assert.Equal(t, []interface{}{"1500"}, result["outputs"])
+		assert.Equal(t, hexutil.Encode(output), result["return_data"])
+	})
+
+	t.Run("decode string, bool and bytes32 outputs", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi":    erc20TestABI,
+			"method": "allowanceInfo",
+			"args":   []interface{}{[]interface{}{}, "0x00000000", false},
+		})
+		require.NoError(t, err)
+
+		id := [32]byte{0xab}
+		output, err := query.Method.Outputs.Pack("USDC", true, id)
+		require.NoError(t, err)
+
+		result, err := query.DecodeOutput(output)
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"USDC", true, hexutil.Encode(id[:])}, result["outputs"])
+	})
+
+	t.Run("raw calldata returns only hex output", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{"data": "0x18160ddd"})
+		require.NoError(t, err)
+
+		result, err := query.DecodeOutput([]byte{0x01, 0x02})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"return_data": "0x0102"}, result)
+	})
+
+	t.Run("fail on malformed output", func(t *testing.T) {
+		t.Parallel()
+
+		query, err := ParseQueryParams(queryFromAddress, builderToAddress, map[string]interface{}{
+			"abi":    erc20TestABI,
+			"method": "balanceOf",
+			"args":   []interface{}{"0x1111111111111111111111111111111111111111"},
+		})
+		require.NoError(t, err)
+
+		_, err = query.DecodeOutput([]byte{0x01})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatUnits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    *big.Int
+		decimals int
+		expected string
+	}{
+		{big.NewInt(0), 18, "0"},
+		{new(big.Int).Mul(big.NewInt(15), big.NewInt(1e17)), 18, "1.5"},
+		{big.NewInt(1), 18, "0.000000000000000001"},
+		{new(big.Int).Mul(big.NewInt(42), big.NewInt(1e18)), 18, "42"},
+		{big.NewInt(-2500), 3, "-2.5"},
+		{big.NewInt(7), 0, "7"},
+		{nil, 18, "0"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatUnits(tt.value, tt.decimals))
+	}
+}
@@ -0,0 +1,404 @@
+package usecases
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+)
+
+// Chaves aceitas no payload de operações de escrita
+const (
+	payloadKeyValue    = "value"
+	payloadKeyAmount   = "amount"
+	payloadKeyData     = "data"
+	payloadKeyGasLimit = "gas_limit"
+	payloadKeyNonce    = "nonce"
+	payloadKeyGasPrice = "gas_price"
+	payloadKeySpender  = "spender"
+
+	payloadKeyMaxFeePerGas         = "max_fee_per_gas"
+	payloadKeyMaxPriorityFeePerGas = "max_priority_fee_per_gas"
+	payloadKeyFeeSpeed             = "fee_speed"
+)
+
+// transferGasLimit gas fixo de uma transferência nativa sem data
+const transferGasLimit uint64 = 21000
+
+// erc20ApproveSelector seletor de approve(address,uint256)
+var erc20ApproveSelector = []byte{0x09, 0x5e, 0xa7, 0xb3}
+
+// TransactionParams parâmetros de transação extraídos do payload
+type TransactionParams struct {
+	To       *common.Address
+	Value    *big.Int
+	Data     []byte
+	GasLimit uint64
+	Nonce    *uint64
+	GasPrice *big.Int
+
+	// Taxas EIP-1559 informadas no payload
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	// FeeSpeed perfil de taxa solicitado (vazio = padrão da chain)
+	FeeSpeed rpc.FeeSpeed
+}
+
+// ParseTransactionParams extrai os parâmetros de uma operação de escrita a partir do payload
+func ParseTransactionParams(
+	operationType valueobjects.OperationType,
+	toAddress valueobjects.EVMAddress,
+	payload map[string]interface{},
+) (*TransactionParams, error) {
+	if !operationType.IsWriteOperation() {
+		return nil, fmt.Errorf("operation %s is not a write operation", operationType)
+	}
+
+	params := &TransactionParams{Value: big.NewInt(0)}
+
+	data, err := payloadBytes(payload, payloadKeyData)
+	if err != nil {
+		return nil, err
+	}
+	params.Data = data
+
+	if params.GasLimit, err = payloadUint64(payload, payloadKeyGasLimit); err != nil {
+		return nil, err
+	}
+
+	if _, ok := payload[payloadKeyNonce]; ok {
+		nonce, err := payloadUint64(payload, payloadKeyNonce)
+		if err != nil {
+			return nil, err
+		}
+		params.Nonce = &nonce
+	}
+
+	if params.GasPrice, err = payloadBigInt(payload, payloadKeyGasPrice); err != nil {
+		return nil, err
+	}
+
+	if err := params.parseDynamicFees(payload); err != nil {
+		return nil, err
+	}
+
+	to := common.HexToAddress(toAddress.String())
+
+	switch operationType {
+	case valueobjects.OperationTypeTransfer:
+		// "amount" é aceito como alias de "value" para transferências nativas
+		value, err := payloadValue(payload, payloadKeyValue, payloadKeyAmount)
+		if err != nil {
+			return nil, err
+		}
+		params.Value = value
+		params.To = &to
+
+	case valueobjects.OperationTypeDeploy:
+		if len(params.Data) == 0 {
+			return nil, fmt.Errorf("payload field %q with contract bytecode is required for %s", payloadKeyData, operationType)
+		}
+		if params.Value, err = payloadValue(payload, payloadKeyValue); err != nil {
+			return nil, err
+		}
+		params.To = nil
+
+	case valueobjects.OperationTypeApprove:
+		if len(params.Data) == 0 {
+			if params.Data, err = encodeApprove(payload); err != nil {
+				return nil, err
+			}
+		}
+		params.To = &to
+
+	case valueobjects.OperationTypeCall:
+		if params.Value, err = payloadValue(payload, payloadKeyValue); err != nil {
+			return nil, err
+		}
+		params.To = &to
+
+	default:
+		// SWAP, STAKE, UNSTAKE, WITHDRAW, MINT, BURN: chamadas de contrato com calldata já codificada
+		if len(params.Data) == 0 {
+			return nil, fmt.Errorf("payload field %q with calldata is required for %s", payloadKeyData, operationType)
+		}
+		if params.Value, err = payloadValue(payload, payloadKeyValue); err != nil {
+			return nil, err
+		}
+		params.To = &to
+	}
+
+	return params, nil
+}
+
+// ParseCallMsg extrai "data" e "value" do payload para simular a chamada no node (ESTIMATE_GAS)
+func ParseCallMsg(
+	fromAddress valueobjects.EVMAddress,
+	toAddress valueobjects.EVMAddress,
+	payload map[string]interface{},
+) (rpc.CallMsg, error) {
+	data, err := payloadBytes(payload, payloadKeyData)
+	if err != nil {
+		return rpc.CallMsg{}, err
+	}
+
+	value, err := payloadValue(payload, payloadKeyValue, payloadKeyAmount)
+	if err != nil {
+		return rpc.CallMsg{}, err
+	}
+
+	to := common.HexToAddress(toAddress.String())
+	return rpc.CallMsg{
+		From:  common.HexToAddress(fromAddress.String()),
+		To:    &to,
+		Data:  data,
+		Value: value,
+	}, nil
+}
+
+// CallMsg mensagem equivalente à transação, usada na estimativa de gas
+func (p *TransactionParams) CallMsg(from valueobjects.EVMAddress) rpc.CallMsg {
+	return rpc.CallMsg{
+		From:  common.HexToAddress(from.String()),
+		To:    p.To,
+		Data:  p.Data,
+		Value: p.Value,
+	}
+}
+
+// KnownGasLimit retorna o gas limit conhecido sem consultar o node (0 se precisar estimar)
+func (p *TransactionParams) KnownGasLimit(operationType valueobjects.OperationType) uint64 {
+	if p.GasLimit > 0 {
+		return p.GasLimit
+	}
+	if operationType == valueobjects.OperationTypeTransfer && len(p.Data) == 0 {
+		return transferGasLimit
+	}
+	return 0
+}
+
+// PayloadFees retorna as taxas informadas no payload (nil se precisar estimar)
+func (p *TransactionParams) PayloadFees() *rpc.FeeData {
+	if p.MaxFeePerGas != nil {
+		return &rpc.FeeData{
+			MaxFeePerGas:         p.MaxFeePerGas,
+			MaxPriorityFeePerGas: p.MaxPriorityFeePerGas,
+		}
+	}
+	if p.GasPrice != nil {
+		return &rpc.FeeData{GasPrice: p.GasPrice}
+	}
+	return nil
+}
+
+// BuildTransaction constrói a transação não assinada: EIP-1559 (DynamicFeeTx) ou legacy, conforme as taxas
+func (p *TransactionParams) BuildTransaction(nonce uint64, fees *rpc.FeeData, gasLimit uint64) (*types.Transaction, error) {
+	if fees == nil {
+		return nil, fmt.Errorf("fees are required")
+	}
+	if gasLimit == 0 {
+		return nil, fmt.Errorf("gas limit is required")
+	}
+
+	if fees.IsDynamic() {
+		if fees.MaxPriorityFeePerGas == nil {
+			return nil, fmt.Errorf("max priority fee per gas is required")
+		}
+		// ChainID é preenchido pelo signer na assinatura
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: fees.MaxPriorityFeePerGas,
+			GasFeeCap: fees.MaxFeePerGas,
+			Gas:       gasLimit,
+			To:        p.To,
+			Value:     p.Value,
+			Data:      p.Data,
+		}), nil
+	}
+
+	if fees.GasPrice == nil {
+		return nil, fmt.Errorf("gas price is required")
+	}
+
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: fees.GasPrice,
+		Gas:      gasLimit,
+		To:       p.To,
+		Value:    p.Value,
+		Data:     p.Data,
+	}), nil
+}
+
+// parseDynamicFees lê max_fee_per_gas, max_priority_fee_per_gas e fee_speed
+func (p *TransactionParams) parseDynamicFees(payload map[string]interface{}) error {
+	var err error
+	if p.MaxFeePerGas, err = payloadBigInt(payload, payloadKeyMaxFeePerGas); err != nil {
+		return err
+	}
+	if p.MaxPriorityFeePerGas, err = payloadBigInt(payload, payloadKeyMaxPriorityFeePerGas); err != nil {
+		return err
+	}
+
+	if (p.MaxFeePerGas == nil) != (p.MaxPriorityFeePerGas == nil) {
+		return fmt.Errorf("payload fields %q and %q must be provided together", payloadKeyMaxFeePerGas, payloadKeyMaxPriorityFeePerGas)
+	}
+	if p.MaxFeePerGas != nil {
+		if p.GasPrice != nil {
+			return fmt.Errorf("payload field %q cannot be combined with %q", payloadKeyGasPrice, payloadKeyMaxFeePerGas)
+		}
+		if p.MaxPriorityFeePerGas.Cmp(p.MaxFeePerGas) > 0 {
+			return fmt.Errorf("payload field %q must not exceed %q", payloadKeyMaxPriorityFeePerGas, payloadKeyMaxFeePerGas)
+		}
+	}
+
+	if raw, ok := payload[payloadKeyFeeSpeed]; ok && raw != nil {
+		value, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("invalid payload field %q: expected string", payloadKeyFeeSpeed)
+		}
+		if p.FeeSpeed, err = rpc.NewFeeSpeed(value); err != nil {
+			return fmt.Errorf("invalid payload field %q: %w", payloadKeyFeeSpeed, err)
+		}
+	}
+
+	return nil
+}
+
+// encodeApprove codifica approve(spender, amount) de um token ERC-20
+func encodeApprove(payload map[string]interface{}) ([]byte, error) {
+	rawSpender, ok := payload[payloadKeySpender].(string)
+	if !ok || !common.IsHexAddress(rawSpender) {
+		return nil, fmt.Errorf("payload field %q must be a valid address for %s", payloadKeySpender, valueobjects.OperationTypeApprove)
+	}
+
+	amount, err := payloadBigInt(payload, payloadKeyAmount)
+	if err != nil {
+		return nil, err
+	}
+	if amount == nil {
+		return nil, fmt.Errorf("payload field %q is required for %s", payloadKeyAmount, valueobjects.OperationTypeApprove)
+	}
+
+	spender := common.HexToAddress(rawSpender)
+	data := make([]byte, 0, 4+32+32)
+	data = append(data, erc20ApproveSelector...)
+	data = append(data, common.LeftPadBytes(spender.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+	return data, nil
+}
+
+// payloadValue retorna o primeiro valor numérico encontrado entre as chaves (0 se ausente)
+func payloadValue(payload map[string]interface{}, keys ...string) (*big.Int, error) {
+	for _, key := range keys {
+		value, err := payloadBigInt(payload, key)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			return value, nil
+		}
+	}
+	return big.NewInt(0), nil
+}
+
+// payloadBigInt lê um inteiro não negativo do payload (nil se ausente)
+func payloadBigInt(payload map[string]interface{}, key string) (*big.Int, error) {
+	raw, ok := payload[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	value, err := toBigInt(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload field %q: %w", key, err)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid payload field %q: must not be negative", key)
+	}
+	return value, nil
+}
+
+// payloadUint64 lê um uint64 do payload (0 se ausente)
+func payloadUint64(payload map[string]interface{}, key string) (uint64, error) {
+	value, err := payloadBigInt(payload, key)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("invalid payload field %q: value out of range", key)
+	}
+	return value.Uint64(), nil
+}
+
+// payloadBytes lê um campo hex do payload (nil se ausente)
+func payloadBytes(payload map[string]interface{}, key string) ([]byte, error) {
+	raw, ok := payload[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+
+	s, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid payload field %q: expected hex string", key)
+	}
+
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload field %q: %w", key, err)
+	}
+	return data, nil
+}
+
+// maxExactJSONInteger maior inteiro que um número JSON (float64) representa sem arredondamento
+const maxExactJSONInteger = 1 << 53
+
+// toBigInt converte os formatos numéricos aceitos no payload (string decimal/hex ou número JSON)
+func toBigInt(raw interface{}) (*big.Int, error) {
+	switch v := raw.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		base := 10
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			s = s[2:]
+			base = 16
+		}
+		value, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return value, nil
+	case json.Number:
+		value, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v.String())
+		}
+		return value, nil
+	case float64:
+		if v != math.Trunc(v) || math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("invalid integer %v", v)
+		}
+		// Números JSON acima de 2^53 já chegam arredondados; o valor exato só vem como string
+		if math.Abs(v) > maxExactJSONInteger {
+			return nil, fmt.Errorf("integer %v exceeds 2^53 and loses precision as a JSON number; send it as a decimal string", v)
+		}
+		value, _ := big.NewFloat(v).Int(nil)
+		return value, nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", raw)
+	}
+}
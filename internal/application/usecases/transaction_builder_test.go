@@ -0,0 +1,305 @@
+package usecases
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const builderToAddress = valueobjects.EVMAddress("0x0987654321098765432109876543210987654321")
+
+func TestParseTransactionParams(t *testing.T) {
+	t.Parallel()
+
+	t.Run("transfer accepts amount as value", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{
+			"amount": "1000000000000000000",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1000000000000000000", params.Value.String())
+		require.NotNil(t, params.To)
+		assert.Equal(t, string(builderToAddress), params.To.Hex())
+		assert.Nil(t, params.Nonce)
+		assert.Nil(t, params.GasPrice)
+		assert.Equal(t, uint64(21000), params.KnownGasLimit(valueobjects.OperationTypeTransfer))
+	})
+
+	t.Run("value takes precedence over amount", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{
+			"value":  "0x10",
+			"amount": "99",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(16), params.Value.Int64())
+	})
+
+	t.Run("numeric formats", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{
+			"value":     json.Number("42"),
+			"nonce":     float64(5),
+			"gas_limit": 30000,
+			"gas_price": "0x3b9aca00",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), params.Value.Int64())
+		require.NotNil(t, params.Nonce)
+		assert.Equal(t, uint64(5), *params.Nonce)
+		assert.Equal(t, uint64(30000), params.KnownGasLimit(valueobjects.OperationTypeTransfer))
+		assert.Equal(t, int64(1000000000), params.GasPrice.Int64())
+	})
+
+	t.Run("reject JSON numbers above 2^53", func(t *testing.T) {
+		t.Parallel()
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(`{"value": 1234567890123456789}`), &payload))
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, payload)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decimal string")
+		assert.Nil(t, params)
+
+		// Como string decimal o valor é preservado
+		params, err = ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{
+			"value": "1234567890123456789",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1234567890123456789", params.Value.String())
+	})
+
+	t.Run("deploy has no recipient", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeDeploy, builderToAddress, map[string]interface{}{
+			"data": "0x6080604052",
+		})
+
+		require.NoError(t, err)
+		assert.Nil(t, params.To)
+		assert.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, params.Data)
+		assert.Equal(t, uint64(0), params.KnownGasLimit(valueobjects.OperationTypeDeploy))
+	})
+
+	t.Run("approve encodes ERC-20 calldata", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeApprove, builderToAddress, map[string]interface{}{
+			"spender": "0x1111111111111111111111111111111111111111",
+			"amount":  "1000",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t,
+			"095ea7b3"+
+				"0000000000000000000000001111111111111111111111111111111111111111"+
+				"00000000000000000000000000000000000000000000000000000000000003e8",
+			hex.EncodeToString(params.Data))
+		assert.Equal(t, int64(0), params.Value.Int64())
+	})
+
+	t.Run("approve keeps explicit calldata", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeApprove, builderToAddress, map[string]interface{}{
+			"data": "0x095ea7b3",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x09, 0x5e, 0xa7, 0xb3}, params.Data)
+	})
+
+	t.Run("dynamic fees and fee speed", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{
+			"value":                    "1",
+			"max_fee_per_gas":          "30000000000",
+			"max_priority_fee_per_gas": "0x77359400",
+			"fee_speed":                "fast",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "30000000000", params.MaxFeePerGas.String())
+		assert.Equal(t, int64(2000000000), params.MaxPriorityFeePerGas.Int64())
+		assert.Equal(t, rpc.FeeSpeedFast, params.FeeSpeed)
+
+		fees := params.PayloadFees()
+		require.NotNil(t, fees)
+		assert.True(t, fees.IsDynamic())
+	})
+
+	t.Run("fees are estimated when not in payload", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{"value": "1"})
+
+		require.NoError(t, err)
+		assert.Nil(t, params.PayloadFees())
+	})
+
+	errorCases := []struct {
+		name          string
+		operationType valueobjects.OperationType
+		payload       map[string]interface{}
+	}{
+		{"read operation", valueobjects.OperationTypeGetBalance, map[string]interface{}{}},
+		{"deploy without bytecode", valueobjects.OperationTypeDeploy, map[string]interface{}{}},
+		{"swap without calldata", valueobjects.OperationTypeSwap, map[string]interface{}{"value": "1"}},
+		{"approve without spender", valueobjects.OperationTypeApprove, map[string]interface{}{"amount": "1"}},
+		{"approve without amount", valueobjects.OperationTypeApprove, map[string]interface{}{"spender": "0x1111111111111111111111111111111111111111"}},
+		{"invalid hex data", valueobjects.OperationTypeCall, map[string]interface{}{"data": "0xzz"}},
+		{"non string data", valueobjects.OperationTypeCall, map[string]interface{}{"data": 12}},
+		{"negative value", valueobjects.OperationTypeTransfer, map[string]interface{}{"value": "-1"}},
+		{"fractional value", valueobjects.OperationTypeTransfer, map[string]interface{}{"value": 1.5}},
+		{"invalid amount", valueobjects.OperationTypeTransfer, map[string]interface{}{"amount": "1.5"}},
+		{"nonce out of range", valueobjects.OperationTypeTransfer, map[string]interface{}{"nonce": "0x10000000000000000"}},
+		{"unsupported type", valueobjects.OperationTypeTransfer, map[string]interface{}{"gas_limit": true}},
+		{"max fee without priority fee", valueobjects.OperationTypeTransfer, map[string]interface{}{"max_fee_per_gas": "10"}},
+		{"priority fee above max fee", valueobjects.OperationTypeTransfer, map[string]interface{}{"max_fee_per_gas": "10", "max_priority_fee_per_gas": "11"}},
+		{"gas price with dynamic fees", valueobjects.OperationTypeTransfer, map[string]interface{}{"gas_price": "10", "max_fee_per_gas": "10", "max_priority_fee_per_gas": "1"}},
+		{"invalid fee speed", valueobjects.OperationTypeTransfer, map[string]interface{}{"fee_speed": "instant"}},
+	}
+
+	for _, tc := range errorCases {
+		t.Run("fail with "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := ParseTransactionParams(tc.operationType, builderToAddress, tc.payload)
+
+			assert.Error(t, err)
+			assert.Nil(t, params)
+		})
+	}
+}
+
+func TestTransactionParams_BuildTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("build legacy transaction", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeCall, builderToAddress, map[string]interface{}{
+			"data":  "0xa9059cbb",
+			"value": "5",
+		})
+		require.NoError(t, err)
+
+		tx, err := params.BuildTransaction(9, &rpc.FeeData{GasPrice: big.NewInt(1000)}, 60000)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint8(types.LegacyTxType), tx.Type())
+		assert.Equal(t, uint64(9), tx.Nonce())
+		assert.Equal(t, int64(1000), tx.GasPrice().Int64())
+		assert.Equal(t, uint64(60000), tx.Gas())
+		assert.Equal(t, int64(5), tx.Value().Int64())
+		assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, tx.Data())
+		require.NotNil(t, tx.To())
+		assert.Equal(t, string(builderToAddress), tx.To().Hex())
+	})
+
+	t.Run("build dynamic fee transaction", func(t *testing.T) {
+		t.Parallel()
+
+		params, err := ParseTransactionParams(valueobjects.OperationTypeTransfer, builderToAddress, map[string]interface{}{"value": "7"})
+		require.NoError(t, err)
+
+		tx, err := params.BuildTransaction(3, &rpc.FeeData{
+			MaxFeePerGas:         big.NewInt(30000000000),
+			MaxPriorityFeePerGas: big.NewInt(2000000000),
+		}, 21000)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+		assert.Equal(t, uint64(3), tx.Nonce())
+		assert.Equal(t, int64(30000000000), tx.GasFeeCap().Int64())
+		assert.Equal(t, int64(2000000000), tx.GasTipCap().Int64())
+		assert.Equal(t, int64(7), tx.Value().Int64())
+	})
+
+	t.Run("fail without fees", func(t *testing.T) {
+		t.Parallel()
+
+		params := &TransactionParams{Value: big.NewInt(0)}
+		tx, err := params.BuildTransaction(0, nil, 21000)
+
+		assert.Error(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("fail without gas price", func(t *testing.T) {
+		t.Parallel()
+
+		params := &TransactionParams{Value: big.NewInt(0)}
+		tx, err := params.BuildTransaction(0, &rpc.FeeData{}, 21000)
+
+		assert.Error(t, err)
+		assert.Nil(t, tx)
+	})
+
+	t.Run("fail without gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		params := &TransactionParams{Value: big.NewInt(0)}
+		tx, err := params.BuildTransaction(0, &rpc.FeeData{GasPrice: big.NewInt(1)}, 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, tx)
+	})
+}
+
+func TestParseCallMsg(t *testing.T) {
+	t.Parallel()
+
+	from := valueobjects.EVMAddress("0x1234567890123456789012345678901234567890")
+
+	t.Run("data and value from payload", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := ParseCallMsg(from, builderToAddress, map[string]interface{}{
+			"data":  "0xa9059cbb",
+			"value": "3",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, string(from), msg.From.Hex())
+		require.NotNil(t, msg.To)
+		assert.Equal(t, string(builderToAddress), msg.To.Hex())
+		assert.Equal(t, []byte{0xa9, 0x05, 0x9c, 0xbb}, msg.Data)
+		assert.Equal(t, int64(3), msg.Value.Int64())
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := ParseCallMsg(from, builderToAddress, map[string]interface{}{})
+
+		require.NoError(t, err)
+		assert.Empty(t, msg.Data)
+		assert.Equal(t, int64(0), msg.Value.Int64())
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseCallMsg(from, builderToAddress, map[string]interface{}{"data": "0xzz"})
+
+		assert.Error(t, err)
+	})
+}
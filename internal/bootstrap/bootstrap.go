@@ -0,0 +1,454 @@
+// Package bootstrap monta as dependências da aplicação (clientes AWS e RPC, signers, use cases)
+// compartilhadas pela Lambda e pelo worker
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/gabrielksneiva/ChainEVM/internal/application/dtos"
+	"github.com/gabrielksneiva/ChainEVM/internal/application/usecases"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/database"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/keyprovider"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/metrics"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/nonce"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/publisher"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	"github.com/gabrielksneiva/ChainEVM/pkg/cache"
+	pkgconfig "github.com/gabrielksneiva/ChainEVM/pkg/config"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// App dependências inicializadas a partir da configuração
+type App struct {
+	SQSClient      eventbus.SQSClient
+	SQSConsumer    *eventbus.SQSConsumer
+	DLQHandler     *eventbus.DLQHandler
+	RetryManager   *eventbus.RetryManager
+	ExecuteUseCase *usecases.ExecuteEVMTransactionUseCase
+	ConfirmUseCase *usecases.ConfirmTransactionUseCase
+	RPCClients     map[string]rpc.RPCClient
+	Signers        map[string]rpc.SignedTransactionClient
+
+	logger *zap.Logger
+}
+
+// builder monta as dependências com a configuração e o logger da aplicação
+type builder struct {
+	cfg *pkgconfig.Config
+	log *zap.Logger
+}
+
+// New inicializa as dependências; chains sem RPC ou signer disponível são registradas em log e ficam
+// sem suporte (ou só leitura), sem impedir a inicialização
+func New(ctx context.Context, cfg *pkgconfig.Config, awsCfg aws.Config, log *zap.Logger) *App {
+	b := &builder{cfg: cfg, log: log}
+
+	// Initialize SQS client
+	sqsAdapter := eventbus.NewSQSAdapter(sqs.NewFromConfig(awsCfg))
+	sqsConsumer := eventbus.NewSQSConsumer(sqsAdapter, cfg.SQSQueueURL, log)
+
+	// Initialize DLQ Handler for failed messages
+	dlqHandler := eventbus.NewDLQHandler(sqsAdapter, cfg.SQSQueueDLQURL, log)
+
+	// Initialize Retry Manager with the configured retry policy
+	appMetrics := metrics.NewMetrics(log)
+	retryManager := eventbus.NewRetryManagerWithConfig(dlqHandler, b.newRetryConfig(), appMetrics, log)
+
+	// Initialize DynamoDB client
+	dynamoDBAdapter := database.NewDynamoDBAdapter(dynamodb.NewFromConfig(awsCfg))
+	transactionRepo := database.NewDynamoDBTransactionRepository(dynamoDBAdapter, cfg.DynamoDBTableName, log)
+
+	// Initialize RPC clients for each chain, each endpoint behind its own circuit breaker and reads
+	// through a cache shared by all chains (chaves por chain)
+	rpcCache := b.newRPCCache()
+	rpcClients := make(map[string]rpc.RPCClient)
+	// uncachedClients leituras que precisam enxergar reorgs (confirmação e substituição de transações)
+	uncachedClients := make(map[string]rpc.RPCClient)
+	for chainName, rpcConfig := range cfg.RPCConfigs {
+		if len(rpcConfig.URLs) == 0 {
+			continue
+		}
+		client, err := b.newRPCClient(chainName, rpcConfig, appMetrics)
+		if err != nil {
+			log.Warn("failed to initialize RPC client for chain",
+				zap.String("chain", chainName),
+				zap.Error(err))
+			continue
+		}
+		uncachedClients[chainName] = client
+		if rpcCache != nil {
+			client = rpc.NewCachedRPCClient(client, chainName, rpcCache, rpc.CacheTTLs{
+				GasPrice: cfg.RPCCache.GasPriceTTL,
+				Balance:  cfg.RPCCache.BalanceTTL,
+				Receipt:  cfg.RPCCache.ReceiptTTL,
+				Head:     cfg.RPCCache.HeadTTL,
+			}, uint64(cfg.ConfirmationDepths[chainName]), appMetrics, log)
+		}
+		rpcClients[chainName] = client
+	}
+
+	// Initialize KeyProvider for signing keys
+	keyProvider, err := b.newKeyProvider(ctx, awsCfg)
+	if err != nil {
+		log.Warn("failed to initialize key provider, write operations are disabled",
+			zap.String("key_provider", cfg.KeyProvider),
+			zap.Error(err))
+	} else {
+		log.Info("key provider initialized",
+			zap.String("key_provider", cfg.KeyProvider),
+			zap.Int("accounts", len(keyProvider.Addresses())))
+	}
+
+	// Initialize one TransactionSigner per chain, using the chain ID reported by its RPC node
+	signers := make(map[string]rpc.SignedTransactionClient)
+	if keyProvider != nil {
+		for chainName, rpcClient := range rpcClients {
+			ethClientProvider, ok := rpcClient.(rpc.EthClientProvider)
+			if !ok {
+				continue
+			}
+
+			var expectedChainID *big.Int
+			if id, ok := cfg.ChainIDs[chainName]; ok {
+				expectedChainID = big.NewInt(id)
+			}
+
+			signer, err := rpc.NewChainSigner(ctx, ethClientProvider, expectedChainID, keyProvider, log, cfg.RPCTimeout)
+			if errors.Is(err, rpc.ErrChainIDMismatch) {
+				log.Error("RPC chain ID does not match the configured chain ID, write operations are disabled; set CHAIN_ID_"+chainName+" to the network of the RPC",
+					zap.String("chain", chainName),
+					zap.Error(err))
+				continue
+			}
+			if err != nil {
+				log.Error("failed to initialize TransactionSigner for chain, write operations are disabled",
+					zap.String("chain", chainName),
+					zap.Error(err))
+				continue
+			}
+
+			signers[chainName] = signer
+			log.Info("TransactionSigner initialized",
+				zap.String("chain", chainName),
+				zap.String("chain_id", signer.ChainID().String()))
+		}
+	}
+
+	// Initialize fee estimators (EIP-1559 com fallback legacy) for each chain
+	feeEstimators := make(map[string]rpc.FeeEstimator)
+	for chainName, rpcClient := range rpcClients {
+		feeEstimators[chainName] = rpc.NewFeeMarketEstimator(rpcClient, newFeeStrategy(cfg.FeeConfigs[chainName]), log)
+	}
+
+	// Initialize gas estimators (eth_estimateGas com margem e limites) for each chain
+	gasEstimators := make(map[string]rpc.GasEstimator)
+	for chainName, rpcClient := range rpcClients {
+		gasConfig := cfg.GasConfigs[chainName]
+		gasEstimators[chainName] = rpc.NewNodeGasEstimator(rpcClient, rpc.GasStrategy{
+			Multiplier:  gasConfig.Multiplier,
+			MinGasLimit: gasConfig.MinGasLimit,
+			MaxGasLimit: gasConfig.MaxGasLimit,
+		}, log)
+	}
+
+	// Initialize pending transaction monitors (replace-by-fee de transações presas) for each chain with a signer
+	txMonitors := make(map[string]rpc.TransactionMonitor)
+	for chainName, signer := range signers {
+		txMonitors[chainName] = rpc.NewPendingTxMonitor(uncachedClients[chainName], signer, feeEstimators[chainName], rpc.ReplacementPolicy{
+			StuckAfter:      cfg.Replacement.StuckTimeout,
+			FeeBumpPercent:  cfg.Replacement.FeeBumpPercent,
+			MaxReplacements: cfg.Replacement.MaxReplacements,
+		}, log)
+	}
+
+	// Initialize nonce manager (reserva concorrente de nonces por remetente)
+	var nonceManager nonce.Manager
+	if cfg.NonceTableName != "" {
+		nonceManager = nonce.NewManager(nonce.NewDynamoDBStore(dynamoDBAdapter, cfg.NonceTableName, log), log)
+	} else {
+		log.Warn("NONCE_TABLE_NAME not set, using in-memory nonce manager (not safe across concurrent instances)")
+		nonceManager = nonce.NewInMemoryManager(log)
+	}
+
+	// Initialize event publisher (eventos de domínio das transições de estado)
+	eventPublisher := b.newEventPublisher(awsCfg, sqsAdapter)
+
+	// Initialize use cases
+	executeUseCase := usecases.NewExecuteEVMTransactionUseCase(
+		rpcClients,
+		transactionRepo,
+		signers,
+		feeEstimators,
+		gasEstimators,
+		nonceManager,
+		txMonitors,
+		eventPublisher,
+		log,
+	)
+	confirmUseCase := usecases.NewConfirmTransactionUseCase(
+		uncachedClients,
+		transactionRepo,
+		txMonitors,
+		cfg.ConfirmationDepths,
+		eventPublisher,
+		log,
+	)
+
+	return &App{
+		SQSClient:      sqsAdapter,
+		SQSConsumer:    sqsConsumer,
+		DLQHandler:     dlqHandler,
+		RetryManager:   retryManager,
+		ExecuteUseCase: executeUseCase,
+		ConfirmUseCase: confirmUseCase,
+		RPCClients:     rpcClients,
+		Signers:        signers,
+		logger:         log,
+	}
+}
+
+// Execute executa a operação da mensagem da fila
+func (a *App) Execute(ctx context.Context, msg *eventbus.Message) error {
+	response, err := a.ExecuteUseCase.Execute(ctx, &dtos.ExecuteTransactionRequest{
+		OperationID:    msg.OperationID,
+		ChainType:      msg.ChainType,
+		OperationType:  msg.OperationType,
+		FromAddress:    msg.FromAddress,
+		ToAddress:      msg.ToAddress,
+		Payload:        msg.Payload,
+		IdempotencyKey: msg.IdempotencyKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	a.logger.Info("transaction executed successfully",
+		zap.String("operation_id", response.OperationID),
+		zap.String("status", response.Status))
+
+	return nil
+}
+
+// newRPCClient cria o cliente da chain; com mais de uma URL usa failover entre os endpoints e, se
+// configurado, leituras por quórum
+func (b *builder) newRPCClient(chainName string, rpcConfig pkgconfig.RPCConfig, breakerMetrics rpc.BreakerMetrics) (rpc.RPCClient, error) {
+	if len(rpcConfig.URLs) == 1 {
+		if rpcConfig.QuorumSize > 1 {
+			b.log.Warn("rpc quorum requires multiple endpoints, ignoring", zap.String("chain", chainName))
+		}
+		rpcURL := rpcConfig.URLs[0]
+		return rpc.NewEVMRPCClient(rpcURL, b.cfg.RPCTimeout, b.newCircuitBreaker(rpc.EndpointName(chainName, rpcURL), breakerMetrics), b.log)
+	}
+
+	strategy, err := rpc.NewSelectionStrategy(rpcConfig.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]rpc.Endpoint, 0, len(rpcConfig.URLs))
+	for _, rpcURL := range rpcConfig.URLs {
+		name := rpc.EndpointName(chainName, rpcURL)
+		client, err := rpc.DialEthClient(rpcURL, b.cfg.RPCTimeout, b.newCircuitBreaker(name, breakerMetrics), b.log)
+		if err != nil {
+			b.log.Warn("skipping RPC endpoint", zap.String("endpoint", name), zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, rpc.Endpoint{Name: name, Client: client})
+	}
+
+	return rpc.NewMultiEndpointRPCClient(endpoints, rpc.MultiEndpointConfig{
+		Strategy:            strategy,
+		MaxBlockLag:         rpcConfig.MaxBlockLag,
+		HealthCheckInterval: rpcConfig.HealthCheckInterval,
+		Quorum: rpc.QuorumConfig{
+			Required:     rpcConfig.QuorumSize,
+			MaxBlockSkew: rpcConfig.QuorumMaxBlockSkew,
+		},
+	}, b.cfg.RPCTimeout, b.log)
+}
+
+// newRPCCache cria o backend do cache de leituras RPC; nil se desativado. Sem Redis disponível usa o
+// cache em memória do processo
+func (b *builder) newRPCCache() cache.Cache {
+	cacheConfig := b.cfg.RPCCache
+	if strings.EqualFold(cacheConfig.Backend, "redis") {
+		ctx, cancel := context.WithTimeout(context.Background(), b.cfg.RPCTimeout)
+		defer cancel()
+
+		redisCache, err := cache.NewRedisCacheFromURL(ctx, cacheConfig.RedisURL, cache.RedisOptions{
+			Prefix: cacheConfig.RedisPrefix,
+			TTL:    cacheConfig.BalanceTTL,
+		})
+		if err == nil {
+			b.log.Info("RPC cache using redis backend", zap.String("prefix", cacheConfig.RedisPrefix))
+			return redisCache
+		}
+		b.log.Warn("failed to connect to RPC cache redis, using in-memory cache", zap.Error(err))
+	}
+
+	if cacheConfig.MaxSize <= 0 {
+		return nil
+	}
+
+	evictionPolicy, err := cache.NewEvictionPolicy(cacheConfig.EvictionPolicy)
+	if err != nil {
+		b.log.Warn("invalid RPC cache eviction policy, using lru", zap.Error(err))
+		evictionPolicy = cache.EvictionLRU
+	}
+	return cache.NewRPCCacheWithOptions(cache.Options{
+		TTL:             cacheConfig.BalanceTTL,
+		MaxSize:         cacheConfig.MaxSize,
+		Policy:          evictionPolicy,
+		JanitorInterval: cacheConfig.JanitorInterval,
+	})
+}
+
+// newCircuitBreaker cria o circuit breaker do endpoint; nil se desativado (FailureThreshold <= 0)
+func (b *builder) newCircuitBreaker(name string, breakerMetrics rpc.BreakerMetrics) *rpc.CircuitBreaker {
+	breakerConfig := b.cfg.CircuitBreaker
+	if breakerConfig.FailureThreshold <= 0 {
+		return nil
+	}
+
+	return rpc.NewNamedCircuitBreaker(name, rpc.CircuitBreakerConfig{
+		FailureThreshold: breakerConfig.FailureThreshold,
+		SuccessThreshold: breakerConfig.SuccessThreshold,
+		Timeout:          breakerConfig.OpenTimeout,
+		HalfOpenMaxCalls: breakerConfig.HalfOpenMaxCalls,
+	}, breakerMetrics, b.log)
+}
+
+// newFeeStrategy converte a configuração de taxas da chain em rpc.FeeStrategy
+func newFeeStrategy(feeConfig pkgconfig.FeeConfig) rpc.FeeStrategy {
+	strategy := rpc.DefaultFeeStrategy()
+
+	if speed, err := rpc.NewFeeSpeed(feeConfig.DefaultSpeed); err == nil {
+		strategy.DefaultSpeed = speed
+	}
+	if feeConfig.SlowPercentile > 0 || feeConfig.StandardPercentile > 0 || feeConfig.FastPercentile > 0 {
+		strategy.RewardPercentiles = map[rpc.FeeSpeed]float64{
+			rpc.FeeSpeedSlow:     feeConfig.SlowPercentile,
+			rpc.FeeSpeedStandard: feeConfig.StandardPercentile,
+			rpc.FeeSpeedFast:     feeConfig.FastPercentile,
+		}
+	}
+	if feeConfig.BaseFeeMultiplier > 0 {
+		strategy.BaseFeeMultiplier = feeConfig.BaseFeeMultiplier
+	}
+	if feeConfig.MaxFeeGwei > 0 {
+		strategy.MaxFeeCap = new(big.Int).Mul(big.NewInt(feeConfig.MaxFeeGwei), big.NewInt(1_000_000_000))
+	}
+	strategy.LegacyOnly = feeConfig.LegacyOnly
+
+	return strategy
+}
+
+// newRetryConfig política de retry da configuração; modo ou jitter desconhecidos usam o padrão
+func (b *builder) newRetryConfig() eventbus.RetryConfig {
+	retryCfg := b.cfg.Retry
+	config := eventbus.DefaultRetryConfig()
+	config.MaxRetries = retryCfg.MaxRetries
+	config.MaxElapsedTime = retryCfg.MaxElapsedTime
+	if retryCfg.BackoffMultiplier >= 1 {
+		config.BackoffMultiplier = retryCfg.BackoffMultiplier
+	}
+	if retryCfg.InitialBackoff > 0 {
+		config.InitialBackoff = retryCfg.InitialBackoff
+	}
+	if retryCfg.MaxBackoff > 0 {
+		config.MaxBackoff = retryCfg.MaxBackoff
+	}
+	if retryCfg.InitialVisibility > 0 {
+		config.InitialVisibility = retryCfg.InitialVisibility
+	}
+	if retryCfg.MaxVisibility > 0 {
+		config.MaxVisibility = retryCfg.MaxVisibility
+	}
+
+	if mode, err := eventbus.NewRetryMode(retryCfg.Mode); err == nil {
+		config.Mode = mode
+	} else if retryCfg.Mode != "" {
+		b.log.Warn("invalid retry mode, using default", zap.String("mode", retryCfg.Mode), zap.Error(err))
+	}
+	if jitter, err := eventbus.NewJitterStrategy(retryCfg.Jitter); err == nil {
+		config.Jitter = jitter
+	} else if retryCfg.Jitter != "" {
+		b.log.Warn("invalid retry jitter, using default", zap.String("jitter", retryCfg.Jitter), zap.Error(err))
+	}
+
+	if len(retryCfg.CategoryMaxRetries) > 0 {
+		config.CategoryMaxRetries = make(map[pkgerrors.Category]int, len(retryCfg.CategoryMaxRetries))
+		for category, limit := range retryCfg.CategoryMaxRetries {
+			config.CategoryMaxRetries[pkgerrors.Category(category)] = limit
+		}
+	}
+
+	return config
+}
+
+// newEventPublisher cria o publisher de EVENT_PUBLISHER; sem destino configurado ou com backend desconhecido
+// os eventos não são publicados (nil)
+func (b *builder) newEventPublisher(awsCfg aws.Config, sqsClient publisher.SQSClient) events.EventPublisher {
+	eventsCfg := b.cfg.Events
+	switch strings.ToLower(eventsCfg.Publisher) {
+	case "", "none":
+		return nil
+	case "sns":
+		if eventsCfg.TopicARN == "" {
+			b.log.Warn("EVENT_TOPIC_ARN not set, domain events will not be published")
+			return nil
+		}
+		return publisher.NewSNSPublisher(sns.NewFromConfig(awsCfg), eventsCfg.TopicARN, eventsCfg.Source, b.log)
+	case "sqs":
+		if eventsCfg.QueueURL == "" {
+			b.log.Warn("EVENT_QUEUE_URL not set, domain events will not be published")
+			return nil
+		}
+		return publisher.NewSQSPublisher(sqsClient, eventsCfg.QueueURL, eventsCfg.Source, b.log)
+	case "eventbridge":
+		client := publisher.NewAWSEventBridgeClient(awsCfg, b.cfg.RPCTimeout)
+		return publisher.NewEventBridgePublisher(client, eventsCfg.BusName, eventsCfg.Source, b.log)
+	default:
+		b.log.Warn("unknown event publisher, domain events will not be published",
+			zap.String("publisher", eventsCfg.Publisher))
+		return nil
+	}
+}
+
+// newKeyProvider cria o KeyProvider configurado em KEY_PROVIDER
+func (b *builder) newKeyProvider(ctx context.Context, awsCfg aws.Config) (keyprovider.KeyProvider, error) {
+	switch b.cfg.KeyProvider {
+	case "keystore":
+		passphrase := ""
+		if b.cfg.KeystorePasswordFile != "" {
+			data, err := os.ReadFile(b.cfg.KeystorePasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read keystore password file: %w", err)
+			}
+			passphrase = strings.TrimRight(string(data), "\r\n")
+		}
+		return keyprovider.NewKeystoreKeyProvider(b.cfg.KeystoreDir, passphrase, b.log)
+	case "file":
+		return keyprovider.NewSecretFileKeyProvider(b.cfg.SignerKeyFile)
+	case "kms":
+		kmsClient := keyprovider.NewAWSKMSClient(kms.NewFromConfig(awsCfg))
+		return keyprovider.NewKMSKeyProvider(ctx, kmsClient, b.cfg.KMSKeyIDs, b.log)
+	case "env":
+		return keyprovider.NewEnvKeyProvider("SIGNER_PRIVATE_KEYS")
+	default:
+		return nil, fmt.Errorf("unknown key provider: %s", b.cfg.KeyProvider)
+	}
+}
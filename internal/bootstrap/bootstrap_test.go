@@ -0,0 +1,186 @@
+package bootstrap
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/eventbus"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/publisher"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/rpc"
+	"github.com/gabrielksneiva/ChainEVM/pkg/cache"
+	pkgconfig "github.com/gabrielksneiva/ChainEVM/pkg/config"
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewFeeStrategy(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, rpc.DefaultFeeStrategy(), newFeeStrategy(pkgconfig.FeeConfig{}))
+	})
+
+	t.Run("overrides", func(t *testing.T) {
+		strategy := newFeeStrategy(pkgconfig.FeeConfig{
+			DefaultSpeed:       "fast",
+			StandardPercentile: 60,
+			BaseFeeMultiplier:  3,
+			MaxFeeGwei:         200,
+			LegacyOnly:         true,
+		})
+
+		assert.Equal(t, rpc.FeeSpeedFast, strategy.DefaultSpeed)
+		assert.Equal(t, 60.0, strategy.RewardPercentiles[rpc.FeeSpeedStandard])
+		assert.EqualValues(t, 3, strategy.BaseFeeMultiplier)
+		assert.Equal(t, big.NewInt(200_000_000_000), strategy.MaxFeeCap)
+		assert.True(t, strategy.LegacyOnly)
+	})
+}
+
+func TestBuilder_NewCircuitBreaker(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{}, log: zap.NewNop()}
+
+		assert.Nil(t, b.newCircuitBreaker("ETHEREUM", nil))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{CircuitBreaker: pkgconfig.CircuitBreakerConfig{
+			FailureThreshold: 5,
+			SuccessThreshold: 2,
+			OpenTimeout:      30 * time.Second,
+			HalfOpenMaxCalls: 1,
+		}}, log: zap.NewNop()}
+
+		assert.NotNil(t, b.newCircuitBreaker("ETHEREUM", nil))
+	})
+}
+
+func TestBuilder_NewRPCCache(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{}, log: zap.NewNop()}
+
+		assert.Nil(t, b.newRPCCache())
+	})
+
+	t.Run("in-memory cache", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{RPCCache: pkgconfig.RPCCacheConfig{
+			Backend:        "memory",
+			MaxSize:        100,
+			EvictionPolicy: "lfu",
+			BalanceTTL:     time.Second,
+		}}, log: zap.NewNop()}
+
+		rpcCache := b.newRPCCache()
+
+		assert.IsType(t, &cache.RPCCache{}, rpcCache)
+	})
+
+	t.Run("fall back to memory without redis", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{
+			RPCTimeout: 100 * time.Millisecond,
+			RPCCache: pkgconfig.RPCCacheConfig{
+				Backend:  "redis",
+				RedisURL: "redis://127.0.0.1:1/0",
+				MaxSize:  100,
+			},
+		}, log: zap.NewNop()}
+
+		assert.IsType(t, &cache.RPCCache{}, b.newRPCCache())
+	})
+}
+
+func TestBuilder_NewRetryConfig(t *testing.T) {
+	t.Run("overrides", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{Retry: pkgconfig.RetryConfig{
+			MaxRetries:         5,
+			CategoryMaxRetries: map[string]int{"NONCE_CONFLICT": 10},
+			MaxElapsedTime:     time.Hour,
+			Mode:               "in_process",
+			Jitter:             "decorrelated",
+			InitialVisibility:  10 * time.Second,
+		}}, log: zap.NewNop()}
+
+		config := b.newRetryConfig()
+
+		assert.Equal(t, 5, config.MaxRetries)
+		assert.Equal(t, map[pkgerrors.Category]int{pkgerrors.CategoryNonceConflict: 10}, config.CategoryMaxRetries)
+		assert.Equal(t, time.Hour, config.MaxElapsedTime)
+		assert.Equal(t, eventbus.RetryModeInProcess, config.Mode)
+		assert.Equal(t, eventbus.JitterDecorrelated, config.Jitter)
+		assert.Equal(t, 10*time.Second, config.InitialVisibility)
+		assert.Equal(t, 15*time.Minute, config.MaxVisibility)
+	})
+
+	t.Run("unknown mode and jitter use defaults", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{Retry: pkgconfig.RetryConfig{MaxRetries: 3, Mode: "sleep", Jitter: "equal"}}, log: zap.NewNop()}
+
+		config := b.newRetryConfig()
+
+		assert.Equal(t, eventbus.RetryModeVisibility, config.Mode)
+		assert.Equal(t, eventbus.JitterFull, config.Jitter)
+	})
+}
+
+func TestBuilder_NewEventPublisher(t *testing.T) {
+	newBuilder := func(events pkgconfig.EventsConfig) *builder {
+		return &builder{cfg: &pkgconfig.Config{Events: events}, log: zap.NewNop()}
+	}
+	awsCfg := aws.Config{Region: "us-east-1"}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newBuilder(pkgconfig.EventsConfig{Publisher: "none"}).newEventPublisher(awsCfg, nil))
+	})
+
+	t.Run("sns", func(t *testing.T) {
+		eventPublisher := newBuilder(pkgconfig.EventsConfig{Publisher: "sns", TopicARN: "arn:aws:sns:us-east-1:123456789012:events"}).
+			newEventPublisher(awsCfg, nil)
+
+		assert.IsType(t, &publisher.SNSPublisher{}, eventPublisher)
+	})
+
+	t.Run("sqs", func(t *testing.T) {
+		eventPublisher := newBuilder(pkgconfig.EventsConfig{Publisher: "SQS", QueueURL: "https://sqs/events"}).
+			newEventPublisher(awsCfg, nil)
+
+		assert.IsType(t, &publisher.SQSPublisher{}, eventPublisher)
+	})
+
+	t.Run("eventbridge", func(t *testing.T) {
+		eventPublisher := newBuilder(pkgconfig.EventsConfig{Publisher: "eventbridge"}).newEventPublisher(awsCfg, nil)
+
+		assert.IsType(t, &publisher.EventBridgePublisher{}, eventPublisher)
+	})
+
+	t.Run("missing target", func(t *testing.T) {
+		assert.Nil(t, newBuilder(pkgconfig.EventsConfig{Publisher: "sns"}).newEventPublisher(awsCfg, nil))
+		assert.Nil(t, newBuilder(pkgconfig.EventsConfig{Publisher: "sqs"}).newEventPublisher(awsCfg, nil))
+	})
+
+	t.Run("unknown publisher", func(t *testing.T) {
+		assert.Nil(t, newBuilder(pkgconfig.EventsConfig{Publisher: "kafka"}).newEventPublisher(awsCfg, nil))
+	})
+}
+
+func TestBuilder_NewKeyProvider(t *testing.T) {
+	t.Run("unknown provider", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{KeyProvider: "vault"}, log: zap.NewNop()}
+
+		_, err := b.newKeyProvider(context.Background(), aws.Config{})
+
+		assert.ErrorContains(t, err, "unknown key provider: vault")
+	})
+
+	t.Run("missing keystore password file", func(t *testing.T) {
+		b := &builder{cfg: &pkgconfig.Config{
+			KeyProvider:          "keystore",
+			KeystorePasswordFile: t.TempDir() + "/missing",
+		}, log: zap.NewNop()}
+
+		_, err := b.newKeyProvider(context.Background(), aws.Config{})
+
+		assert.ErrorContains(t, err, "failed to read keystore password file")
+	})
+}
@@ -8,22 +8,30 @@ import (
 
 // EVMTransaction representa uma transação EVM no domínio
 type EVMTransaction struct {
-	operationID    valueobjects.OperationID
-	chainType      valueobjects.ChainType
-	operationType  valueobjects.OperationType
-	fromAddress    valueobjects.EVMAddress
-	toAddress      valueobjects.EVMAddress
-	payload        map[string]interface{}
-	txHash         valueobjects.TransactionHash
-	status         TransactionStatus
-	createdAt      time.Time
-	executedAt     *time.Time
-	blockNumber    *int64
-	gasUsed        *int64
-	gasPrice       *string
-	nonce          *int64
-	errorMessage   string
-	idempotencyKey string
+	operationID       valueobjects.OperationID
+	chainType         valueobjects.ChainType
+	operationType     valueobjects.OperationType
+	fromAddress       valueobjects.EVMAddress
+	toAddress         valueobjects.EVMAddress
+	payload           map[string]interface{}
+	txHash            valueobjects.TransactionHash
+	status            TransactionStatus
+	createdAt         time.Time
+	executedAt        *time.Time
+	blockNumber       *int64
+	blockHash         *string
+	gasUsed           *int64
+	gasPrice          *string
+	maxFeePerGas      *string
+	maxPriorityFee    *string
+	nonce             *int64
+	gasEstimate       *int64
+	queryResult       map[string]interface{}
+	replacementHashes []string
+	rawTransaction    *string
+	lastBroadcastAt   *time.Time
+	errorMessage      string
+	idempotencyKey    string
 }
 
 // TransactionStatus status da transação
@@ -32,9 +40,11 @@ type TransactionStatus string
 const (
 	TransactionStatusPending    TransactionStatus = "PENDING"
 	TransactionStatusProcessing TransactionStatus = "PROCESSING"
+	TransactionStatusSubmitted  TransactionStatus = "SUBMITTED"
 	TransactionStatusSuccess    TransactionStatus = "SUCCESS"
 	TransactionStatusFailed     TransactionStatus = "FAILED"
 	TransactionStatusConfirmed  TransactionStatus = "CONFIRMED"
+	TransactionStatusCancelled  TransactionStatus = "CANCELLED"
 )
 
 // NewEVMTransaction cria uma nova transação EVM
@@ -105,6 +115,10 @@ func (t *EVMTransaction) BlockNumber() *int64 {
 	return t.blockNumber
 }
 
+func (t *EVMTransaction) BlockHash() *string {
+	return t.blockHash
+}
+
 func (t *EVMTransaction) GasUsed() *int64 {
 	return t.gasUsed
 }
@@ -113,10 +127,39 @@ func (t *EVMTransaction) GasPrice() *string {
 	return t.gasPrice
 }
 
+func (t *EVMTransaction) MaxFeePerGas() *string {
+	return t.maxFeePerGas
+}
+
+func (t *EVMTransaction) MaxPriorityFeePerGas() *string {
+	return t.maxPriorityFee
+}
+
 func (t *EVMTransaction) Nonce() *int64 {
 	return t.nonce
 }
 
+func (t *EVMTransaction) GasEstimate() *int64 {
+	return t.gasEstimate
+}
+
+func (t *EVMTransaction) QueryResult() map[string]interface{} {
+	return t.queryResult
+}
+
+func (t *EVMTransaction) ReplacementHashes() []string {
+	return t.replacementHashes
+}
+
+// RawTransaction última versão enviada (não assinada, serializada)
+func (t *EVMTransaction) RawTransaction() *string {
+	return t.rawTransaction
+}
+
+func (t *EVMTransaction) LastBroadcastAt() *time.Time {
+	return t.lastBroadcastAt
+}
+
 func (t *EVMTransaction) ErrorMessage() string {
 	return t.errorMessage
 }
@@ -130,6 +173,12 @@ func (t *EVMTransaction) MarkAsProcessing() {
 	t.status = TransactionStatusProcessing
 }
 
+// MarkAsSubmitted a transação foi enviada ao node e aguarda as confirmações
+func (t *EVMTransaction) MarkAsSubmitted(txHash valueobjects.TransactionHash) {
+	t.status = TransactionStatusSubmitted
+	t.txHash = txHash
+}
+
 func (t *EVMTransaction) MarkAsSuccess(txHash valueobjects.TransactionHash, blockNumber int64, gasUsed int64) {
 	t.status = TransactionStatusSuccess
 	t.txHash = txHash
@@ -139,10 +188,39 @@ func (t *EVMTransaction) MarkAsSuccess(txHash valueobjects.TransactionHash, bloc
 	t.executedAt = &now
 }
 
+// SetInclusion registra o bloco em que a transação foi minerada (ainda sujeito a reorg)
+func (t *EVMTransaction) SetInclusion(blockNumber int64, blockHash string) {
+	t.blockNumber = &blockNumber
+	t.blockHash = &blockHash
+}
+
+// MarkAsReorged o bloco da transação saiu da chain canônica: volta para SUBMITTED sem bloco
+func (t *EVMTransaction) MarkAsReorged() {
+	t.status = TransactionStatusSubmitted
+	t.blockNumber = nil
+	t.blockHash = nil
+	t.gasUsed = nil
+	t.executedAt = nil
+}
+
 func (t *EVMTransaction) MarkAsConfirmed() {
 	t.status = TransactionStatusConfirmed
 }
 
+// MarkAsCancelled a transação foi substituída por um cancelamento (transferência de valor zero)
+func (t *EVMTransaction) MarkAsCancelled() {
+	t.status = TransactionStatusCancelled
+	t.errorMessage = "transaction cancelled"
+	now := time.Now()
+	t.executedAt = &now
+}
+
+// MarkAsPendingRetry a execução falhou por um erro transitório: volta para PENDING para ser reprocessada
+func (t *EVMTransaction) MarkAsPendingRetry(errorMsg string) {
+	t.status = TransactionStatusPending
+	t.errorMessage = errorMsg
+}
+
 func (t *EVMTransaction) MarkAsFailed(errorMsg string) {
 	t.status = TransactionStatusFailed
 	t.errorMessage = errorMsg
@@ -154,3 +232,32 @@ func (t *EVMTransaction) SetTxMetadata(gasPrice string, nonce int64) {
 	t.gasPrice = &gasPrice
 	t.nonce = &nonce
 }
+
+// SetDynamicFeeMetadata registra as taxas de uma transação EIP-1559
+func (t *EVMTransaction) SetDynamicFeeMetadata(maxFeePerGas string, maxPriorityFeePerGas string, nonce int64) {
+	t.maxFeePerGas = &maxFeePerGas
+	t.maxPriorityFee = &maxPriorityFeePerGas
+	t.nonce = &nonce
+}
+
+// SetGasEstimate registra o gas limit estimado (operação ESTIMATE_GAS)
+func (t *EVMTransaction) SetGasEstimate(gas int64) {
+	t.gasEstimate = &gas
+}
+
+// SetQueryResult registra o resultado de uma operação de leitura
+func (t *EVMTransaction) SetQueryResult(result map[string]interface{}) {
+	t.queryResult = result
+}
+
+// AddReplacementHash registra o hash de uma substituição enviada para a operação
+func (t *EVMTransaction) AddReplacementHash(txHash string) {
+	t.replacementHashes = append(t.replacementHashes, txHash)
+}
+
+// SetBroadcast registra a última versão enviada e o momento do envio, usados para reconstruir e substituir
+// a transação em outra instância
+func (t *EVMTransaction) SetBroadcast(rawTransaction string, broadcastAt time.Time) {
+	t.rawTransaction = &rawTransaction
+	t.lastBroadcastAt = &broadcastAt
+}
@@ -75,6 +75,51 @@ func TestMarkAsConfirmed(t *testing.T) {
 	assert.Equal(t, TransactionStatusConfirmed, tx.Status())
 }
 
+func TestMarkAsSubmitted(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+
+	txHash, _ := valueobjects.NewTransactionHash("0x1234567890123456789012345678901234567890123456789012345678901234")
+	tx.MarkAsSubmitted(txHash)
+
+	assert.Equal(t, TransactionStatusSubmitted, tx.Status())
+	assert.Equal(t, txHash, tx.TxHash())
+	assert.Nil(t, tx.BlockNumber())
+	assert.Nil(t, tx.ExecutedAt())
+}
+
+func TestSetInclusionAndMarkAsReorged(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+
+	txHash, _ := valueobjects.NewTransactionHash("0x1234567890123456789012345678901234567890123456789012345678901234")
+	tx.MarkAsSubmitted(txHash)
+	tx.SetInclusion(12345, "0xblock")
+
+	assert.Equal(t, TransactionStatusSubmitted, tx.Status())
+	assert.Equal(t, int64(12345), *tx.BlockNumber())
+	assert.Equal(t, "0xblock", *tx.BlockHash())
+
+	tx.MarkAsReorged()
+
+	assert.Equal(t, TransactionStatusSubmitted, tx.Status())
+	assert.Equal(t, txHash, tx.TxHash())
+	assert.Nil(t, tx.BlockNumber())
+	assert.Nil(t, tx.BlockHash())
+	assert.Nil(t, tx.GasUsed())
+	assert.Nil(t, tx.ExecutedAt())
+}
+
 func TestMarkAsSuccess(t *testing.T) {
 	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
 	chainType, _ := valueobjects.NewChainType("ETHEREUM")
@@ -109,6 +154,22 @@ func TestMarkAsFailed(t *testing.T) {
 	assert.NotNil(t, tx.ExecutedAt())
 }
 
+func TestMarkAsPendingRetry(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+	tx.MarkAsProcessing()
+	tx.MarkAsPendingRetry("failed to get gas price")
+
+	assert.Equal(t, TransactionStatusPending, tx.Status())
+	assert.Equal(t, "failed to get gas price", tx.ErrorMessage())
+	assert.Nil(t, tx.ExecutedAt())
+}
+
 func TestSetTxMetadata(t *testing.T) {
 	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
 	chainType, _ := valueobjects.NewChainType("ETHEREUM")
@@ -124,3 +185,71 @@ func TestSetTxMetadata(t *testing.T) {
 	assert.NotNil(t, tx.Nonce())
 	assert.Equal(t, int64(5), *tx.Nonce())
 }
+
+func TestSetDynamicFeeMetadata(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+	tx.SetDynamicFeeMetadata("30000000000", "2000000000", 7)
+
+	assert.Nil(t, tx.GasPrice())
+	assert.NotNil(t, tx.MaxFeePerGas())
+	assert.Equal(t, "30000000000", *tx.MaxFeePerGas())
+	assert.NotNil(t, tx.MaxPriorityFeePerGas())
+	assert.Equal(t, "2000000000", *tx.MaxPriorityFeePerGas())
+	assert.Equal(t, int64(7), *tx.Nonce())
+}
+
+func TestSetGasEstimate(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("ESTIMATE_GAS")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+	assert.Nil(t, tx.GasEstimate())
+
+	tx.SetGasEstimate(60000)
+
+	assert.NotNil(t, tx.GasEstimate())
+	assert.Equal(t, int64(60000), *tx.GasEstimate())
+}
+
+func TestSetQueryResult(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("GET_BALANCE")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+	assert.Nil(t, tx.QueryResult())
+
+	tx.SetQueryResult(map[string]interface{}{"balance": "1000"})
+
+	assert.Equal(t, "1000", tx.QueryResult()["balance"])
+}
+
+func TestReplacementAndCancellation(t *testing.T) {
+	operationID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	operationType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x1234567890123456789012345678901234567890")
+	toAddr, _ := valueobjects.NewEVMAddress("0x0987654321098765432109876543210987654321")
+
+	tx := NewEVMTransaction(operationID, chainType, operationType, fromAddr, toAddr, map[string]interface{}{}, "key")
+	assert.Empty(t, tx.ReplacementHashes())
+
+	tx.AddReplacementHash("0xaaa")
+	tx.AddReplacementHash("0xbbb")
+	tx.MarkAsCancelled()
+
+	assert.Equal(t, []string{"0xaaa", "0xbbb"}, tx.ReplacementHashes())
+	assert.Equal(t, TransactionStatusCancelled, tx.Status())
+	assert.NotNil(t, tx.ExecutedAt())
+}
@@ -91,6 +91,24 @@ func TestNewTransactionConfirmedEvent(t *testing.T) {
 	assert.Equal(t, 15, event.Confirmations)
 }
 
+func TestNewTransactionReorgedEvent(t *testing.T) {
+	event := NewTransactionReorgedEvent(
+		"op-321",
+		"POLYGON",
+		"0x1234567890123456789012345678901234567890123456789012345678901234",
+		500,
+		"0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd",
+	)
+
+	require.NotNil(t, event)
+	assert.Equal(t, "transaction.reorged", event.EventType())
+	assert.Equal(t, "op-321", event.AggregateID())
+	assert.Equal(t, "POLYGON", event.ChainType)
+	assert.Equal(t, "0x1234567890123456789012345678901234567890123456789012345678901234", event.TransactionHash)
+	assert.Equal(t, int64(500), event.BlockNumber)
+	assert.Equal(t, "0xabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcdefabcd", event.BlockHash)
+}
+
 func TestBaseDomainEventAttributes(t *testing.T) {
 	event := NewBaseDomainEvent("custom.event", "custom-agg")
 
@@ -132,3 +150,33 @@ func TestTransactionEventsTimestamps(t *testing.T) {
 	assert.NotNil(t, event1.FailedAt)
 	assert.NotNil(t, event2.ExecutedAt)
 }
+
+func TestNewTransactionSubmittedEvent(t *testing.T) {
+	nonce := int64(42)
+	event := NewTransactionSubmittedEvent("op-555", "ETHEREUM", "0xhash", &nonce)
+
+	require.NotNil(t, event)
+	assert.Equal(t, "transaction.submitted", event.EventType())
+	assert.Equal(t, "op-555", event.AggregateID())
+	assert.Equal(t, "0xhash", event.TransactionHash)
+	assert.Equal(t, int64(42), *event.Nonce)
+}
+
+func TestNewTransactionPendingRetryEvent(t *testing.T) {
+	event := NewTransactionPendingRetryEvent("op-666", "BSC", "rpc timeout", "RPC_FAILED")
+
+	require.NotNil(t, event)
+	assert.Equal(t, "transaction.pending_retry", event.EventType())
+	assert.Equal(t, "op-666", event.AggregateID())
+	assert.Equal(t, "rpc timeout", event.ErrorMessage)
+	assert.Equal(t, "RPC_FAILED", event.ErrorCode)
+}
+
+func TestNewTransactionCancelledEvent(t *testing.T) {
+	event := NewTransactionCancelledEvent("op-777", "POLYGON", "0xcancel")
+
+	require.NotNil(t, event)
+	assert.Equal(t, "transaction.cancelled", event.EventType())
+	assert.Equal(t, "op-777", event.AggregateID())
+	assert.Equal(t, "0xcancel", event.CancelTransactionHash)
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EnvelopeVersion versão do formato do envelope; mudanças incompatíveis nos campos do envelope
+// ou nos dados dos eventos incrementam a versão
+const EnvelopeVersion = "1.0"
+
+// Envelope formato JSON versionado em que os eventos são publicados; Data contém os campos do evento
+type Envelope struct {
+	Version     string          `json:"version"`
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Source      string          `json:"source"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// NewEnvelope envolve o evento com um ID único e a origem informada
+func NewEnvelope(source string, event DomainEvent) (*Envelope, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event %s: %w", event.EventType(), err)
+	}
+
+	id, err := newEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		Version:     EnvelopeVersion,
+		ID:          id,
+		Type:        event.EventType(),
+		Source:      source,
+		AggregateID: event.AggregateID(),
+		OccurredAt:  event.OccurredAt().UTC(),
+		Data:        data,
+	}, nil
+}
+
+// Marshal serializa o envelope
+func (e *Envelope) Marshal() ([]byte, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+	return body, nil
+}
+
+// newEventID UUID v4 do envelope (chave de deduplicação dos consumidores)
+func newEventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate event ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	event := NewTransactionSucceededEvent("op-456", "POLYGON", "0xhash", 100, 21000)
+
+	envelope, err := NewEnvelope("chainevm", event)
+
+	require.NoError(t, err)
+	assert.Equal(t, EnvelopeVersion, envelope.Version)
+	assert.Equal(t, "transaction.succeeded", envelope.Type)
+	assert.Equal(t, "chainevm", envelope.Source)
+	assert.Equal(t, "op-456", envelope.AggregateID)
+	assert.Equal(t, event.OccurredAt().UTC(), envelope.OccurredAt)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, envelope.ID)
+
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal(envelope.Data, &data))
+	assert.Equal(t, "op-456", data["operation_id"])
+	assert.Equal(t, "0xhash", data["transaction_hash"])
+	assert.Equal(t, float64(100), data["block_number"])
+	assert.Equal(t, float64(21000), data["gas_used"])
+}
+
+func TestEnvelope_Marshal(t *testing.T) {
+	nonce := int64(7)
+	envelope, err := NewEnvelope("chainevm", NewTransactionSubmittedEvent("op-1", "ETHEREUM", "0xabc", &nonce))
+	require.NoError(t, err)
+
+	body, err := envelope.Marshal()
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "1.0", decoded["version"])
+	assert.Equal(t, "transaction.submitted", decoded["type"])
+	assert.Equal(t, "op-1", decoded["aggregate_id"])
+	assert.Equal(t, map[string]interface{}{
+		"operation_id":     "op-1",
+		"chain_type":       "ETHEREUM",
+		"transaction_hash": "0xabc",
+		"nonce":            float64(7),
+	}, decoded["data"])
+}
+
+func TestNewEnvelope_UniqueIDs(t *testing.T) {
+	event := NewTransactionProcessingEvent("op-1", "ETHEREUM")
+
+	first, err := NewEnvelope("chainevm", event)
+	require.NoError(t, err)
+	second, err := NewEnvelope("chainevm", event)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.ID, second.ID)
+}
@@ -0,0 +1,8 @@
+package events
+
+import "context"
+
+// EventPublisher publica eventos de domínio para consumidores externos
+type EventPublisher interface {
+	Publish(ctx context.Context, event DomainEvent) error
+}
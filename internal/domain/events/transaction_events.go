@@ -5,12 +5,12 @@ import "time"
 // TransactionCreatedEvent evento disparado quando uma transação é criada
 type TransactionCreatedEvent struct {
 	*BaseDomainEvent
-	OperationID    string
-	ChainType      string
-	OperationType  string
-	FromAddress    string
-	ToAddress      string
-	IdempotencyKey string
+	OperationID    string `json:"operation_id"`
+	ChainType      string `json:"chain_type"`
+	OperationType  string `json:"operation_type"`
+	FromAddress    string `json:"from_address"`
+	ToAddress      string `json:"to_address"`
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 // NewTransactionCreatedEvent cria um novo evento de transação criada
@@ -36,8 +36,8 @@ func NewTransactionCreatedEvent(
 // TransactionProcessingEvent evento disparado quando uma transação entra em processamento
 type TransactionProcessingEvent struct {
 	*BaseDomainEvent
-	OperationID string
-	ChainType   string
+	OperationID string `json:"operation_id"`
+	ChainType   string `json:"chain_type"`
 }
 
 // NewTransactionProcessingEvent cria um novo evento de processamento
@@ -49,15 +49,35 @@ func NewTransactionProcessingEvent(operationID, chainType string) *TransactionPr
 	}
 }
 
+// TransactionSubmittedEvent evento disparado quando uma transação de escrita é enviada ao node
+type TransactionSubmittedEvent struct {
+	*BaseDomainEvent
+	OperationID     string `json:"operation_id"`
+	ChainType       string `json:"chain_type"`
+	TransactionHash string `json:"transaction_hash"`
+	Nonce           *int64 `json:"nonce,omitempty"`
+}
+
+// NewTransactionSubmittedEvent cria um novo evento de envio
+func NewTransactionSubmittedEvent(operationID, chainType, txHash string, nonce *int64) *TransactionSubmittedEvent {
+	return &TransactionSubmittedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("transaction.submitted", operationID),
+		OperationID:     operationID,
+		ChainType:       chainType,
+		TransactionHash: txHash,
+		Nonce:           nonce,
+	}
+}
+
 // TransactionSucceededEvent evento disparado quando uma transação é bem-sucedida
 type TransactionSucceededEvent struct {
 	*BaseDomainEvent
-	OperationID     string
-	ChainType       string
-	TransactionHash string
-	BlockNumber     int64
-	GasUsed         int64
-	ExecutedAt      time.Time
+	OperationID     string    `json:"operation_id"`
+	ChainType       string    `json:"chain_type"`
+	TransactionHash string    `json:"transaction_hash"`
+	BlockNumber     int64     `json:"block_number"`
+	GasUsed         int64     `json:"gas_used"`
+	ExecutedAt      time.Time `json:"executed_at"`
 }
 
 // NewTransactionSucceededEvent cria um novo evento de sucesso
@@ -82,10 +102,12 @@ func NewTransactionSucceededEvent(
 // TransactionFailedEvent evento disparado quando uma transação falha
 type TransactionFailedEvent struct {
 	*BaseDomainEvent
-	OperationID  string
-	ChainType    string
-	ErrorMessage string
-	FailedAt     time.Time
+	OperationID  string `json:"operation_id"`
+	ChainType    string `json:"chain_type"`
+	ErrorMessage string `json:"error_message"`
+	// ErrorCode código do erro da aplicação (vazio quando a falha não vem de um AppError)
+	ErrorCode string    `json:"error_code,omitempty"`
+	FailedAt  time.Time `json:"failed_at"`
 }
 
 // NewTransactionFailedEvent cria um novo evento de falha
@@ -102,9 +124,9 @@ func NewTransactionFailedEvent(operationID, chainType, errorMessage string) *Tra
 // TransactionConfirmedEvent evento disparado quando uma transação é confirmada
 type TransactionConfirmedEvent struct {
 	*BaseDomainEvent
-	OperationID   string
-	ChainType     string
-	Confirmations int
+	OperationID   string `json:"operation_id"`
+	ChainType     string `json:"chain_type"`
+	Confirmations int    `json:"confirmations"`
 }
 
 // NewTransactionConfirmedEvent cria um novo evento de confirmação
@@ -116,3 +138,69 @@ func NewTransactionConfirmedEvent(operationID, chainType string, confirmations i
 		Confirmations:   confirmations,
 	}
 }
+
+// TransactionReorgedEvent evento disparado quando o bloco de uma transação sai da chain canônica
+type TransactionReorgedEvent struct {
+	*BaseDomainEvent
+	OperationID     string `json:"operation_id"`
+	ChainType       string `json:"chain_type"`
+	TransactionHash string `json:"transaction_hash"`
+	BlockNumber     int64  `json:"block_number"`
+	BlockHash       string `json:"block_hash"`
+}
+
+// NewTransactionReorgedEvent cria um novo evento de reorganização
+func NewTransactionReorgedEvent(
+	operationID string,
+	chainType string,
+	txHash string,
+	blockNumber int64,
+	blockHash string,
+) *TransactionReorgedEvent {
+	return &TransactionReorgedEvent{
+		BaseDomainEvent: NewBaseDomainEvent("transaction.reorged", operationID),
+		OperationID:     operationID,
+		ChainType:       chainType,
+		TransactionHash: txHash,
+		BlockNumber:     blockNumber,
+		BlockHash:       blockHash,
+	}
+}
+
+// TransactionPendingRetryEvent evento disparado quando uma falha transitória devolve a transação para PENDING
+type TransactionPendingRetryEvent struct {
+	*BaseDomainEvent
+	OperationID  string `json:"operation_id"`
+	ChainType    string `json:"chain_type"`
+	ErrorMessage string `json:"error_message"`
+	ErrorCode    string `json:"error_code,omitempty"`
+}
+
+// NewTransactionPendingRetryEvent cria um novo evento de retry pendente
+func NewTransactionPendingRetryEvent(operationID, chainType, errorMessage, errorCode string) *TransactionPendingRetryEvent {
+	return &TransactionPendingRetryEvent{
+		BaseDomainEvent: NewBaseDomainEvent("transaction.pending_retry", operationID),
+		OperationID:     operationID,
+		ChainType:       chainType,
+		ErrorMessage:    errorMessage,
+		ErrorCode:       errorCode,
+	}
+}
+
+// TransactionCancelledEvent evento disparado quando uma transação é cancelada antes de ser minerada
+type TransactionCancelledEvent struct {
+	*BaseDomainEvent
+	OperationID           string `json:"operation_id"`
+	ChainType             string `json:"chain_type"`
+	CancelTransactionHash string `json:"cancel_transaction_hash"`
+}
+
+// NewTransactionCancelledEvent cria um novo evento de cancelamento
+func NewTransactionCancelledEvent(operationID, chainType, cancelTxHash string) *TransactionCancelledEvent {
+	return &TransactionCancelledEvent{
+		BaseDomainEvent:       NewBaseDomainEvent("transaction.cancelled", operationID),
+		OperationID:           operationID,
+		ChainType:             chainType,
+		CancelTransactionHash: cancelTxHash,
+	}
+}
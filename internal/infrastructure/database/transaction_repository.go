@@ -2,7 +2,10 @@ package database
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -18,6 +21,9 @@ type TransactionRepository interface {
 	GetByOperationID(ctx context.Context, operationID string) (*entities.EVMTransaction, error)
 	GetByIdempotencyKey(ctx context.Context, idempotencyKey string) (*entities.EVMTransaction, error)
 	UpdateStatus(ctx context.Context, operationID string, status entities.TransactionStatus) error
+	// ListByStatus retorna até limit transações no status a partir do cursor (vazio = mais antigas), das mais
+	// antigas às mais recentes, e o cursor da página seguinte (vazio ao chegar ao fim)
+	ListByStatus(ctx context.Context, status entities.TransactionStatus, limit int, cursor string) ([]*entities.EVMTransaction, string, error)
 }
 
 // DynamoDBTransactionRepository implementação usando DynamoDB
@@ -42,47 +48,68 @@ func NewDynamoDBTransactionRepository(
 
 // TransactionItem estrutura para armazenar no DynamoDB
 type TransactionItem struct {
-	OperationID     string  `dynamodbav:"operation_id"`
-	IdempotencyKey  string  `dynamodbav:"idempotency_key"`
-	ChainType       string  `dynamodbav:"chain_type"`
-	OperationType   string  `dynamodbav:"operation_type"`
-	FromAddress     string  `dynamodbav:"from_address"`
-	ToAddress       string  `dynamodbav:"to_address"`
-	Status          string  `dynamodbav:"status"`
-	TransactionHash string  `dynamodbav:"transaction_hash,omitempty"`
-	BlockNumber     *int64  `dynamodbav:"block_number,omitempty"`
-	GasUsed         *int64  `dynamodbav:"gas_used,omitempty"`
-	GasPrice        *string `dynamodbav:"gas_price,omitempty"`
-	ErrorMessage    string  `dynamodbav:"error_message,omitempty"`
-	CreatedAt       string  `dynamodbav:"created_at"`
-	ExecutedAt      *string `dynamodbav:"executed_at,omitempty"`
-	TTL             int64   `dynamodbav:"ttl"`
+	OperationID       string                 `dynamodbav:"operation_id"`
+	IdempotencyKey    string                 `dynamodbav:"idempotency_key"`
+	ChainType         string                 `dynamodbav:"chain_type"`
+	OperationType     string                 `dynamodbav:"operation_type"`
+	FromAddress       string                 `dynamodbav:"from_address"`
+	ToAddress         string                 `dynamodbav:"to_address"`
+	Status            string                 `dynamodbav:"status"`
+	TransactionHash   string                 `dynamodbav:"transaction_hash,omitempty"`
+	BlockNumber       *int64                 `dynamodbav:"block_number,omitempty"`
+	BlockHash         *string                `dynamodbav:"block_hash,omitempty"`
+	GasUsed           *int64                 `dynamodbav:"gas_used,omitempty"`
+	GasPrice          *string                `dynamodbav:"gas_price,omitempty"`
+	MaxFeePerGas      *string                `dynamodbav:"max_fee_per_gas,omitempty"`
+	MaxPriorityFee    *string                `dynamodbav:"max_priority_fee_per_gas,omitempty"`
+	Nonce             *int64                 `dynamodbav:"nonce,omitempty"`
+	GasEstimate       *int64                 `dynamodbav:"gas_estimate,omitempty"`
+	QueryResult       map[string]interface{} `dynamodbav:"query_result,omitempty"`
+	ReplacementHashes []string               `dynamodbav:"replacement_hashes,omitempty"`
+	RawTransaction    *string                `dynamodbav:"raw_transaction,omitempty"`
+	LastBroadcastAt   *string                `dynamodbav:"last_broadcast_at,omitempty"`
+	ErrorMessage      string                 `dynamodbav:"error_message,omitempty"`
+	CreatedAt         string                 `dynamodbav:"created_at"`
+	ExecutedAt        *string                `dynamodbav:"executed_at,omitempty"`
+	TTL               int64                  `dynamodbav:"ttl"`
 }
 
 // Save persiste uma transação
 func (r *DynamoDBTransactionRepository) Save(ctx context.Context, tx *entities.EVMTransaction) error {
 	item := TransactionItem{
-		OperationID:     tx.OperationID().String(),
-		IdempotencyKey:  tx.IdempotencyKey(),
-		ChainType:       tx.ChainType().String(),
-		OperationType:   tx.OperationType().String(),
-		FromAddress:     tx.FromAddress().String(),
-		ToAddress:       tx.ToAddress().String(),
-		Status:          string(tx.Status()),
-		TransactionHash: tx.TxHash().String(),
-		BlockNumber:     tx.BlockNumber(),
-		GasUsed:         tx.GasUsed(),
-		GasPrice:        tx.GasPrice(),
-		ErrorMessage:    tx.ErrorMessage(),
-		CreatedAt:       tx.CreatedAt().Format("2006-01-02T15:04:05Z"),
-		ExecutedAt:      nil,
-		TTL:             7776000, // 90 dias em segundos
+		OperationID:       tx.OperationID().String(),
+		IdempotencyKey:    tx.IdempotencyKey(),
+		ChainType:         tx.ChainType().String(),
+		OperationType:     tx.OperationType().String(),
+		FromAddress:       tx.FromAddress().String(),
+		ToAddress:         tx.ToAddress().String(),
+		Status:            string(tx.Status()),
+		TransactionHash:   tx.TxHash().String(),
+		BlockNumber:       tx.BlockNumber(),
+		BlockHash:         tx.BlockHash(),
+		GasUsed:           tx.GasUsed(),
+		GasPrice:          tx.GasPrice(),
+		MaxFeePerGas:      tx.MaxFeePerGas(),
+		MaxPriorityFee:    tx.MaxPriorityFeePerGas(),
+		Nonce:             tx.Nonce(),
+		GasEstimate:       tx.GasEstimate(),
+		QueryResult:       tx.QueryResult(),
+		ReplacementHashes: tx.ReplacementHashes(),
+		RawTransaction:    tx.RawTransaction(),
+		ErrorMessage:      tx.ErrorMessage(),
+		CreatedAt:         tx.CreatedAt().Format("2006-01-02T15:04:05Z"),
+		ExecutedAt:        nil,
+		TTL:               7776000, // 90 dias em segundos
 	}
 
 	if tx.ExecutedAt() != nil {
 		executedAt := tx.ExecutedAt().Format("2006-01-02T15:04:05Z")
 		item.ExecutedAt = &executedAt
 	}
+	if tx.LastBroadcastAt() != nil {
+		lastBroadcastAt := tx.LastBroadcastAt().UTC().Format(time.RFC3339Nano)
+		item.LastBroadcastAt = &lastBroadcastAt
+	}
 
 	av, err := attributevalue.MarshalMap(item)
 	if err != nil {
@@ -137,7 +164,9 @@ func (r *DynamoDBTransactionRepository) GetByOperationID(ctx context.Context, op
 	}
 
 	return unmarshalTransactionItem(item, r.logger)
-} // GetByIdempotencyKey recupera uma transação pelo idempotency key
+}
+
+// GetByIdempotencyKey recupera uma transação pelo idempotency key
 func (r *DynamoDBTransactionRepository) GetByIdempotencyKey(ctx context.Context, idempotencyKey string) (*entities.EVMTransaction, error) {
 	input := &dynamodb.QueryInput{
 		TableName:              &r.tableName,
@@ -200,10 +229,128 @@ func (r *DynamoDBTransactionRepository) UpdateStatus(ctx context.Context, operat
 	return nil
 }
 
+// ListByStatus consulta o índice status-created_at-index (KEYS_ONLY) e carrega cada transação pela chave,
+// seguindo as páginas do índice até juntar limit transações ou chegar ao fim
+func (r *DynamoDBTransactionRepository) ListByStatus(ctx context.Context, status entities.TransactionStatus, limit int, cursor string) ([]*entities.EVMTransaction, string, error) {
+	startKey, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	transactions := make([]*entities.EVMTransaction, 0)
+	for {
+		input := &dynamodb.QueryInput{
+			TableName:              &r.tableName,
+			IndexName:              stringPtr("status-created_at-index"),
+			KeyConditionExpression: stringPtr("#status = :status"),
+			ExpressionAttributeNames: map[string]string{
+				"#status": "status",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":status": &types.AttributeValueMemberS{Value: string(status)},
+			},
+			ScanIndexForward:  boolPtr(true),
+			ExclusiveStartKey: startKey,
+		}
+		// A página termina no último item lido, para que o cursor retome exatamente dali
+		if limit > 0 {
+			input.Limit = int32Ptr(int32(limit - len(transactions)))
+		}
+
+		result, err := r.dynamoDBClient.Query(ctx, input)
+		if err != nil {
+			r.logger.Error("failed to query transactions by status",
+				zap.String("status", string(status)),
+				zap.Error(err))
+			return nil, "", fmt.Errorf("failed to query transactions: %w", err)
+		}
+
+		for _, key := range result.Items {
+			operationID, ok := key["operation_id"].(*types.AttributeValueMemberS)
+			if !ok {
+				continue
+			}
+
+			tx, err := r.GetByOperationID(ctx, operationID.Value)
+			if err != nil {
+				return nil, "", err
+			}
+			// O índice é eventualmente consistente: ignora itens que já mudaram de status
+			if tx.Status() != status {
+				continue
+			}
+			transactions = append(transactions, tx)
+		}
+
+		startKey = result.LastEvaluatedKey
+		if len(startKey) == 0 || (limit > 0 && len(transactions) >= limit) {
+			break
+		}
+	}
+
+	next, err := encodeCursor(startKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return transactions, next, nil
+}
+
+// encodeCursor serializa a LastEvaluatedKey do índice (atributos string) como cursor opaco
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	values := make(map[string]string, len(key))
+	for name, value := range key {
+		member, ok := value.(*types.AttributeValueMemberS)
+		if !ok {
+			return "", fmt.Errorf("unsupported cursor attribute %q", name)
+		}
+		values[name] = member.Value
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor reconstrói a ExclusiveStartKey a partir do cursor (nil se vazio)
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	key := make(map[string]types.AttributeValue, len(values))
+	for name, value := range values {
+		key[name] = &types.AttributeValueMemberS{Value: value}
+	}
+	return key, nil
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 // unmarshalTransactionItem converte um TransactionItem em EVMTransaction
 func unmarshalTransactionItem(item TransactionItem, logger *zap.Logger) (*entities.EVMTransaction, error) {
 	operationID, err := valueobjects.NewOperationID(item.OperationID)
@@ -246,31 +393,100 @@ func unmarshalTransactionItem(item TransactionItem, logger *zap.Logger) (*entiti
 		item.IdempotencyKey,
 	)
 
-	// Restaurar estado
+	// Restaurar taxas e nonce
+	if item.Nonce != nil {
+		switch {
+		case item.MaxFeePerGas != nil && item.MaxPriorityFee != nil:
+			tx.SetDynamicFeeMetadata(*item.MaxFeePerGas, *item.MaxPriorityFee, *item.Nonce)
+		case item.GasPrice != nil:
+			tx.SetTxMetadata(*item.GasPrice, *item.Nonce)
+		}
+	}
+
+	if item.GasEstimate != nil {
+		tx.SetGasEstimate(*item.GasEstimate)
+	}
+	if item.QueryResult != nil {
+		tx.SetQueryResult(item.QueryResult)
+	}
+	for _, replacementHash := range item.ReplacementHashes {
+		tx.AddReplacementHash(replacementHash)
+	}
+	if item.RawTransaction != nil && item.LastBroadcastAt != nil {
+		lastBroadcastAt, err := time.Parse(time.RFC3339Nano, *item.LastBroadcastAt)
+		if err != nil {
+			logger.Error("failed to parse last broadcast time", zap.Error(err))
+		} else {
+			tx.SetBroadcast(*item.RawTransaction, lastBroadcastAt)
+		}
+	}
+
+	// Restaurar estado; o hash é restaurado em qualquer status que o tenha
+	hasHash := item.TransactionHash != ""
 	status := entities.TransactionStatus(item.Status)
 	switch status {
 	case entities.TransactionStatusPending:
-		// Estado inicial
+		// Estado inicial; com hash, a operação voltou para PENDING após um envio
+		if hasHash {
+			restoreSubmission(tx, item)
+			tx.MarkAsPendingRetry(item.ErrorMessage)
+		}
 	case entities.TransactionStatusProcessing:
+		if hasHash {
+			restoreSubmission(tx, item)
+		}
 		tx.MarkAsProcessing()
+	case entities.TransactionStatusSubmitted:
+		restoreSubmission(tx, item)
 	case entities.TransactionStatusSuccess:
-		if item.TransactionHash != "" {
-			txHash, _ := valueobjects.NewTransactionHash(item.TransactionHash)
-			blockNum := int64(0)
-			if item.BlockNumber != nil {
-				blockNum = *item.BlockNumber
-			}
-			gasUsedVal := int64(0)
-			if item.GasUsed != nil {
-				gasUsedVal = *item.GasUsed
-			}
-			tx.MarkAsSuccess(txHash, blockNum, gasUsedVal)
+		if hasHash {
+			restoreExecution(tx, item)
 		}
 	case entities.TransactionStatusConfirmed:
+		if hasHash {
+			restoreExecution(tx, item)
+		}
 		tx.MarkAsConfirmed()
+	case entities.TransactionStatusCancelled:
+		if hasHash {
+			restoreSubmission(tx, item)
+		}
+		tx.MarkAsCancelled()
 	case entities.TransactionStatusFailed:
+		// Revertida on-chain: mantém hash, bloco e gas da versão minerada
+		if hasHash && item.GasUsed != nil {
+			restoreExecution(tx, item)
+		} else if hasHash {
+			restoreSubmission(tx, item)
+		}
 		tx.MarkAsFailed(item.ErrorMessage)
 	}
 
 	return tx, nil
 }
+
+// restoreSubmission restaura o hash e, se já minerada, o bloco de uma transação enviada
+func restoreSubmission(tx *entities.EVMTransaction, item TransactionItem) {
+	txHash, _ := valueobjects.NewTransactionHash(item.TransactionHash)
+	tx.MarkAsSubmitted(txHash)
+	if item.BlockNumber != nil && item.BlockHash != nil {
+		tx.SetInclusion(*item.BlockNumber, *item.BlockHash)
+	}
+}
+
+// restoreExecution restaura hash, bloco e gas de uma transação executada
+func restoreExecution(tx *entities.EVMTransaction, item TransactionItem) {
+	txHash, _ := valueobjects.NewTransactionHash(item.TransactionHash)
+	blockNum := int64(0)
+	if item.BlockNumber != nil {
+		blockNum = *item.BlockNumber
+	}
+	gasUsedVal := int64(0)
+	if item.GasUsed != nil {
+		gasUsedVal = *item.GasUsed
+	}
+	tx.MarkAsSuccess(txHash, blockNum, gasUsedVal)
+	if item.BlockHash != nil {
+		tx.SetInclusion(blockNum, *item.BlockHash)
+	}
+}
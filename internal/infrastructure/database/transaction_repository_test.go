@@ -12,6 +12,7 @@ import (
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/valueobjects"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -256,6 +257,185 @@ func TestDynamoDBTransactionRepository_GetByIdempotencyKey_Error(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestDynamoDBTransactionRepository_ListByStatus(t *testing.T) {
+	t.Parallel()
+
+	storedItem := func(operationID string, status string) map[string]types.AttributeValue {
+		return map[string]types.AttributeValue{
+			"operation_id":     &types.AttributeValueMemberS{Value: operationID},
+			"chain_type":       &types.AttributeValueMemberS{Value: "ETHEREUM"},
+			"operation_type":   &types.AttributeValueMemberS{Value: "TRANSFER"},
+			"from_address":     &types.AttributeValueMemberS{Value: "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"},
+			"to_address":       &types.AttributeValueMemberS{Value: "0x8ba1f109551bD432803012645Ac136ddd64DBA72"},
+			"status":           &types.AttributeValueMemberS{Value: status},
+			"transaction_hash": &types.AttributeValueMemberS{Value: "0x00000000000000000000000000000000000000000000000000000000000000aa"},
+			"created_at":       &types.AttributeValueMemberS{Value: time.Now().Format(time.RFC3339)},
+			"idempotency_key":  &types.AttributeValueMemberS{Value: "idem-" + operationID},
+		}
+	}
+	getItemFor := func(operationID string) interface{} {
+		return mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+			return input.Key["operation_id"].(*types.AttributeValueMemberS).Value == operationID
+		})
+	}
+
+	t.Run("query status index and load each transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockDynamoDBClient)
+		repo := NewDynamoDBTransactionRepository(mockClient, "test-table", zap.NewNop())
+
+		mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			status := input.ExpressionAttributeValues[":status"].(*types.AttributeValueMemberS)
+			return *input.IndexName == "status-created_at-index" &&
+				input.ExpressionAttributeNames["#status"] == "status" &&
+				status.Value == "SUBMITTED" &&
+				*input.Limit == 10 &&
+				*input.ScanIndexForward
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{"operation_id": &types.AttributeValueMemberS{Value: "550e8400-e29b-41d4-a716-446655440001"}},
+			{"operation_id": &types.AttributeValueMemberS{Value: "550e8400-e29b-41d4-a716-446655440002"}},
+		}}, nil)
+		mockClient.On("GetItem", mock.Anything, getItemFor("550e8400-e29b-41d4-a716-446655440001")).
+			Return(&dynamodb.GetItemOutput{Item: storedItem("550e8400-e29b-41d4-a716-446655440001", "SUBMITTED")}, nil)
+		mockClient.On("GetItem", mock.Anything, getItemFor("550e8400-e29b-41d4-a716-446655440002")).
+			Return(&dynamodb.GetItemOutput{Item: storedItem("550e8400-e29b-41d4-a716-446655440002", "SUBMITTED")}, nil)
+
+		transactions, next, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 10, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, next)
+		assert.Len(t, transactions, 2)
+		assert.Equal(t, entities.TransactionStatusSubmitted, transactions[0].Status())
+		assert.Equal(t, "0x00000000000000000000000000000000000000000000000000000000000000aa", transactions[0].TxHash().String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("skip items whose status changed after indexing", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockDynamoDBClient)
+		repo := NewDynamoDBTransactionRepository(mockClient, "test-table", zap.NewNop())
+
+		mockClient.On("Query", mock.Anything, mock.Anything).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{"operation_id": &types.AttributeValueMemberS{Value: "550e8400-e29b-41d4-a716-446655440001"}},
+		}}, nil)
+		mockClient.On("GetItem", mock.Anything, mock.Anything).
+			Return(&dynamodb.GetItemOutput{Item: storedItem("550e8400-e29b-41d4-a716-446655440001", "CONFIRMED")}, nil)
+
+		transactions, _, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 10, "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, transactions)
+	})
+
+	t.Run("propagate query error", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockDynamoDBClient)
+		repo := NewDynamoDBTransactionRepository(mockClient, "test-table", zap.NewNop())
+		mockClient.On("Query", mock.Anything, mock.Anything).Return(nil, errors.New("dynamodb error"))
+
+		transactions, _, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 10, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, transactions)
+	})
+
+	t.Run("page through more items than limit with a cursor", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockDynamoDBClient)
+		repo := NewDynamoDBTransactionRepository(mockClient, "test-table", zap.NewNop())
+
+		ids := []string{
+			"550e8400-e29b-41d4-a716-446655440001",
+			"550e8400-e29b-41d4-a716-446655440002",
+			"550e8400-e29b-41d4-a716-446655440003",
+		}
+		indexKey := func(operationID string, createdAt string) map[string]types.AttributeValue {
+			return map[string]types.AttributeValue{
+				"operation_id": &types.AttributeValueMemberS{Value: operationID},
+				"status":       &types.AttributeValueMemberS{Value: "SUBMITTED"},
+				"created_at":   &types.AttributeValueMemberS{Value: createdAt},
+			}
+		}
+		lastKey := indexKey(ids[1], "2025-01-01T00:00:02Z")
+
+		mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return input.ExclusiveStartKey == nil && *input.Limit == 2
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]types.AttributeValue{indexKey(ids[0], "2025-01-01T00:00:01Z"), lastKey},
+			LastEvaluatedKey: lastKey,
+		}, nil).Once()
+		mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return assert.ObjectsAreEqual(lastKey, input.ExclusiveStartKey) && *input.Limit == 2
+		})).Return(&dynamodb.QueryOutput{
+			Items: []map[string]types.AttributeValue{indexKey(ids[2], "2025-01-01T00:00:03Z")},
+		}, nil).Once()
+		for _, id := range ids {
+			mockClient.On("GetItem", mock.Anything, getItemFor(id)).
+				Return(&dynamodb.GetItemOutput{Item: storedItem(id, "SUBMITTED")}, nil)
+		}
+
+		first, cursor, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 2, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, cursor)
+		second, next, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 2, cursor)
+		require.NoError(t, err)
+
+		require.Len(t, first, 2)
+		require.Len(t, second, 1)
+		assert.Equal(t, ids[2], second[0].OperationID().String())
+		assert.Empty(t, next)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("follow next page when indexed items changed status", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockDynamoDBClient)
+		repo := NewDynamoDBTransactionRepository(mockClient, "test-table", zap.NewNop())
+
+		stale := "550e8400-e29b-41d4-a716-446655440001"
+		submitted := "550e8400-e29b-41d4-a716-446655440002"
+		staleKey := map[string]types.AttributeValue{"operation_id": &types.AttributeValueMemberS{Value: stale}}
+		mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return input.ExclusiveStartKey == nil
+		})).Return(&dynamodb.QueryOutput{
+			Items:            []map[string]types.AttributeValue{staleKey},
+			LastEvaluatedKey: staleKey,
+		}, nil).Once()
+		mockClient.On("Query", mock.Anything, mock.MatchedBy(func(input *dynamodb.QueryInput) bool {
+			return input.ExclusiveStartKey != nil
+		})).Return(&dynamodb.QueryOutput{Items: []map[string]types.AttributeValue{
+			{"operation_id": &types.AttributeValueMemberS{Value: submitted}},
+		}}, nil).Once()
+		mockClient.On("GetItem", mock.Anything, getItemFor(stale)).
+			Return(&dynamodb.GetItemOutput{Item: storedItem(stale, "CONFIRMED")}, nil)
+		mockClient.On("GetItem", mock.Anything, getItemFor(submitted)).
+			Return(&dynamodb.GetItemOutput{Item: storedItem(submitted, "SUBMITTED")}, nil)
+
+		transactions, next, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 1, "")
+
+		require.NoError(t, err)
+		require.Len(t, transactions, 1)
+		assert.Equal(t, submitted, transactions[0].OperationID().String())
+		assert.Empty(t, next)
+	})
+
+	t.Run("reject invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewDynamoDBTransactionRepository(new(MockDynamoDBClient), "test-table", zap.NewNop())
+
+		transactions, _, err := repo.ListByStatus(context.Background(), entities.TransactionStatusSubmitted, 10, "%%%")
+
+		assert.Error(t, err)
+		assert.Nil(t, transactions)
+	})
+}
+
 func TestDynamoDBTransactionRepository_UpdateStatus_Success(t *testing.T) {
 	t.Parallel()
 
@@ -362,3 +542,31 @@ func TestDynamoDBTransactionRepository_GetByIdempotencyKey_UnmarshalError(t *tes
 	assert.Nil(t, tx)
 	mockClient.AssertExpectations(t)
 }
+
+func TestDynamoDBTransactionRepository_Save_WithBroadcast(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockDynamoDBClient)
+	logger, _ := zap.NewDevelopment()
+	repo := NewDynamoDBTransactionRepository(mockClient, "test-table", logger)
+
+	opID, _ := valueobjects.NewOperationID("550e8400-e29b-41d4-a716-446655440000")
+	chainType, _ := valueobjects.NewChainType("ETHEREUM")
+	opType, _ := valueobjects.NewOperationType("TRANSFER")
+	fromAddr, _ := valueobjects.NewEVMAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0")
+	toAddr, _ := valueobjects.NewEVMAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+
+	tx := entities.NewEVMTransaction(opID, chainType, opType, fromAddr, toAddr, nil, "idem123")
+	tx.SetBroadcast("0xe504", time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC))
+
+	mockClient.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+		raw, ok := input.Item["raw_transaction"].(*types.AttributeValueMemberS)
+		broadcastAt, ok2 := input.Item["last_broadcast_at"].(*types.AttributeValueMemberS)
+		return ok && ok2 && raw.Value == "0xe504" && broadcastAt.Value == "2026-01-02T15:04:05Z"
+	})).Return(&dynamodb.PutItemOutput{}, nil)
+
+	err := repo.Save(context.Background(), tx)
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gabrielksneiva/ChainEVM/internal/domain/entities"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -53,6 +54,84 @@ func TestUnmarshalTransactionItem_StatusConfirmed(t *testing.T) {
 	assert.Equal(t, entities.TransactionStatusConfirmed, tx.Status())
 }
 
+func TestUnmarshalTransactionItem_StatusConfirmedWithHash(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(100)
+	gasUsed := int64(21000)
+	item := TransactionItem{
+		OperationID:     "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:       "ETHEREUM",
+		OperationType:   "TRANSFER",
+		FromAddress:     "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:       "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:          string(entities.TransactionStatusConfirmed),
+		TransactionHash: "0x1234567890123456789012345678901234567890123456789012345678901234",
+		BlockNumber:     &blockNumber,
+		GasUsed:         &gasUsed,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		IdempotencyKey:  "idem123",
+	}
+
+	tx, err := unmarshalTransactionItem(item, zap.NewNop())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TransactionStatusConfirmed, tx.Status())
+	assert.Equal(t, item.TransactionHash, tx.TxHash().String())
+	assert.Equal(t, blockNumber, *tx.BlockNumber())
+	assert.Equal(t, gasUsed, *tx.GasUsed())
+}
+
+func TestUnmarshalTransactionItem_StatusSubmitted(t *testing.T) {
+	t.Parallel()
+
+	item := TransactionItem{
+		OperationID:     "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:       "ETHEREUM",
+		OperationType:   "TRANSFER",
+		FromAddress:     "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:       "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:          string(entities.TransactionStatusSubmitted),
+		TransactionHash: "0x1234567890123456789012345678901234567890123456789012345678901234",
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		IdempotencyKey:  "idem123",
+	}
+
+	tx, err := unmarshalTransactionItem(item, zap.NewNop())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TransactionStatusSubmitted, tx.Status())
+	assert.Equal(t, item.TransactionHash, tx.TxHash().String())
+	assert.Nil(t, tx.BlockNumber())
+}
+
+func TestUnmarshalTransactionItem_StatusSubmittedWithInclusion(t *testing.T) {
+	t.Parallel()
+
+	blockNumber := int64(12345)
+	blockHash := "0xabcdef0000000000000000000000000000000000000000000000000000000000"
+	item := TransactionItem{
+		OperationID:     "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:       "ETHEREUM",
+		OperationType:   "TRANSFER",
+		FromAddress:     "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:       "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:          string(entities.TransactionStatusSubmitted),
+		TransactionHash: "0x1234567890123456789012345678901234567890123456789012345678901234",
+		BlockNumber:     &blockNumber,
+		BlockHash:       &blockHash,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		IdempotencyKey:  "idem123",
+	}
+
+	tx, err := unmarshalTransactionItem(item, zap.NewNop())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TransactionStatusSubmitted, tx.Status())
+	assert.Equal(t, blockNumber, *tx.BlockNumber())
+	assert.Equal(t, blockHash, *tx.BlockHash())
+}
+
 func TestUnmarshalTransactionItem_StatusFailed(t *testing.T) {
 	t.Parallel()
 
@@ -135,3 +214,172 @@ func TestUnmarshalTransactionItem_StatusSuccessWithoutHash(t *testing.T) {
 	// Quando não há TransactionHash, o MarkAsSuccess não é chamado, então permanece PENDING
 	assert.Equal(t, entities.TransactionStatusPending, tx.Status())
 }
+
+func TestUnmarshalTransactionItem_DynamicFeeMetadata(t *testing.T) {
+	t.Parallel()
+
+	maxFee := "42000000000"
+	maxPriority := "2000000000"
+	nonce := int64(7)
+	item := TransactionItem{
+		OperationID:    "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:      "ETHEREUM",
+		OperationType:  "TRANSFER",
+		FromAddress:    "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:      "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:         string(entities.TransactionStatusProcessing),
+		MaxFeePerGas:   &maxFee,
+		MaxPriorityFee: &maxPriority,
+		Nonce:          &nonce,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+		IdempotencyKey: "idem123",
+	}
+
+	logger, _ := zap.NewDevelopment()
+	tx, err := unmarshalTransactionItem(item, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, maxFee, *tx.MaxFeePerGas())
+	assert.Equal(t, maxPriority, *tx.MaxPriorityFeePerGas())
+	assert.Equal(t, nonce, *tx.Nonce())
+	assert.Nil(t, tx.GasPrice())
+}
+
+func TestUnmarshalTransactionItem_QueryResult(t *testing.T) {
+	t.Parallel()
+
+	gasEstimate := int64(60000)
+	item := TransactionItem{
+		OperationID:    "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:      "ETHEREUM",
+		OperationType:  "ESTIMATE_GAS",
+		FromAddress:    "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:      "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:         string(entities.TransactionStatusSuccess),
+		GasEstimate:    &gasEstimate,
+		QueryResult:    map[string]interface{}{"gas_estimate": "60000"},
+		CreatedAt:      time.Now().Format(time.RFC3339),
+		IdempotencyKey: "idem123",
+	}
+
+	logger, _ := zap.NewDevelopment()
+	tx, err := unmarshalTransactionItem(item, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gasEstimate, *tx.GasEstimate())
+	assert.Equal(t, "60000", tx.QueryResult()["gas_estimate"])
+}
+
+func TestUnmarshalTransactionItem_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	nonce := int64(4)
+	gasPrice := "11000000000"
+	item := TransactionItem{
+		OperationID:       "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:         "ETHEREUM",
+		OperationType:     "TRANSFER",
+		FromAddress:       "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:         "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:            string(entities.TransactionStatusCancelled),
+		TransactionHash:   "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		GasPrice:          &gasPrice,
+		Nonce:             &nonce,
+		ReplacementHashes: []string{"0xaaa", "0xbbb"},
+		CreatedAt:         time.Now().Format(time.RFC3339),
+		IdempotencyKey:    "idem123",
+	}
+
+	logger, _ := zap.NewDevelopment()
+	tx, err := unmarshalTransactionItem(item, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.TransactionStatusCancelled, tx.Status())
+	assert.Equal(t, "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef", tx.TxHash().String())
+	assert.Equal(t, []string{"0xaaa", "0xbbb"}, tx.ReplacementHashes())
+}
+
+func TestUnmarshalTransactionItem_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	nonce := int64(4)
+	gasPrice := "11000000000"
+	rawTransaction := "0xe50485028fa6ae00825208940987654321098765432109876543210987654321018080808080"
+	lastBroadcastAt := "2026-01-02T15:04:05.123Z"
+	item := TransactionItem{
+		OperationID:     "550e8400-e29b-41d4-a716-446655440000",
+		ChainType:       "ETHEREUM",
+		OperationType:   "TRANSFER",
+		FromAddress:     "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+		ToAddress:       "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+		Status:          string(entities.TransactionStatusSubmitted),
+		TransactionHash: "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+		GasPrice:        &gasPrice,
+		Nonce:           &nonce,
+		RawTransaction:  &rawTransaction,
+		LastBroadcastAt: &lastBroadcastAt,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+		IdempotencyKey:  "idem123",
+	}
+
+	logger, _ := zap.NewDevelopment()
+	tx, err := unmarshalTransactionItem(item, logger)
+
+	require.NoError(t, err)
+	require.NotNil(t, tx.RawTransaction())
+	assert.Equal(t, rawTransaction, *tx.RawTransaction())
+	require.NotNil(t, tx.LastBroadcastAt())
+	assert.Equal(t, time.Date(2026, 1, 2, 15, 4, 5, 123000000, time.UTC), tx.LastBroadcastAt().UTC())
+}
+
+func TestUnmarshalTransactionItem_RestoreHashForEveryStatus(t *testing.T) {
+	t.Parallel()
+
+	const hash = "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+	blockNum := int64(12345)
+	blockHash := "0x0000000000000000000000000000000000000000000000000000000000000b01"
+	gasUsed := int64(21000)
+
+	tests := []struct {
+		name        string
+		status      entities.TransactionStatus
+		blockNumber *int64
+		gasUsed     *int64
+	}{
+		{name: "pending retry", status: entities.TransactionStatusPending},
+		{name: "processing", status: entities.TransactionStatusProcessing},
+		{name: "failed before mining", status: entities.TransactionStatusFailed},
+		{name: "reverted", status: entities.TransactionStatusFailed, blockNumber: &blockNum, gasUsed: &gasUsed},
+		{name: "cancelled", status: entities.TransactionStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := TransactionItem{
+				OperationID:     "550e8400-e29b-41d4-a716-446655440000",
+				ChainType:       "ETHEREUM",
+				OperationType:   "TRANSFER",
+				FromAddress:     "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0",
+				ToAddress:       "0x8ba1f109551bD432803012645Ac136ddd64DBA72",
+				Status:          string(tt.status),
+				TransactionHash: hash,
+				BlockNumber:     tt.blockNumber,
+				GasUsed:         tt.gasUsed,
+				ErrorMessage:    "boom",
+				CreatedAt:       time.Now().Format(time.RFC3339),
+				IdempotencyKey:  "idem123",
+			}
+			if tt.blockNumber != nil {
+				item.BlockHash = &blockHash
+			}
+
+			tx, err := unmarshalTransactionItem(item, zap.NewNop())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, tx.Status())
+			assert.Equal(t, hash, tx.TxHash().String())
+			assert.Equal(t, tt.blockNumber, tx.BlockNumber())
+			assert.Equal(t, tt.gasUsed, tx.GasUsed())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrBatchDeadline o registro não foi iniciado porque o deadline da invocação estava próximo
+	ErrBatchDeadline = errors.New("batch deadline reached before record was processed")
+	// ErrGroupBlocked o registro não foi processado porque um anterior do mesmo grupo FIFO falhou
+	ErrGroupBlocked = errors.New("previous record of the message group failed")
+)
+
+// BatchConfig configuração do processamento concorrente de um lote
+type BatchConfig struct {
+	// Concurrency registros processados em paralelo (<= 0 = 1)
+	Concurrency int
+	// DeadlineMargin antecedência em relação ao deadline do contexto a partir da qual novos registros
+	// não são iniciados (ficam para a próxima entrega)
+	DeadlineMargin time.Duration
+}
+
+// BatchItem registro do lote; itens com a mesma OrderKey são processados em sequência, na ordem do lote
+type BatchItem struct {
+	ID       string
+	OrderKey string
+	// FIFO após uma falha os itens seguintes da mesma OrderKey não são processados (semântica do MessageGroupId)
+	FIFO bool
+}
+
+// BatchProcessor processa os registros de um lote SQS em um pool limitado de workers
+type BatchProcessor struct {
+	config BatchConfig
+	logger *zap.Logger
+}
+
+// NewBatchProcessor cria o processador de lotes
+func NewBatchProcessor(config BatchConfig, logger *zap.Logger) *BatchProcessor {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	return &BatchProcessor{config: config, logger: logger}
+}
+
+// Process executa process para cada item e retorna o erro de cada um, na ordem de items (nil = sucesso).
+// Grupos de OrderKey diferentes rodam em paralelo; com o deadline próximo os itens ainda não iniciados
+// retornam ErrBatchDeadline, enquanto os em andamento terminam normalmente
+func (p *BatchProcessor) Process(ctx context.Context, items []BatchItem, process func(ctx context.Context, index int) error) []error {
+	results := make([]error, len(items))
+
+	groups := make(chan []int, len(items))
+	for _, group := range groupByOrderKey(items) {
+		groups <- group
+	}
+	close(groups)
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.config.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range groups {
+				p.processGroup(ctx, items, group, process, results)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// processGroup processa os itens de um grupo em ordem (cada índice é escrito por um único worker)
+func (p *BatchProcessor) processGroup(
+	ctx context.Context,
+	items []BatchItem,
+	group []int,
+	process func(ctx context.Context, index int) error,
+	results []error,
+) {
+	for position, index := range group {
+		if p.deadlineReached(ctx) {
+			for _, skipped := range group[position:] {
+				results[skipped] = ErrBatchDeadline
+			}
+			p.logger.Warn("batch deadline reached, leaving records for redelivery",
+				zap.String("order_key", items[index].OrderKey),
+				zap.Int("skipped", len(group)-position))
+			return
+		}
+
+		results[index] = process(ctx, index)
+		if results[index] != nil && items[index].FIFO {
+			for _, blocked := range group[position+1:] {
+				results[blocked] = ErrGroupBlocked
+			}
+			return
+		}
+	}
+}
+
+// deadlineReached indica que o contexto terminou ou que o deadline está dentro da margem
+func (p *BatchProcessor) deadlineReached(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) <= p.config.DeadlineMargin
+}
+
+// groupByOrderKey índices dos itens agrupados por OrderKey, na ordem da primeira ocorrência;
+// itens sem OrderKey formam grupos próprios
+func groupByOrderKey(items []BatchItem) [][]int {
+	var groups [][]int
+	positions := make(map[string]int)
+	for index, item := range items {
+		if item.OrderKey == "" {
+			groups = append(groups, []int{index})
+			continue
+		}
+		if position, ok := positions[item.OrderKey]; ok {
+			groups[position] = append(groups[position], index)
+			continue
+		}
+		positions[item.OrderKey] = len(groups)
+		groups = append(groups, []int{index})
+	}
+	return groups
+}
+
+// SenderOrderKey chave de ordenação de mensagens de um remetente em uma chain (nonces em sequência)
+func SenderOrderKey(chainType, fromAddress string) string {
+	if chainType == "" || fromAddress == "" {
+		return ""
+	}
+	return strings.ToUpper(chainType) + ":" + strings.ToLower(fromAddress)
+}
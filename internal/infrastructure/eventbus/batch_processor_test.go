@@ -0,0 +1,140 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestBatchProcessor_Process(t *testing.T) {
+	t.Run("process independent records in parallel", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 3}, zap.NewNop())
+		items := []BatchItem{{ID: "1", OrderKey: "a"}, {ID: "2", OrderKey: "b"}, {ID: "3", OrderKey: "c"}}
+
+		var running, maxRunning atomic.Int32
+		results := processor.Process(context.Background(), items, func(ctx context.Context, index int) error {
+			current := running.Add(1)
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+
+		assert.Equal(t, []error{nil, nil, nil}, results)
+		assert.Equal(t, int32(3), maxRunning.Load())
+	})
+
+	t.Run("keep order of records with the same key", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 4}, zap.NewNop())
+		key := SenderOrderKey("ETHEREUM", "0xAbC")
+		items := []BatchItem{{ID: "1", OrderKey: key}, {ID: "2", OrderKey: "other"}, {ID: "3", OrderKey: key}, {ID: "4", OrderKey: key}}
+
+		var mu sync.Mutex
+		var order []string
+		processor.Process(context.Background(), items, func(ctx context.Context, index int) error {
+			if items[index].OrderKey == key {
+				// O primeiro item demora: os seguintes da mesma chave não podem passar à frente
+				if index == 0 {
+					time.Sleep(20 * time.Millisecond)
+				}
+				mu.Lock()
+				order = append(order, items[index].ID)
+				mu.Unlock()
+			}
+			return nil
+		})
+
+		assert.Equal(t, []string{"1", "3", "4"}, order)
+	})
+
+	t.Run("bound concurrency", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 2}, zap.NewNop())
+		items := make([]BatchItem, 6)
+		for i := range items {
+			items[i] = BatchItem{ID: string(rune('a' + i))}
+		}
+
+		var running, maxRunning atomic.Int32
+		processor.Process(context.Background(), items, func(ctx context.Context, index int) error {
+			current := running.Add(1)
+			if current > maxRunning.Load() {
+				maxRunning.Store(current)
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+
+		assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+	})
+
+	t.Run("fifo group stops after a failure", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 2}, zap.NewNop())
+		items := []BatchItem{
+			{ID: "1", OrderKey: "group-1", FIFO: true},
+			{ID: "2", OrderKey: "group-1", FIFO: true},
+			{ID: "3", OrderKey: "group-2", FIFO: true},
+		}
+		failure := errors.New("rpc timeout")
+
+		var processed atomic.Int32
+		results := processor.Process(context.Background(), items, func(ctx context.Context, index int) error {
+			processed.Add(1)
+			if index == 0 {
+				return failure
+			}
+			return nil
+		})
+
+		assert.Equal(t, []error{failure, ErrGroupBlocked, nil}, results)
+		assert.Equal(t, int32(2), processed.Load())
+	})
+
+	t.Run("standard queue continues after a failure", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 1}, zap.NewNop())
+		items := []BatchItem{{ID: "1", OrderKey: "key"}, {ID: "2", OrderKey: "key"}}
+		failure := errors.New("rpc timeout")
+
+		results := processor.Process(context.Background(), items, func(ctx context.Context, index int) error {
+			if index == 0 {
+				return failure
+			}
+			return nil
+		})
+
+		assert.Equal(t, []error{failure, nil}, results)
+	})
+
+	t.Run("leave records for redelivery when deadline approaches", func(t *testing.T) {
+		processor := NewBatchProcessor(BatchConfig{Concurrency: 1, DeadlineMargin: 50 * time.Millisecond}, zap.NewNop())
+		items := []BatchItem{{ID: "1", OrderKey: "key"}, {ID: "2", OrderKey: "key"}, {ID: "3"}}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 80*time.Millisecond)
+		defer cancel()
+
+		results := processor.Process(ctx, items, func(ctx context.Context, index int) error {
+			time.Sleep(40 * time.Millisecond)
+			return nil
+		})
+
+		assert.NoError(t, results[0])
+		assert.ErrorIs(t, results[1], ErrBatchDeadline)
+		assert.ErrorIs(t, results[2], ErrBatchDeadline)
+	})
+}
+
+func TestSenderOrderKey(t *testing.T) {
+	assert.Equal(t, "ETHEREUM:0xabc", SenderOrderKey("ethereum", "0xABC"))
+	assert.Equal(t, "", SenderOrderKey("ETHEREUM", ""))
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"go.uber.org/zap"
@@ -13,7 +15,7 @@ import (
 
 // DLQSender interface para enviar mensagens para Dead Letter Queue
 type DLQSender interface {
-	SendMessage(ctx context.Context, message *Message, reason string) error
+	SendMessage(ctx context.Context, deadLetter *DeadLetterMessage) error
 }
 
 // DLQHandler gerencia a Dead Letter Queue para mensagens com falha
@@ -21,6 +23,7 @@ type DLQHandler struct {
 	sqsClient SQSClient
 	dlqURL    string
 	logger    *zap.Logger
+	now       func() time.Time
 }
 
 // NewDLQHandler cria um novo gerenciador de DLQ
@@ -29,46 +32,77 @@ func NewDLQHandler(sqsClient SQSClient, dlqURL string, logger *zap.Logger) *DLQH
 		sqsClient: sqsClient,
 		dlqURL:    dlqURL,
 		logger:    logger,
+		now:       time.Now,
 	}
 }
 
-// DeadLetterMessage representa uma mensagem na DLQ com metadados
+// DeliveryAttempt uma tentativa de processamento da mensagem
+type DeliveryAttempt struct {
+	Attempt   int       `json:"attempt"`
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error"`
+	ErrorCode string    `json:"error_code,omitempty"`
+}
+
+// DeadLetterMessage envelope enviado para a DLQ: a mensagem original com o histórico da falha
 type DeadLetterMessage struct {
-	OriginalMessage *Message
-	ReceiptHandle   *string
-	Reason          string
-	RetryCount      int
-	Timestamp       string
+	OriginalMessage *Message `json:"original_message"`
+	// ReceiptHandle da mensagem na DLQ (preenchido só na leitura)
+	ReceiptHandle *string `json:"-"`
+	Reason        string  `json:"failure_reason"`
+	// ErrorCode código do AppError da última falha
+	ErrorCode string `json:"error_code,omitempty"`
+	// RetryCount tentativas realizadas (entregas da mensagem na fila principal)
+	RetryCount int `json:"retry_count"`
+	// ApproximateReceiveCount atributo da SQS na última entrega
+	ApproximateReceiveCount int       `json:"approximate_receive_count,omitempty"`
+	FirstAttemptAt          time.Time `json:"first_attempt_at"`
+	LastAttemptAt           time.Time `json:"last_attempt_at"`
+	// Attempts erros das tentativas conhecidas pelo processo que enviou a mensagem para a DLQ; entregas
+	// anteriores processadas por outro container não aparecem (RetryCount continua exato)
+	Attempts []DeliveryAttempt `json:"attempts,omitempty"`
+	// RequestID request ID da invocação Lambda que enviou a mensagem para a DLQ
+	RequestID string `json:"request_id,omitempty"`
+	// Timestamp envio para a DLQ
+	Timestamp time.Time `json:"timestamp"`
 }
 
 // SendMessage envia uma mensagem para a Dead Letter Queue
-func (h *DLQHandler) SendMessage(ctx context.Context, message *Message, reason string) error {
-	// Enriquecer com informação de falha
-	enrichedPayload := map[string]interface{}{
-		"original_message": message,
-		"failure_reason":   reason,
+func (h *DLQHandler) SendMessage(ctx context.Context, deadLetter *DeadLetterMessage) error {
+	message := deadLetter.OriginalMessage
+	if message == nil {
+		return fmt.Errorf("dead letter has no original message")
+	}
+	if deadLetter.Timestamp.IsZero() {
+		deadLetter.Timestamp = h.now().UTC()
 	}
 
-	bodyBytes, err := json.Marshal(enrichedPayload)
+	bodyBytes, err := json.Marshal(deadLetter)
 	if err != nil {
 		h.logger.Error("failed to marshal DLQ message", zap.Error(err))
 		return fmt.Errorf("failed to marshal DLQ message: %w", err)
 	}
 
-	input := &sqs.SendMessageInput{
-		QueueUrl:    &h.dlqURL,
-		MessageBody: func() *string { s := string(bodyBytes); return &s }(),
-		MessageAttributes: map[string]types.MessageAttributeValue{
-			"OperationID": {
-				DataType:    func() *string { s := "String"; return &s }(),
-				StringValue: &message.OperationID,
-			},
-			"FailureReason": {
-				DataType:    func() *string { s := "String"; return &s }(),
-				StringValue: func() *string { return &reason }(),
-			},
+	attributes := map[string]types.MessageAttributeValue{
+		"OperationID":   stringAttribute(message.OperationID),
+		"FailureReason": stringAttribute(deadLetter.Reason),
+		"RetryCount": {
+			DataType:    aws.String("Number"),
+			StringValue: aws.String(strconv.Itoa(deadLetter.RetryCount)),
 		},
 	}
+	if deadLetter.ErrorCode != "" {
+		attributes["ErrorCode"] = stringAttribute(deadLetter.ErrorCode)
+	}
+	if deadLetter.RequestID != "" {
+		attributes["RequestID"] = stringAttribute(deadLetter.RequestID)
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:          &h.dlqURL,
+		MessageBody:       aws.String(string(bodyBytes)),
+		MessageAttributes: attributes,
+	}
 
 	_, err = h.sqsClient.SendMessage(ctx, input)
 	if err != nil {
@@ -80,13 +114,15 @@ func (h *DLQHandler) SendMessage(ctx context.Context, message *Message, reason s
 
 	h.logger.Info("message sent to DLQ",
 		zap.String("operation_id", message.OperationID),
-		zap.String("reason", reason))
+		zap.String("reason", deadLetter.Reason),
+		zap.String("error_code", deadLetter.ErrorCode),
+		zap.Int("retry_count", deadLetter.RetryCount))
 
 	return nil
 }
 
-// GetDeadLetterMessages recupera mensagens da DLQ
-func (h *DLQHandler) GetDeadLetterMessages(ctx context.Context, maxMessages int32) ([]types.Message, error) {
+// GetDeadLetterMessages recupera mensagens da DLQ com o envelope já interpretado
+func (h *DLQHandler) GetDeadLetterMessages(ctx context.Context, maxMessages int32) ([]DeadLetterMessage, error) {
 	input := &sqs.ReceiveMessageInput{
 		QueueUrl:              &h.dlqURL,
 		MaxNumberOfMessages:   maxMessages,
@@ -102,7 +138,12 @@ func (h *DLQHandler) GetDeadLetterMessages(ctx context.Context, maxMessages int3
 	}
 
 	h.logger.Info("received messages from DLQ", zap.Int("count", len(result.Messages)))
-	return result.Messages, nil
+
+	deadLetters := make([]DeadLetterMessage, 0, len(result.Messages))
+	for _, message := range result.Messages {
+		deadLetters = append(deadLetters, ParseDeadLetter(message).DeadLetterMessage)
+	}
+	return deadLetters, nil
 }
 
 // DeleteDeadLetterMessage remove uma mensagem da DLQ
@@ -153,3 +194,8 @@ func (h *DLQHandler) GetDeadLetterMessageCount(ctx context.Context) (int32, erro
 
 	return int32(count), nil
 }
+
+// stringAttribute atributo String de mensagem SQS
+func stringAttribute(value string) types.MessageAttributeValue {
+	return types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
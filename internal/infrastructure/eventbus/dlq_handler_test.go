@@ -2,6 +2,7 @@ package eventbus
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -75,7 +76,20 @@ func TestDLQHandler_SendMessageSuccess(t *testing.T) {
 
 	msgID := "msg-123"
 	mockSQS.On("SendMessage", mock.Anything, mock.MatchedBy(func(input *sqs.SendMessageInput) bool {
-		return input.QueueUrl != nil && *input.QueueUrl == dlqURL
+		var envelope DeadLetterMessage
+		if json.Unmarshal([]byte(*input.MessageBody), &envelope) != nil {
+			return false
+		}
+		return input.QueueUrl != nil && *input.QueueUrl == dlqURL &&
+			envelope.OriginalMessage.OperationID == "op-123" &&
+			envelope.Reason == "max retries exceeded" &&
+			envelope.RetryCount == 4 &&
+			envelope.RequestID == "request-1" &&
+			len(envelope.Attempts) == 1 &&
+			!envelope.Timestamp.IsZero() &&
+			*input.MessageAttributes["ErrorCode"].StringValue == "RPC_FAILED" &&
+			*input.MessageAttributes["RetryCount"].StringValue == "4" &&
+			*input.MessageAttributes["RequestID"].StringValue == "request-1"
 	})).Return(&sqs.SendMessageOutput{
 		MessageId: &msgID,
 	}, nil)
@@ -83,7 +97,14 @@ func TestDLQHandler_SendMessageSuccess(t *testing.T) {
 	dlqHandler := NewDLQHandler(mockSQS, dlqURL, logger)
 
 	// Act
-	err := dlqHandler.SendMessage(context.Background(), originalMessage, "max retries exceeded")
+	err := dlqHandler.SendMessage(context.Background(), &DeadLetterMessage{
+		OriginalMessage: originalMessage,
+		Reason:          "max retries exceeded",
+		ErrorCode:       "RPC_FAILED",
+		RetryCount:      4,
+		Attempts:        []DeliveryAttempt{{Attempt: 4, Error: "rpc timeout", ErrorCode: "RPC_FAILED"}},
+		RequestID:       "request-1",
+	})
 
 	// Assert
 	assert.NoError(t, err)
@@ -110,7 +131,7 @@ func TestDLQHandler_SendMessageFailure(t *testing.T) {
 	dlqHandler := NewDLQHandler(mockSQS, dlqURL, logger)
 
 	// Act
-	err2 := dlqHandler.SendMessage(context.Background(), originalMessage, "some reason")
+	err2 := dlqHandler.SendMessage(context.Background(), &DeadLetterMessage{OriginalMessage: originalMessage, Reason: "some reason"})
 
 	// Assert
 	assert.Error(t, err2)
@@ -126,7 +147,7 @@ func TestDLQHandler_GetDeadLetterMessages(t *testing.T) {
 
 	messageID1 := "msg-1"
 	messageID2 := "msg-2"
-	body1 := `{"operation_id":"op-1","chain_type":"sepolia"}`
+	body1 := `{"original_message":{"operation_id":"op-1","chain_type":"sepolia"},"failure_reason":"permanent failure","retry_count":1}`
 	body2 := `{"operation_id":"op-2","chain_type":"ethereum"}`
 	receiptHandle1 := "receipt-1"
 	receiptHandle2 := "receipt-2"
@@ -160,8 +181,12 @@ func TestDLQHandler_GetDeadLetterMessages(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(messages))
-	assert.Equal(t, messageID1, *messages[0].MessageId)
-	assert.Equal(t, messageID2, *messages[1].MessageId)
+	assert.Equal(t, "op-1", messages[0].OriginalMessage.OperationID)
+	assert.Equal(t, "permanent failure", messages[0].Reason)
+	assert.Equal(t, 1, messages[0].RetryCount)
+	assert.Equal(t, receiptHandle1, *messages[0].ReceiptHandle)
+	assert.Equal(t, "op-2", messages[1].OriginalMessage.OperationID)
+	assert.Equal(t, receiptHandle2, *messages[1].ReceiptHandle)
 }
 
 // TestDLQHandler_GetDeadLetterMessages_ReceiveError testa erro ao receber mensagens
@@ -0,0 +1,335 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.uber.org/zap"
+)
+
+// IdempotencyMode decisão de idempotência das mensagens reenviadas da DLQ para a fila principal
+type IdempotencyMode string
+
+const (
+	// IdempotencyKeep mantém a chave: operações já finalizadas (ex.: FAILED) são devolvidas sem reexecução,
+	// só as sem registro ou PENDING executam de novo
+	IdempotencyKeep IdempotencyMode = "keep"
+	// IdempotencyRenew gera uma nova chave: a operação é executada novamente mesmo com registro finalizado
+	IdempotencyRenew IdempotencyMode = "renew"
+)
+
+// NewIdempotencyMode converte o nome do modo (keep | renew)
+func NewIdempotencyMode(name string) (IdempotencyMode, error) {
+	switch mode := IdempotencyMode(strings.ToLower(name)); mode {
+	case IdempotencyKeep, IdempotencyRenew:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown idempotency mode: %s", name)
+	}
+}
+
+// DeadLetterEntry mensagem da DLQ com o envelope da falha interpretado
+type DeadLetterEntry struct {
+	MessageID string `json:"message_id"`
+	DeadLetterMessage
+	SentAt     time.Time         `json:"sent_at"`
+	Attributes map[string]string `json:"message_attributes,omitempty"`
+	// Body corpo recebido da DLQ, sem alterações
+	Body string `json:"body"`
+}
+
+// DeadLetterFilter seleção de mensagens da DLQ; campos vazios não filtram
+type DeadLetterFilter struct {
+	MessageID   string
+	OperationID string
+	ChainType   string
+	// Reason trecho do motivo da falha (sem diferenciar maiúsculas)
+	Reason string
+}
+
+// IsEmpty indica que o filtro seleciona todas as mensagens
+func (f DeadLetterFilter) IsEmpty() bool {
+	return f == DeadLetterFilter{}
+}
+
+// Matches indica se a entrada atende ao filtro
+func (f DeadLetterFilter) Matches(entry DeadLetterEntry) bool {
+	if f.MessageID != "" && entry.MessageID != f.MessageID {
+		return false
+	}
+	if f.OperationID != "" && (entry.OriginalMessage == nil || entry.OriginalMessage.OperationID != f.OperationID) {
+		return false
+	}
+	if f.ChainType != "" && (entry.OriginalMessage == nil || !strings.EqualFold(entry.OriginalMessage.ChainType, f.ChainType)) {
+		return false
+	}
+	if f.Reason != "" && !strings.Contains(strings.ToLower(entry.Reason), strings.ToLower(f.Reason)) {
+		return false
+	}
+	return true
+}
+
+// DLQManagerConfig configuração da inspeção da DLQ
+type DLQManagerConfig struct {
+	// MaxMessages mensagens lidas por operação (padrão 1000)
+	MaxMessages int
+	// VisibilityTimeout tempo em que as mensagens lidas ficam ocultas durante a operação (padrão 60s);
+	// ao final as não removidas voltam a ficar visíveis
+	VisibilityTimeout time.Duration
+}
+
+// DLQManager lista, reenvia (redrive) e remove mensagens da DLQ do DLQHandler
+type DLQManager struct {
+	handler  *DLQHandler
+	queueURL string
+	config   DLQManagerConfig
+	logger   *zap.Logger
+	now      func() time.Time
+}
+
+// NewDLQManager cria o gerenciador da DLQ; queueURL é a fila principal de destino do redrive
+func NewDLQManager(handler *DLQHandler, queueURL string, config DLQManagerConfig, logger *zap.Logger) *DLQManager {
+	if config.MaxMessages <= 0 {
+		config.MaxMessages = 1000
+	}
+	if config.VisibilityTimeout <= 0 {
+		config.VisibilityTimeout = 60 * time.Second
+	}
+	return &DLQManager{
+		handler:  handler,
+		queueURL: queueURL,
+		config:   config,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Count número aproximado de mensagens na DLQ
+func (m *DLQManager) Count(ctx context.Context) (int32, error) {
+	return m.handler.GetDeadLetterMessageCount(ctx)
+}
+
+// List mensagens da DLQ que atendem ao filtro; nenhuma mensagem é removida
+func (m *DLQManager) List(ctx context.Context, filter DeadLetterFilter) ([]DeadLetterEntry, error) {
+	entries, err := m.receiveAll(ctx)
+	defer m.release(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectEntries(entries, filter), nil
+}
+
+// Redrive reenvia as mensagens selecionadas para a fila principal (só a mensagem original, sem o envelope
+// da DLQ) e as remove da DLQ; com dryRun apenas retorna as que seriam reenviadas
+func (m *DLQManager) Redrive(ctx context.Context, filter DeadLetterFilter, mode IdempotencyMode, dryRun bool) ([]DeadLetterEntry, error) {
+	return m.apply(ctx, filter, dryRun, func(entry DeadLetterEntry) error {
+		return m.redrive(ctx, entry, mode)
+	})
+}
+
+// Purge remove da DLQ as mensagens selecionadas; com dryRun apenas retorna as que seriam removidas
+func (m *DLQManager) Purge(ctx context.Context, filter DeadLetterFilter, dryRun bool) ([]DeadLetterEntry, error) {
+	return m.apply(ctx, filter, dryRun, func(entry DeadLetterEntry) error {
+		return m.delete(ctx, entry)
+	})
+}
+
+// apply executa action (que remove a mensagem da DLQ) em cada entrada selecionada, parando na primeira
+// falha; retorna as entradas processadas e libera as demais mensagens lidas
+func (m *DLQManager) apply(ctx context.Context, filter DeadLetterFilter, dryRun bool, action func(entry DeadLetterEntry) error) ([]DeadLetterEntry, error) {
+	entries, err := m.receiveAll(ctx)
+	if err != nil {
+		m.release(ctx, entries)
+		return nil, err
+	}
+
+	selected := selectEntries(entries, filter)
+	if dryRun {
+		m.release(ctx, entries)
+		return selected, nil
+	}
+
+	var processed []DeadLetterEntry
+	removed := make(map[string]bool, len(selected))
+	for _, entry := range selected {
+		if err = action(entry); err != nil {
+			break
+		}
+		removed[entry.MessageID] = true
+		processed = append(processed, entry)
+	}
+
+	var remaining []DeadLetterEntry
+	for _, entry := range entries {
+		if !removed[entry.MessageID] {
+			remaining = append(remaining, entry)
+		}
+	}
+	m.release(ctx, remaining)
+
+	return processed, err
+}
+
+// receiveAll lê a DLQ até ela não retornar mais mensagens ou atingir MaxMessages; as mensagens lidas
+// ficam ocultas por VisibilityTimeout
+func (m *DLQManager) receiveAll(ctx context.Context) ([]DeadLetterEntry, error) {
+	var entries []DeadLetterEntry
+	seen := make(map[string]bool)
+
+	for len(entries) < m.config.MaxMessages {
+		batch := int32(min(maxReceiveMessages, m.config.MaxMessages-len(entries)))
+		result, err := m.handler.sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            &m.handler.dlqURL,
+			MaxNumberOfMessages: batch,
+			WaitTimeSeconds:     1,
+			VisibilityTimeout:   int32(m.config.VisibilityTimeout.Seconds()),
+			MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+				types.MessageSystemAttributeNameSentTimestamp,
+			},
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			m.logger.Error("failed to receive messages from DLQ", zap.Error(err))
+			return entries, fmt.Errorf("failed to receive messages from DLQ: %w", err)
+		}
+		if len(result.Messages) == 0 {
+			break
+		}
+
+		for _, message := range result.Messages {
+			entry := ParseDeadLetter(message)
+			if seen[entry.MessageID] {
+				continue
+			}
+			seen[entry.MessageID] = true
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// redrive envia a mensagem original para a fila principal e a remove da DLQ
+func (m *DLQManager) redrive(ctx context.Context, entry DeadLetterEntry, mode IdempotencyMode) error {
+	if entry.OriginalMessage == nil {
+		return fmt.Errorf("dead letter %s has no original message", entry.MessageID)
+	}
+
+	message := *entry.OriginalMessage
+	if mode == IdempotencyRenew {
+		message.IdempotencyKey = m.renewedIdempotencyKey(message)
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal redriven message: %w", err)
+	}
+
+	if _, err := m.handler.sqsClient.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    &m.queueURL,
+		MessageBody: aws.String(string(body)),
+	}); err != nil {
+		m.logger.Error("failed to redrive message",
+			zap.String("message_id", entry.MessageID),
+			zap.Error(err))
+		return fmt.Errorf("failed to redrive message %s: %w", entry.MessageID, err)
+	}
+
+	m.logger.Info("message redriven from DLQ",
+		zap.String("message_id", entry.MessageID),
+		zap.String("operation_id", message.OperationID),
+		zap.String("idempotency_key", message.IdempotencyKey))
+
+	return m.delete(ctx, entry)
+}
+
+// renewedIdempotencyKey chave derivada da original (ou do operation ID) com o instante do redrive
+func (m *DLQManager) renewedIdempotencyKey(message Message) string {
+	base := message.IdempotencyKey
+	if base == "" {
+		base = message.OperationID
+	}
+	return base + ":redrive:" + strconv.FormatInt(m.now().Unix(), 10)
+}
+
+// delete remove a mensagem da DLQ
+func (m *DLQManager) delete(ctx context.Context, entry DeadLetterEntry) error {
+	if err := m.handler.DeleteDeadLetterMessage(ctx, entry.ReceiptHandle); err != nil {
+		return fmt.Errorf("message %s: %w", entry.MessageID, err)
+	}
+	return nil
+}
+
+// release torna as mensagens lidas visíveis de novo; falhas só atrasam a volta até o fim do VisibilityTimeout
+func (m *DLQManager) release(ctx context.Context, entries []DeadLetterEntry) {
+	for _, entry := range entries {
+		if _, err := m.handler.sqsClient.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          &m.handler.dlqURL,
+			ReceiptHandle:     entry.ReceiptHandle,
+			VisibilityTimeout: 0,
+		}); err != nil {
+			m.logger.Warn("failed to release DLQ message",
+				zap.String("message_id", entry.MessageID),
+				zap.Error(err))
+		}
+	}
+}
+
+// ParseDeadLetter interpreta o envelope enviado pelo DLQHandler (mensagem original, motivo e histórico das
+// tentativas); mensagens movidas pela redrive policy da fila (sem envelope) são a própria mensagem original
+func ParseDeadLetter(message types.Message) DeadLetterEntry {
+	entry := DeadLetterEntry{
+		MessageID: aws.ToString(message.MessageId),
+		Body:      aws.ToString(message.Body),
+	}
+	entry.ReceiptHandle = message.ReceiptHandle
+
+	if sentAt, err := strconv.ParseInt(message.Attributes[string(types.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		entry.SentAt = time.UnixMilli(sentAt).UTC()
+	}
+	for name, value := range message.MessageAttributes {
+		if value.StringValue == nil {
+			continue
+		}
+		if entry.Attributes == nil {
+			entry.Attributes = make(map[string]string)
+		}
+		entry.Attributes[name] = *value.StringValue
+	}
+
+	var envelope DeadLetterMessage
+	if err := json.Unmarshal([]byte(entry.Body), &envelope); err != nil {
+		return entry
+	}
+	if envelope.OriginalMessage != nil {
+		envelope.ReceiptHandle = entry.ReceiptHandle
+		entry.DeadLetterMessage = envelope
+		return entry
+	}
+
+	var original Message
+	if err := json.Unmarshal([]byte(entry.Body), &original); err == nil && original.OperationID != "" {
+		entry.OriginalMessage = &original
+		entry.Reason = "max receive count exceeded (queue redrive policy)"
+	}
+	return entry
+}
+
+// selectEntries entradas que atendem ao filtro
+func selectEntries(entries []DeadLetterEntry, filter DeadLetterFilter) []DeadLetterEntry {
+	var selected []DeadLetterEntry
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			selected = append(selected, entry)
+		}
+	}
+	return selected
+}
@@ -0,0 +1,307 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+const (
+	testQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/evm-queue"
+	testDLQURL   = "https://sqs.us-east-1.amazonaws.com/123456789012/evm-dlq"
+)
+
+func deadLetter(id string, message Message, reason string) types.Message {
+	body, _ := json.Marshal(map[string]interface{}{
+		"original_message": message,
+		"failure_reason":   reason,
+	})
+	return types.Message{
+		MessageId:     stringPtr(id),
+		ReceiptHandle: stringPtr("receipt-" + id),
+		Body:          stringPtr(string(body)),
+		Attributes:    map[string]string{"SentTimestamp": "1767225600000"},
+	}
+}
+
+// newTestDLQManager DLQ com as mensagens informadas (seguidas de uma leitura vazia)
+func newTestDLQManager(messages ...types.Message) (*DLQManager, *mockSQSClient) {
+	mockSQS := new(mockSQSClient)
+	mockSQS.On("ReceiveMessage", mock.Anything, mock.MatchedBy(func(input *sqs.ReceiveMessageInput) bool {
+		return *input.QueueUrl == testDLQURL
+	})).Return(&sqs.ReceiveMessageOutput{Messages: messages}, nil).Once()
+	mockSQS.On("ReceiveMessage", mock.Anything, mock.Anything).Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+
+	manager := NewDLQManager(NewDLQHandler(mockSQS, testDLQURL, zap.NewNop()), testQueueURL, DLQManagerConfig{}, zap.NewNop())
+	manager.now = func() time.Time { return time.Unix(1767225600, 0) }
+	return manager, mockSQS
+}
+
+func expectRelease(mockSQS *mockSQSClient, receiptHandle string) {
+	mockSQS.On("ChangeMessageVisibility", mock.Anything, mock.MatchedBy(func(input *sqs.ChangeMessageVisibilityInput) bool {
+		return *input.ReceiptHandle == receiptHandle && input.VisibilityTimeout == 0
+	})).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+}
+
+func expectDelete(mockSQS *mockSQSClient, receiptHandle string) {
+	mockSQS.On("DeleteMessage", mock.Anything, mock.MatchedBy(func(input *sqs.DeleteMessageInput) bool {
+		return *input.QueueUrl == testDLQURL && *input.ReceiptHandle == receiptHandle
+	})).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+}
+
+var (
+	ethereumMessage = Message{OperationID: "op-1", ChainType: "ETHEREUM", OperationType: "TRANSFER", IdempotencyKey: "key-1"}
+	polygonMessage  = Message{OperationID: "op-2", ChainType: "POLYGON", OperationType: "TRANSFER", IdempotencyKey: "key-2"}
+)
+
+func TestParseDeadLetter(t *testing.T) {
+	t.Run("envelope from DLQHandler", func(t *testing.T) {
+		message := deadLetter("msg-1", ethereumMessage, "permanent failure: execution reverted")
+		message.MessageAttributes = map[string]types.MessageAttributeValue{
+			"FailureReason": {DataType: stringPtr("String"), StringValue: stringPtr("permanent failure: execution reverted")},
+		}
+
+		entry := ParseDeadLetter(message)
+
+		assert.Equal(t, "msg-1", entry.MessageID)
+		assert.Equal(t, "receipt-msg-1", *entry.ReceiptHandle)
+		assert.Equal(t, &ethereumMessage, entry.OriginalMessage)
+		assert.Equal(t, "permanent failure: execution reverted", entry.Reason)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), entry.SentAt)
+		assert.Equal(t, "permanent failure: execution reverted", entry.Attributes["FailureReason"])
+	})
+
+	t.Run("envelope with attempt history", func(t *testing.T) {
+		firstAttemptAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		body, _ := json.Marshal(DeadLetterMessage{
+			OriginalMessage:         &ethereumMessage,
+			Reason:                  "max retries exceeded: rpc timeout",
+			ErrorCode:               "RPC_FAILED",
+			RetryCount:              4,
+			ApproximateReceiveCount: 4,
+			FirstAttemptAt:          firstAttemptAt,
+			LastAttemptAt:           firstAttemptAt.Add(10 * time.Minute),
+			Attempts:                []DeliveryAttempt{{Attempt: 4, Timestamp: firstAttemptAt.Add(10 * time.Minute), Error: "rpc timeout", ErrorCode: "RPC_FAILED"}},
+			RequestID:               "request-1",
+		})
+
+		entry := ParseDeadLetter(types.Message{MessageId: stringPtr("msg-1"), ReceiptHandle: stringPtr("receipt-msg-1"), Body: stringPtr(string(body))})
+
+		assert.Equal(t, "RPC_FAILED", entry.ErrorCode)
+		assert.Equal(t, 4, entry.RetryCount)
+		assert.Equal(t, firstAttemptAt, entry.FirstAttemptAt)
+		assert.Equal(t, "request-1", entry.RequestID)
+		assert.Equal(t, "rpc timeout", entry.Attempts[0].Error)
+		assert.Equal(t, "receipt-msg-1", *entry.DeadLetterMessage.ReceiptHandle)
+	})
+
+	t.Run("original message moved by the queue redrive policy", func(t *testing.T) {
+		body, _ := json.Marshal(ethereumMessage)
+
+		entry := ParseDeadLetter(types.Message{MessageId: stringPtr("msg-1"), Body: stringPtr(string(body))})
+
+		assert.Equal(t, &ethereumMessage, entry.OriginalMessage)
+		assert.Contains(t, entry.Reason, "redrive policy")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		entry := ParseDeadLetter(types.Message{MessageId: stringPtr("msg-1"), Body: stringPtr("invalid")})
+
+		assert.Nil(t, entry.OriginalMessage)
+		assert.Equal(t, "invalid", entry.Body)
+	})
+}
+
+func TestDeadLetterFilter_Matches(t *testing.T) {
+	entry := DeadLetterEntry{MessageID: "msg-1", DeadLetterMessage: DeadLetterMessage{
+		OriginalMessage: &ethereumMessage,
+		Reason:          "max retries exceeded: rpc timeout",
+	}}
+
+	assert.True(t, DeadLetterFilter{}.Matches(entry))
+	assert.True(t, DeadLetterFilter{OperationID: "op-1", ChainType: "ethereum", Reason: "RPC TIMEOUT"}.Matches(entry))
+	assert.False(t, DeadLetterFilter{MessageID: "msg-2"}.Matches(entry))
+	assert.False(t, DeadLetterFilter{ChainType: "POLYGON"}.Matches(entry))
+	assert.False(t, DeadLetterFilter{Reason: "reverted"}.Matches(entry))
+	assert.False(t, DeadLetterFilter{OperationID: "op-1"}.Matches(DeadLetterEntry{MessageID: "msg-1"}))
+	assert.True(t, DeadLetterFilter{}.IsEmpty())
+}
+
+func TestNewIdempotencyMode(t *testing.T) {
+	mode, err := NewIdempotencyMode("RENEW")
+	assert.NoError(t, err)
+	assert.Equal(t, IdempotencyRenew, mode)
+
+	_, err = NewIdempotencyMode("reset")
+	assert.Error(t, err)
+}
+
+func TestDLQManager_List(t *testing.T) {
+	manager, mockSQS := newTestDLQManager(
+		deadLetter("msg-1", ethereumMessage, "permanent failure"),
+		deadLetter("msg-2", polygonMessage, "max retries exceeded"),
+	)
+	expectRelease(mockSQS, "receipt-msg-1")
+	expectRelease(mockSQS, "receipt-msg-2")
+
+	entries, err := manager.List(context.Background(), DeadLetterFilter{ChainType: "POLYGON"})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "op-2", entries[0].OriginalMessage.OperationID)
+	mockSQS.AssertExpectations(t)
+	mockSQS.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything)
+}
+
+func TestDLQManager_List_ReceiveError(t *testing.T) {
+	mockSQS := new(mockSQSClient)
+	mockSQS.On("ReceiveMessage", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+	manager := NewDLQManager(NewDLQHandler(mockSQS, testDLQURL, zap.NewNop()), testQueueURL, DLQManagerConfig{}, zap.NewNop())
+
+	entries, err := manager.List(context.Background(), DeadLetterFilter{})
+
+	assert.ErrorContains(t, err, "failed to receive messages from DLQ")
+	assert.Nil(t, entries)
+}
+
+func TestDLQManager_List_MaxMessages(t *testing.T) {
+	mockSQS := new(mockSQSClient)
+	mockSQS.On("ReceiveMessage", mock.Anything, mock.MatchedBy(func(input *sqs.ReceiveMessageInput) bool {
+		return input.MaxNumberOfMessages == 1
+	})).Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{deadLetter("msg-1", ethereumMessage, "permanent failure")}}, nil).Once()
+	expectRelease(mockSQS, "receipt-msg-1")
+	manager := NewDLQManager(NewDLQHandler(mockSQS, testDLQURL, zap.NewNop()), testQueueURL, DLQManagerConfig{MaxMessages: 1}, zap.NewNop())
+
+	entries, err := manager.List(context.Background(), DeadLetterFilter{})
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	mockSQS.AssertNumberOfCalls(t, "ReceiveMessage", 1)
+}
+
+func TestDLQManager_Redrive(t *testing.T) {
+	t.Run("send original message to the main queue and keep idempotency key", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(
+			deadLetter("msg-1", ethereumMessage, "permanent failure"),
+			deadLetter("msg-2", polygonMessage, "max retries exceeded"),
+		)
+		expected, _ := json.Marshal(ethereumMessage)
+		mockSQS.On("SendMessage", mock.Anything, mock.MatchedBy(func(input *sqs.SendMessageInput) bool {
+			return *input.QueueUrl == testQueueURL && *input.MessageBody == string(expected)
+		})).Return(&sqs.SendMessageOutput{}, nil).Once()
+		expectDelete(mockSQS, "receipt-msg-1")
+		expectRelease(mockSQS, "receipt-msg-2")
+
+		redriven, err := manager.Redrive(context.Background(), DeadLetterFilter{OperationID: "op-1"}, IdempotencyKeep, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, redriven, 1)
+		mockSQS.AssertExpectations(t)
+	})
+
+	t.Run("renew idempotency key", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(deadLetter("msg-1", ethereumMessage, "permanent failure"))
+		mockSQS.On("SendMessage", mock.Anything, mock.MatchedBy(func(input *sqs.SendMessageInput) bool {
+			var message Message
+			_ = json.Unmarshal([]byte(*input.MessageBody), &message)
+			return message.IdempotencyKey == "key-1:redrive:1767225600" && message.OperationID == "op-1"
+		})).Return(&sqs.SendMessageOutput{}, nil).Once()
+		expectDelete(mockSQS, "receipt-msg-1")
+
+		_, err := manager.Redrive(context.Background(), DeadLetterFilter{}, IdempotencyRenew, false)
+
+		assert.NoError(t, err)
+		mockSQS.AssertExpectations(t)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(deadLetter("msg-1", ethereumMessage, "permanent failure"))
+		expectRelease(mockSQS, "receipt-msg-1")
+
+		redriven, err := manager.Redrive(context.Background(), DeadLetterFilter{}, IdempotencyKeep, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, redriven, 1)
+		mockSQS.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+		mockSQS.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything)
+		mockSQS.AssertExpectations(t)
+	})
+
+	t.Run("stop on send failure and release remaining messages", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(
+			deadLetter("msg-1", ethereumMessage, "permanent failure"),
+			deadLetter("msg-2", polygonMessage, "permanent failure"),
+		)
+		mockSQS.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("throttled")).Once()
+		expectRelease(mockSQS, "receipt-msg-1")
+		expectRelease(mockSQS, "receipt-msg-2")
+
+		redriven, err := manager.Redrive(context.Background(), DeadLetterFilter{}, IdempotencyKeep, false)
+
+		assert.ErrorContains(t, err, "failed to redrive message msg-1")
+		assert.Empty(t, redriven)
+		mockSQS.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything)
+		mockSQS.AssertExpectations(t)
+	})
+
+	t.Run("message without original body", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(types.Message{MessageId: stringPtr("msg-1"), ReceiptHandle: stringPtr("receipt-msg-1"), Body: stringPtr("invalid")})
+		expectRelease(mockSQS, "receipt-msg-1")
+
+		_, err := manager.Redrive(context.Background(), DeadLetterFilter{}, IdempotencyKeep, false)
+
+		assert.ErrorContains(t, err, "has no original message")
+		mockSQS.AssertExpectations(t)
+	})
+}
+
+func TestDLQManager_Count(t *testing.T) {
+	mockSQS := new(mockSQSClient)
+	mockSQS.On("GetQueueAttributes", mock.Anything, mock.Anything).Return(&sqs.GetQueueAttributesOutput{
+		Attributes: map[string]string{"ApproximateNumberOfMessages": "7"},
+	}, nil)
+	manager := NewDLQManager(NewDLQHandler(mockSQS, testDLQURL, zap.NewNop()), testQueueURL, DLQManagerConfig{}, zap.NewNop())
+
+	count, err := manager.Count(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), count)
+}
+
+func TestDLQManager_Purge(t *testing.T) {
+	t.Run("delete selected messages", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(
+			deadLetter("msg-1", ethereumMessage, "permanent failure: execution reverted"),
+			deadLetter("msg-2", polygonMessage, "max retries exceeded"),
+		)
+		expectDelete(mockSQS, "receipt-msg-1")
+		expectRelease(mockSQS, "receipt-msg-2")
+
+		purged, err := manager.Purge(context.Background(), DeadLetterFilter{Reason: "reverted"}, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, purged, 1)
+		assert.Equal(t, "msg-1", purged[0].MessageID)
+		mockSQS.AssertExpectations(t)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		manager, mockSQS := newTestDLQManager(deadLetter("msg-1", ethereumMessage, "permanent failure"))
+		expectRelease(mockSQS, "receipt-msg-1")
+
+		purged, err := manager.Purge(context.Background(), DeadLetterFilter{}, true)
+
+		assert.NoError(t, err)
+		assert.Len(t, purged, 1)
+		mockSQS.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything)
+		mockSQS.AssertExpectations(t)
+	})
+}
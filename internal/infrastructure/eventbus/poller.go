@@ -0,0 +1,223 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.uber.org/zap"
+)
+
+// maxReceiveMessages limite do SQS por ReceiveMessage
+const maxReceiveMessages = 10
+
+// ErrShutdownTimeout mensagens ainda estavam em processamento ao fim do ShutdownTimeout
+var ErrShutdownTimeout = errors.New("shutdown timeout reached with messages in flight")
+
+// PollerConfig configuração do consumo contínuo da fila
+type PollerConfig struct {
+	// Concurrency mensagens processadas em paralelo (<= 0 = 1)
+	Concurrency int
+	// WaitTime long polling de cada ReceiveMessage (máximo de 20s)
+	WaitTime time.Duration
+	// VisibilityTimeout visibilidade das mensagens recebidas e renovada a cada heartbeat
+	VisibilityTimeout time.Duration
+	// HeartbeatInterval renovação da visibilidade das mensagens em processamento; deve ser menor que
+	// VisibilityTimeout (senão usa um terço dele)
+	HeartbeatInterval time.Duration
+	// ShutdownTimeout espera pelas mensagens em processamento depois que o contexto termina
+	ShutdownTimeout time.Duration
+	// ErrorBackoff espera após uma falha no ReceiveMessage
+	ErrorBackoff time.Duration
+}
+
+// DeliveryFunc processa uma mensagem recebida e decide o destino dela na fila
+type DeliveryFunc func(ctx context.Context, message types.Message) DeliveryResult
+
+// Poller consome a fila com long polling e um pool limitado de workers: remove as mensagens processadas
+// ou enviadas à DLQ e devolve as falhas transitórias com a visibilidade do backoff
+type Poller struct {
+	consumer *SQSConsumer
+	config   PollerConfig
+	logger   *zap.Logger
+}
+
+// NewPoller cria o poller da fila
+func NewPoller(consumer *SQSConsumer, config PollerConfig, logger *zap.Logger) *Poller {
+	if config.Concurrency <= 0 {
+		config.Concurrency = 1
+	}
+	if config.WaitTime > 20*time.Second {
+		config.WaitTime = 20 * time.Second
+	}
+	if config.VisibilityTimeout <= 0 {
+		config.VisibilityTimeout = 30 * time.Second
+	}
+	if config.HeartbeatInterval <= 0 || config.HeartbeatInterval >= config.VisibilityTimeout {
+		config.HeartbeatInterval = config.VisibilityTimeout / 3
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = 30 * time.Second
+	}
+	if config.ErrorBackoff <= 0 {
+		config.ErrorBackoff = 5 * time.Second
+	}
+	return &Poller{consumer: consumer, config: config, logger: logger}
+}
+
+// Run consome a fila até ctx terminar. As mensagens em processamento não são canceladas junto com ctx:
+// têm até ShutdownTimeout para terminar, depois disso o contexto delas é cancelado e Run retorna
+// ErrShutdownTimeout (as mensagens voltam para a fila quando a visibilidade expirar)
+func (p *Poller) Run(ctx context.Context, deliver DeliveryFunc) error {
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
+
+	slots := make(chan struct{}, p.config.Concurrency)
+	var wg sync.WaitGroup
+
+	p.logger.Info("poller started", zap.Int("concurrency", p.config.Concurrency))
+
+	for {
+		free, ok := p.acquireSlots(ctx, slots)
+		if !ok {
+			break
+		}
+
+		messages, err := p.consumer.Receive(ctx, ReceiveOptions{
+			MaxMessages:       int32(free),
+			WaitTime:          p.config.WaitTime,
+			VisibilityTimeout: p.config.VisibilityTimeout,
+		})
+		for i := len(messages); i < free; i++ {
+			<-slots
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			p.sleep(ctx, p.config.ErrorBackoff)
+			continue
+		}
+
+		for _, message := range messages {
+			wg.Add(1)
+			go func(message types.Message) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				p.handle(workCtx, message, deliver)
+			}(message)
+		}
+	}
+
+	p.logger.Info("poller stopping, waiting for in-flight messages", zap.Int("in_flight", len(slots)))
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("poller stopped")
+		return nil
+	case <-time.After(p.config.ShutdownTimeout):
+		cancelWork()
+		<-done
+		p.logger.Warn("poller stopped before in-flight messages finished", zap.Duration("shutdown_timeout", p.config.ShutdownTimeout))
+		return ErrShutdownTimeout
+	}
+}
+
+// acquireSlots espera um worker livre e reserva os demais disponíveis (até o limite do ReceiveMessage);
+// ok = false quando ctx terminou
+func (p *Poller) acquireSlots(ctx context.Context, slots chan struct{}) (int, bool) {
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return 0, false
+	}
+
+	free := 1
+	for free < maxReceiveMessages {
+		select {
+		case slots <- struct{}{}:
+			free++
+		default:
+			return free, true
+		}
+	}
+	return free, true
+}
+
+// handle processa a mensagem renovando a visibilidade enquanto ela está em andamento e aplica o resultado
+func (p *Poller) handle(ctx context.Context, message types.Message, deliver DeliveryFunc) {
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		p.heartbeat(heartbeatCtx, message)
+	}()
+
+	result := deliver(ctx, message)
+	stopHeartbeat()
+	<-heartbeatDone
+
+	messageID := aws.ToString(message.MessageId)
+	switch result.Outcome {
+	case DeliverySucceeded, DeliveryDeadLettered:
+		if err := p.consumer.DeleteMessage(ctx, message.ReceiptHandle); err != nil {
+			p.logger.Warn("failed to delete processed message, it will be redelivered",
+				zap.String("message_id", messageID),
+				zap.Error(err))
+		}
+	case DeliveryRetry:
+		// Sem visibilidade definida (ex.: corpo inválido) a mensagem volta ao fim do VisibilityTimeout
+		if result.Visibility > 0 {
+			if err := p.consumer.ChangeMessageVisibility(ctx, message.ReceiptHandle, int32(result.Visibility.Seconds())); err != nil {
+				p.logger.Warn("failed to change message visibility, using receive visibility",
+					zap.String("message_id", messageID),
+					zap.Error(err))
+			}
+		}
+		p.logger.Warn("message will be retried",
+			zap.String("message_id", messageID),
+			zap.Duration("visibility", result.Visibility),
+			zap.Error(result.Err))
+	}
+}
+
+// heartbeat renova a visibilidade da mensagem a cada HeartbeatInterval até ctx terminar
+func (p *Poller) heartbeat(ctx context.Context, message types.Message) {
+	ticker := time.NewTicker(p.config.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.consumer.ChangeMessageVisibility(ctx, message.ReceiptHandle, int32(p.config.VisibilityTimeout.Seconds())); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				p.logger.Warn("failed to extend message visibility",
+					zap.String("message_id", aws.ToString(message.MessageId)),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// sleep espera d ou até ctx terminar
+func (p *Poller) sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
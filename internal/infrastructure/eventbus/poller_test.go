@@ -0,0 +1,160 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// blockUntilDone faz o ReceiveMessage esperar o cancelamento do contexto, como o long polling
+func blockUntilDone(args mock.Arguments) {
+	<-args.Get(0).(context.Context).Done()
+}
+
+func sqsMessage(id string) types.Message {
+	return types.Message{MessageId: stringPtr(id), ReceiptHandle: stringPtr("receipt-" + id), Body: stringPtr("{}")}
+}
+
+func TestPoller_Run(t *testing.T) {
+	t.Run("apply delivery outcome to each message", func(t *testing.T) {
+		mockClient := new(MockSQSClient)
+		consumer := NewSQSConsumer(mockClient, "queue-url", zap.NewNop())
+		poller := NewPoller(consumer, PollerConfig{Concurrency: 3, VisibilityTimeout: 30 * time.Second}, zap.NewNop())
+
+		mockClient.On("ReceiveMessage", mock.Anything, mock.MatchedBy(func(input *sqs.ReceiveMessageInput) bool {
+			return input.MaxNumberOfMessages == 3 && input.VisibilityTimeout == 30
+		})).Return(&sqs.ReceiveMessageOutput{
+			Messages: []types.Message{sqsMessage("ok"), sqsMessage("dlq"), sqsMessage("retry")},
+		}, nil).Once()
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Run(blockUntilDone).Return(nil, context.Canceled)
+		mockClient.On("DeleteMessage", mock.Anything, mock.MatchedBy(func(input *sqs.DeleteMessageInput) bool {
+			return *input.ReceiptHandle == "receipt-ok" || *input.ReceiptHandle == "receipt-dlq"
+		})).Return(&sqs.DeleteMessageOutput{}, nil).Twice()
+		mockClient.On("ChangeMessageVisibility", mock.Anything, mock.MatchedBy(func(input *sqs.ChangeMessageVisibilityInput) bool {
+			return *input.ReceiptHandle == "receipt-retry" && input.VisibilityTimeout == 45
+		})).Return(&sqs.ChangeMessageVisibilityOutput{}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var delivered sync.WaitGroup
+		delivered.Add(3)
+		go func() {
+			delivered.Wait()
+			cancel()
+		}()
+
+		err := poller.Run(ctx, func(ctx context.Context, message types.Message) DeliveryResult {
+			defer delivered.Done()
+			switch *message.MessageId {
+			case "dlq":
+				return DeliveryResult{Outcome: DeliveryDeadLettered, Err: errors.New("reverted")}
+			case "retry":
+				return DeliveryResult{Outcome: DeliveryRetry, Visibility: 45 * time.Second, Err: errors.New("rpc timeout")}
+			}
+			return DeliveryResult{Outcome: DeliverySucceeded}
+		})
+
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("extend visibility while message is in flight", func(t *testing.T) {
+		mockClient := new(MockSQSClient)
+		consumer := NewSQSConsumer(mockClient, "queue-url", zap.NewNop())
+		poller := NewPoller(consumer, PollerConfig{
+			Concurrency:       1,
+			VisibilityTimeout: 2 * time.Second,
+			HeartbeatInterval: 10 * time.Millisecond,
+		}, zap.NewNop())
+
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{sqsMessage("slow")}}, nil).Once()
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Run(blockUntilDone).Return(nil, context.Canceled)
+		mockClient.On("ChangeMessageVisibility", mock.Anything, mock.MatchedBy(func(input *sqs.ChangeMessageVisibilityInput) bool {
+			return input.VisibilityTimeout == 2
+		})).Return(&sqs.ChangeMessageVisibilityOutput{}, nil)
+		mockClient.On("DeleteMessage", mock.Anything, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		err := poller.Run(ctx, func(ctx context.Context, message types.Message) DeliveryResult {
+			defer cancel()
+			time.Sleep(50 * time.Millisecond)
+			return DeliveryResult{Outcome: DeliverySucceeded}
+		})
+
+		assert.NoError(t, err)
+		mockClient.AssertCalled(t, "ChangeMessageVisibility", mock.Anything, mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("finish in-flight messages on shutdown", func(t *testing.T) {
+		mockClient := new(MockSQSClient)
+		consumer := NewSQSConsumer(mockClient, "queue-url", zap.NewNop())
+		poller := NewPoller(consumer, PollerConfig{Concurrency: 1, ShutdownTimeout: time.Second}, zap.NewNop())
+
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{sqsMessage("1")}}, nil).Once()
+		mockClient.On("DeleteMessage", mock.Anything, mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil).Once()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var deliveryErr error
+		err := poller.Run(ctx, func(ctx context.Context, message types.Message) DeliveryResult {
+			cancel()
+			time.Sleep(20 * time.Millisecond)
+			deliveryErr = ctx.Err()
+			return DeliveryResult{Outcome: DeliverySucceeded}
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, deliveryErr)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("cancel in-flight messages after shutdown timeout", func(t *testing.T) {
+		mockClient := new(MockSQSClient)
+		consumer := NewSQSConsumer(mockClient, "queue-url", zap.NewNop())
+		poller := NewPoller(consumer, PollerConfig{Concurrency: 1, ShutdownTimeout: 20 * time.Millisecond}, zap.NewNop())
+
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Return(&sqs.ReceiveMessageOutput{Messages: []types.Message{sqsMessage("1")}}, nil).Once()
+		mockClient.On("ChangeMessageVisibility", mock.Anything, mock.Anything).Return(nil, context.Canceled).Maybe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		err := poller.Run(ctx, func(ctx context.Context, message types.Message) DeliveryResult {
+			cancel()
+			<-ctx.Done()
+			return DeliveryResult{Outcome: DeliveryRetry, Err: ctx.Err()}
+		})
+
+		assert.ErrorIs(t, err, ErrShutdownTimeout)
+		mockClient.AssertNotCalled(t, "DeleteMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("back off after receive failure", func(t *testing.T) {
+		mockClient := new(MockSQSClient)
+		consumer := NewSQSConsumer(mockClient, "queue-url", zap.NewNop())
+		poller := NewPoller(consumer, PollerConfig{Concurrency: 1, ErrorBackoff: 10 * time.Millisecond}, zap.NewNop())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Return(nil, errors.New("sqs unavailable")).Once()
+		mockClient.On("ReceiveMessage", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { cancel() }).Return(nil, context.Canceled).Once()
+
+		err := poller.Run(ctx, func(ctx context.Context, message types.Message) DeliveryResult {
+			return DeliveryResult{Outcome: DeliverySucceeded}
+		})
+
+		assert.NoError(t, err)
+		mockClient.AssertNumberOfCalls(t, "ReceiveMessage", 2)
+	})
+}
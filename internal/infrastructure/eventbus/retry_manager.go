@@ -2,115 +2,506 @@ package eventbus
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
 	"time"
 
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"go.uber.org/zap"
 )
 
+// ErrPermanentFailure falha classificada como permanente: não é retentada e vai para a DLQ na primeira
+// entrega, pois nenhuma nova tentativa muda o resultado (a transação fica em FAILED); DeliveryResult.Err
+// a envolve junto com o erro original
+var ErrPermanentFailure = errors.New("permanent failure")
+
+// attemptHistoryTTL tempo sem novas entregas após o qual o histórico de tentativas de uma mensagem é descartado
+const attemptHistoryTTL = time.Hour
+
+// inProcessDeadlineMargin tempo que precisa sobrar no contexto, além da espera, para ProcessDelivery
+// tentar de novo no próprio processo (RetryModeInProcess)
+const inProcessDeadlineMargin = 10 * time.Second
+
 // ProcessorFunc tipo para função que processa mensagens
 type ProcessorFunc func(ctx context.Context) error
 
+// JitterStrategy aleatoriedade aplicada às esperas entre tentativas
+type JitterStrategy string
+
+const (
+	// JitterNone backoff exponencial sem aleatoriedade
+	JitterNone JitterStrategy = "none"
+	// JitterFull espera uniforme entre 0 e o backoff exponencial
+	JitterFull JitterStrategy = "full"
+	// JitterDecorrelated espera uniforme entre a espera inicial e o triplo da anterior
+	JitterDecorrelated JitterStrategy = "decorrelated"
+)
+
+// NewJitterStrategy converte o nome da estratégia (none | full | decorrelated)
+func NewJitterStrategy(name string) (JitterStrategy, error) {
+	switch jitter := JitterStrategy(strings.ToLower(name)); jitter {
+	case JitterNone, JitterFull, JitterDecorrelated:
+		return jitter, nil
+	default:
+		return "", fmt.Errorf("unknown jitter strategy: %s", name)
+	}
+}
+
+// RetryMode onde ProcessDelivery espera entre as tentativas de uma falha transitória
+type RetryMode string
+
+const (
+	// RetryModeVisibility a mensagem volta para a fila com a espera como visibilidade; nenhum tempo
+	// de execução é gasto esperando
+	RetryModeVisibility RetryMode = "visibility"
+	// RetryModeInProcess espera no próprio processo e tenta de novo na mesma entrega enquanto o prazo do
+	// contexto permitir; sem prazo suficiente a mensagem volta para a fila como em RetryModeVisibility
+	RetryModeInProcess RetryMode = "in_process"
+)
+
+// NewRetryMode converte o nome do modo (visibility | in_process)
+func NewRetryMode(name string) (RetryMode, error) {
+	switch mode := RetryMode(strings.ToLower(name)); mode {
+	case RetryModeVisibility, RetryModeInProcess:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown retry mode: %s", name)
+	}
+}
+
+// RetryMetrics recebe as novas tentativas agendadas e as mensagens enviadas para a DLQ, por categoria de erro
+type RetryMetrics interface {
+	IncrementRetryAttempt(category string)
+	IncrementDeadLetter(category string)
+}
+
 // RetryConfig configuração de retry
 type RetryConfig struct {
-	MaxRetries        int
+	// MaxRetries novas tentativas após a primeira falha transitória
+	MaxRetries int
+	// CategoryMaxRetries limite de novas tentativas por categoria de erro (ex.: mais tentativas para
+	// NONCE_CONFLICT); categorias ausentes usam MaxRetries
+	CategoryMaxRetries map[pkgerrors.Category]int
+	// MaxElapsedTime tempo máximo desde a primeira tentativa; falhas que só seriam retentadas depois
+	// dele vão para a DLQ (0 = sem limite)
+	MaxElapsedTime time.Duration
+	// Mode espera de ProcessDelivery (padrão RetryModeVisibility)
+	Mode RetryMode
+	// Jitter aleatoriedade das esperas (padrão JitterFull)
+	Jitter            JitterStrategy
 	InitialBackoff    time.Duration
 	MaxBackoff        time.Duration
 	BackoffMultiplier float64
+	// UnavailableBackoff espera mínima quando o circuit breaker do endpoint RPC rejeita a chamada; essas
+	// rejeições consomem tentativas e respeitam MaxElapsedTime como as demais falhas transitórias
+	UnavailableBackoff time.Duration
+	// InitialVisibility visibilidade da mensagem após a primeira falha transitória em ProcessDelivery,
+	// multiplicada por BackoffMultiplier a cada nova entrega até MaxVisibility; com jitter a visibilidade
+	// nunca fica abaixo de metade de InitialVisibility
+	InitialVisibility time.Duration
+	MaxVisibility     time.Duration
 }
 
 // DefaultRetryConfig retorna configuração padrão de retry
 func DefaultRetryConfig() RetryConfig {
 	return RetryConfig{
-		MaxRetries:        3,
-		InitialBackoff:    100 * time.Millisecond,
-		MaxBackoff:        5 * time.Second,
-		BackoffMultiplier: 2.0,
+		MaxRetries:         3,
+		Mode:               RetryModeVisibility,
+		Jitter:             JitterFull,
+		InitialBackoff:     100 * time.Millisecond,
+		MaxBackoff:         5 * time.Second,
+		BackoffMultiplier:  2.0,
+		UnavailableBackoff: 5 * time.Second,
+		InitialVisibility:  30 * time.Second,
+		MaxVisibility:      15 * time.Minute,
 	}
 }
 
+// DeliveryOutcome destino de uma entrega SQS após o processamento
+type DeliveryOutcome string
+
+const (
+	// DeliverySucceeded processada; a mensagem pode ser removida da fila
+	DeliverySucceeded DeliveryOutcome = "SUCCEEDED"
+	// DeliveryRetry falha transitória; a mensagem fica na fila e volta após Visibility
+	DeliveryRetry DeliveryOutcome = "RETRY"
+	// DeliveryDeadLettered falha terminal enviada para a DLQ; a mensagem pode ser removida da fila
+	DeliveryDeadLettered DeliveryOutcome = "DEAD_LETTERED"
+)
+
+// DeliveryResult resultado de ProcessDelivery
+type DeliveryResult struct {
+	Outcome DeliveryOutcome
+	// Visibility nova visibilidade da mensagem quando Outcome é DeliveryRetry (segundos inteiros)
+	Visibility time.Duration
+	// Err falha do processamento (ou do envio para a DLQ)
+	Err error
+}
+
+// Delivery dados da entrega SQS processada por ProcessDelivery
+type Delivery struct {
+	MessageID string
+	// ReceiveCount número da entrega (ApproximateReceiveCount, 1 na primeira)
+	ReceiveCount int
+	// FirstReceivedAt primeira entrega da mensagem (ApproximateFirstReceiveTimestamp)
+	FirstReceivedAt time.Time
+	// RequestID request ID da invocação Lambda que processa a entrega
+	RequestID string
+}
+
+// attemptHistory tentativas com falha de uma mensagem vistas por este processo
+type attemptHistory struct {
+	attempts []DeliveryAttempt
+	// lastWait última espera agendada (base de JitterDecorrelated)
+	lastWait  time.Duration
+	updatedAt time.Time
+}
+
 // RetryManager gerencia retries e Dead Letter Queue
 type RetryManager struct {
 	dlqHandler DLQSender
 	config     RetryConfig
+	metrics    RetryMetrics
 	logger     *zap.Logger
+	now        func() time.Time
+	// random duração uniforme em [0, n]
+	random func(n time.Duration) time.Duration
+
+	mu      sync.Mutex
+	history map[string]*attemptHistory
 }
 
-// NewRetryManager cria um novo gerenciador de retry
+// NewRetryManager cria um novo gerenciador de retry com a configuração padrão e maxRetries
 func NewRetryManager(dlqHandler DLQSender, maxRetries int, logger *zap.Logger) *RetryManager {
 	config := DefaultRetryConfig()
 	config.MaxRetries = maxRetries
+	return NewRetryManagerWithConfig(dlqHandler, config, nil, logger)
+}
+
+// NewRetryManagerWithConfig cria o gerenciador de retry com a política informada; campos zerados usam
+// os valores de DefaultRetryConfig e metrics pode ser nil
+func NewRetryManagerWithConfig(dlqHandler DLQSender, config RetryConfig, metrics RetryMetrics, logger *zap.Logger) *RetryManager {
+	defaults := DefaultRetryConfig()
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.Mode == "" {
+		config.Mode = defaults.Mode
+	}
+	if config.Jitter == "" {
+		config.Jitter = defaults.Jitter
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = max(defaults.MaxBackoff, config.InitialBackoff)
+	}
+	if config.BackoffMultiplier < 1 {
+		config.BackoffMultiplier = defaults.BackoffMultiplier
+	}
+	if config.UnavailableBackoff <= 0 {
+		config.UnavailableBackoff = defaults.UnavailableBackoff
+	}
+	if config.InitialVisibility <= 0 {
+		config.InitialVisibility = defaults.InitialVisibility
+	}
+	if config.MaxVisibility < config.InitialVisibility {
+		config.MaxVisibility = max(defaults.MaxVisibility, config.InitialVisibility)
+	}
+
 	return &RetryManager{
 		dlqHandler: dlqHandler,
 		config:     config,
+		metrics:    metrics,
 		logger:     logger,
+		now:        time.Now,
+		random: func(n time.Duration) time.Duration {
+			return time.Duration(rand.Int64N(int64(n) + 1))
+		},
+		history: make(map[string]*attemptHistory),
 	}
 }
 
-// ProcessWithRetry executa processamento com retry automático
-func (rm *RetryManager) ProcessWithRetry(
+// ProcessDelivery processa uma entrega de uma mensagem SQS. Falhas transitórias voltam para a fila com a
+// espera da política como visibilidade (RetryModeVisibility) ou são retentadas no próprio processo
+// (RetryModeInProcess); falhas permanentes e transitórias fora dos limites da política vão para a DLQ
+// com o histórico das tentativas. Se o envio para a DLQ falhar a mensagem é mantida na fila para não
+// ser perdida
+func (rm *RetryManager) ProcessDelivery(
 	ctx context.Context,
 	message *Message,
+	delivery Delivery,
 	processor ProcessorFunc,
-) error {
-	backoff := rm.config.InitialBackoff
-
-	for attempt := 0; attempt <= rm.config.MaxRetries; attempt++ {
-		// Verificar se contexto foi cancelado
-		if ctx.Err() != nil {
-			return fmt.Errorf("context cancelled: %w", ctx.Err())
-		}
+) DeliveryResult {
+	key := historyKey(message, delivery)
+	// Tentativas feitas no processo contam junto com as entregas da SQS
+	attempt := max(delivery.ReceiveCount, rm.lastAttempt(key)+1)
 
-		// Tentar processar
+	for {
+		startedAt := rm.now().UTC()
 		err := processor(ctx)
 		if err == nil {
-			// Sucesso
+			rm.forgetAttempts(key)
 			rm.logger.Info("message processed successfully",
 				zap.String("operation_id", message.OperationID),
-				zap.Int("attempt", attempt+1))
-			return nil
+				zap.Int("attempt", attempt),
+				zap.Int("receive_count", delivery.ReceiveCount))
+			return DeliveryResult{Outcome: DeliverySucceeded}
 		}
 
-		// Se for última tentativa, enviar para DLQ
-		if attempt == rm.config.MaxRetries {
-			failureReason := fmt.Sprintf("max retries exceeded: %v", err)
-			rm.logger.Error("max retries exceeded, sending to DLQ",
-				zap.String("operation_id", message.OperationID),
-				zap.Int("total_attempts", attempt+1),
-				zap.Error(err))
+		history := rm.recordAttempt(key, newDeliveryAttempt(attempt, startedAt, err))
+		firstAttemptAt := delivery.FirstReceivedAt.UTC()
+		if delivery.FirstReceivedAt.IsZero() {
+			firstAttemptAt = history.attempts[0].Timestamp
+		}
+
+		initial, maxWait := rm.config.InitialVisibility, rm.config.MaxVisibility
+		if rm.config.Mode == RetryModeInProcess {
+			initial, maxWait = rm.config.InitialBackoff, rm.config.MaxBackoff
+		}
+		wait := rm.backoff(attempt, history.lastWait, initial, maxWait)
+		if isRPCUnavailable(err) && rm.config.UnavailableBackoff > wait {
+			wait = rm.config.UnavailableBackoff
+		}
+
+		failureReason := rm.exhausted(attempt, err, rm.now().Sub(firstAttemptAt)+wait)
+		if failureReason == "" {
+			rm.setLastWait(key, wait)
+			rm.recordRetry(err)
 
-			dlqErr := rm.dlqHandler.SendMessage(ctx, message, failureReason)
-			if dlqErr != nil {
-				return fmt.Errorf("failed to send to DLQ: %w", dlqErr)
+			if rm.config.Mode == RetryModeInProcess && canWait(ctx, wait) {
+				rm.logger.Warn("processing failed, retrying in process",
+					zap.String("operation_id", message.OperationID),
+					zap.Int("attempt", attempt),
+					zap.Int("max_retries", rm.maxRetries(err)),
+					zap.Duration("wait", wait),
+					zap.Error(err))
+
+				select {
+				case <-time.After(wait):
+					attempt++
+					continue
+				case <-ctx.Done():
+					return DeliveryResult{Outcome: DeliveryRetry, Err: err}
+				}
 			}
 
-			return fmt.Errorf("max retries exceeded: %v", err)
+			visibility := wait
+			if rm.config.Mode == RetryModeInProcess {
+				// Sem prazo para esperar no processo: a espera passa para a fila
+				visibility = rm.backoff(attempt, 0, rm.config.InitialVisibility, rm.config.MaxVisibility)
+			}
+			visibility = rm.visibility(visibility)
+			rm.logger.Warn("processing failed, message will be redelivered",
+				zap.String("operation_id", message.OperationID),
+				zap.Int("attempt", attempt),
+				zap.Int("receive_count", delivery.ReceiveCount),
+				zap.Int("max_retries", rm.maxRetries(err)),
+				zap.Duration("visibility", visibility),
+				zap.Error(err))
+			return DeliveryResult{Outcome: DeliveryRetry, Visibility: visibility, Err: err}
 		}
 
-		// Log de retry
-		rm.logger.Warn("processing failed, will retry",
+		rm.logger.Error("terminal failure, sending to DLQ",
 			zap.String("operation_id", message.OperationID),
-			zap.Int("attempt", attempt+1),
-			zap.Int("max_retries", rm.config.MaxRetries),
-			zap.Duration("next_backoff", backoff),
+			zap.Int("attempt", attempt),
+			zap.Int("receive_count", delivery.ReceiveCount),
+			zap.String("category", string(pkgerrors.CategoryOfError(err))),
 			zap.Error(err))
 
-		// Aguardar com backoff exponencial
-		select {
-		case <-time.After(backoff):
-			// Continuar
-		case <-ctx.Done():
-			return fmt.Errorf("context cancelled during backoff: %w", ctx.Err())
+		deadLetter := &DeadLetterMessage{
+			OriginalMessage:         message,
+			Reason:                  failureReason,
+			ErrorCode:               errorCode(err),
+			RetryCount:              attempt,
+			ApproximateReceiveCount: delivery.ReceiveCount,
+			FirstAttemptAt:          firstAttemptAt,
+			LastAttemptAt:           startedAt,
+			Attempts:                history.attempts,
+			RequestID:               delivery.RequestID,
+		}
+		if dlqErr := rm.dlqHandler.SendMessage(ctx, deadLetter); dlqErr != nil {
+			return DeliveryResult{
+				Outcome:    DeliveryRetry,
+				Visibility: rm.visibility(rm.backoff(attempt, 0, rm.config.InitialVisibility, rm.config.MaxVisibility)),
+				Err:        fmt.Errorf("failed to send to DLQ: %w", dlqErr),
+			}
+		}
+		rm.forgetAttempts(key)
+		rm.recordDeadLetter(err)
+		if !pkgerrors.IsRetryable(err) {
+			err = fmt.Errorf("%w: %w", ErrPermanentFailure, err)
+		}
+		return DeliveryResult{Outcome: DeliveryDeadLettered, Err: err}
+	}
+}
+
+// exhausted motivo do envio para a DLQ da falha na tentativa attempt (a primeira é 1), ou vazio se ela
+// ainda pode ser retentada; elapsed é o tempo desde a primeira tentativa até a próxima
+func (rm *RetryManager) exhausted(attempt int, err error, elapsed time.Duration) string {
+	switch {
+	case !pkgerrors.IsRetryable(err):
+		return fmt.Sprintf("permanent failure: %v", err)
+	case attempt > rm.maxRetries(err):
+		return fmt.Sprintf("max retries exceeded: %v", err)
+	case rm.config.MaxElapsedTime > 0 && elapsed > rm.config.MaxElapsedTime:
+		return fmt.Sprintf("max elapsed time exceeded: %v", err)
+	default:
+		return ""
+	}
+}
+
+// maxRetries limite de novas tentativas para a categoria do erro
+func (rm *RetryManager) maxRetries(err error) int {
+	if limit, ok := rm.config.CategoryMaxRetries[pkgerrors.CategoryOfError(err)]; ok {
+		return limit
+	}
+	return rm.config.MaxRetries
+}
+
+// backoff espera após a falha da tentativa attempt (a primeira é 1), limitada a [0, maxWait];
+// previous é a espera anterior, base de JitterDecorrelated
+func (rm *RetryManager) backoff(attempt int, previous, initial, maxWait time.Duration) time.Duration {
+	if rm.config.Jitter == JitterDecorrelated {
+		upper := min(max(previous, initial)*3, maxWait)
+		if upper <= initial {
+			return min(initial, maxWait)
 		}
+		return initial + rm.random(upper-initial)
+	}
+
+	wait := initial
+	for i := 1; i < attempt && wait < maxWait; i++ {
+		wait = time.Duration(float64(wait) * rm.config.BackoffMultiplier)
+	}
+	wait = min(wait, maxWait)
 
-		// Aumentar backoff exponencialmente, mas não exceder máximo
-		backoff = time.Duration(float64(backoff) * rm.config.BackoffMultiplier)
-		if backoff > rm.config.MaxBackoff {
-			backoff = rm.config.MaxBackoff
+	if rm.config.Jitter == JitterFull {
+		return rm.random(wait)
+	}
+	return wait
+}
+
+// recordRetry conta uma nova tentativa agendada
+func (rm *RetryManager) recordRetry(err error) {
+	if rm.metrics != nil {
+		rm.metrics.IncrementRetryAttempt(string(pkgerrors.CategoryOfError(err)))
+	}
+}
+
+// recordDeadLetter conta uma mensagem enviada para a DLQ
+func (rm *RetryManager) recordDeadLetter(err error) {
+	if rm.metrics != nil {
+		rm.metrics.IncrementDeadLetter(string(pkgerrors.CategoryOfError(err)))
+	}
+}
+
+// recordAttempt adiciona a tentativa ao histórico da mensagem e retorna uma cópia do histórico;
+// históricos sem novas entregas há mais de attemptHistoryTTL são descartados
+func (rm *RetryManager) recordAttempt(key string, attempt DeliveryAttempt) attemptHistory {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	now := rm.now()
+	for k, h := range rm.history {
+		if now.Sub(h.updatedAt) > attemptHistoryTTL {
+			delete(rm.history, k)
 		}
 	}
 
-	return fmt.Errorf("unexpected error: retry loop exited without result")
+	h, ok := rm.history[key]
+	if !ok {
+		h = &attemptHistory{}
+		rm.history[key] = h
+	}
+	h.attempts = append(h.attempts, attempt)
+	h.updatedAt = now
+
+	return attemptHistory{
+		attempts:  append([]DeliveryAttempt(nil), h.attempts...),
+		lastWait:  h.lastWait,
+		updatedAt: h.updatedAt,
+	}
+}
+
+// lastAttempt número da última tentativa registrada da mensagem (0 sem histórico)
+func (rm *RetryManager) lastAttempt(key string) int {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	h, ok := rm.history[key]
+	if !ok || len(h.attempts) == 0 {
+		return 0
+	}
+	return h.attempts[len(h.attempts)-1].Attempt
+}
+
+// setLastWait registra a espera agendada para a próxima tentativa da mensagem
+func (rm *RetryManager) setLastWait(key string, wait time.Duration) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if h, ok := rm.history[key]; ok {
+		h.lastWait = wait
+	}
+}
+
+// forgetAttempts remove o histórico da mensagem finalizada
+func (rm *RetryManager) forgetAttempts(key string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	delete(rm.history, key)
+}
+
+// historyKey identifica a mensagem no histórico: o message ID da SQS ou, sem ele, o operation ID
+func historyKey(message *Message, delivery Delivery) string {
+	if delivery.MessageID != "" {
+		return delivery.MessageID
+	}
+	return message.OperationID
+}
+
+// canWait indica se o prazo do contexto comporta a espera e uma nova tentativa
+func canWait(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > wait+inProcessDeadlineMargin
+}
+
+// visibility converte a espera em visibilidade da mensagem: no mínimo metade de InitialVisibility, pois com
+// JitterFull o sorteio pode chegar a 0s e a mensagem voltaria imediatamente, e em segundos inteiros
+func (rm *RetryManager) visibility(wait time.Duration) time.Duration {
+	return wholeSeconds(max(wait, rm.config.InitialVisibility/2))
+}
+
+// wholeSeconds arredonda a visibilidade para cima em segundos inteiros, a unidade da SQS
+func wholeSeconds(visibility time.Duration) time.Duration {
+	if rounded := visibility.Truncate(time.Second); rounded < visibility {
+		return rounded + time.Second
+	}
+	return visibility
+}
+
+// newDeliveryAttempt registro da tentativa com falha
+func newDeliveryAttempt(attempt int, at time.Time, err error) DeliveryAttempt {
+	return DeliveryAttempt{Attempt: attempt, Timestamp: at, Error: err.Error(), ErrorCode: errorCode(err)}
+}
+
+// errorCode código do AppError na cadeia de err (vazio se não houver)
+func errorCode(err error) string {
+	var appErr *pkgerrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
+// isRPCUnavailable indica que a chamada foi rejeitada pelo circuit breaker do endpoint RPC
+func isRPCUnavailable(err error) bool {
+	return errors.Is(err, pkgerrors.ErrRPCUnavailable)
 }
 
 // GetRetryConfig retorna configuração atual de retry
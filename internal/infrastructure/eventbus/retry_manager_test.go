@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
@@ -15,166 +17,419 @@ type mockDLQHandler struct {
 	mock.Mock
 }
 
-func (m *mockDLQHandler) SendMessage(ctx context.Context, message *Message, reason string) error {
-	args := m.Called(ctx, message, reason)
+func (m *mockDLQHandler) SendMessage(ctx context.Context, deadLetter *DeadLetterMessage) error {
+	args := m.Called(ctx, deadLetter)
 	if args.Get(0) != nil {
 		return args.Get(0).(error)
 	}
 	return args.Error(0)
 }
 
-// TestRetryManager_ProcessWithRetry_Success testa processamento bem-sucedido na primeira tentativa
-func TestRetryManager_ProcessWithRetry_Success(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 3, logger)
-
-	processedCalls := 0
-	processorFunc := func(ctx context.Context) error {
-		processedCalls++
-		return nil
-	}
+// mockRetryMetrics para testes de métricas de retry
+type mockRetryMetrics struct {
+	mock.Mock
+}
 
-	message := &Message{
-		OperationID:   "op-123",
-		ChainType:     "sepolia",
-		OperationType: "TRANSFER",
-	}
+func (m *mockRetryMetrics) IncrementRetryAttempt(category string) {
+	m.Called(category)
+}
 
-	// Act
-	err := retryManager.ProcessWithRetry(context.Background(), message, processorFunc)
+func (m *mockRetryMetrics) IncrementDeadLetter(category string) {
+	m.Called(category)
+}
 
-	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, 1, processedCalls)
+// deadLetterFor aceita o envelope da mensagem com o motivo informado
+func deadLetterFor(message *Message, reason string) interface{} {
+	return mock.MatchedBy(func(deadLetter *DeadLetterMessage) bool {
+		return deadLetter.OriginalMessage == message && deadLetter.Reason == reason
+	})
 }
 
-// TestRetryManager_ProcessWithRetry_RetryAndSucceed testa falha + sucesso na retry
-func TestRetryManager_ProcessWithRetry_RetryAndSucceed(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 3, logger)
-
-	processedCalls := 0
-	processorFunc := func(ctx context.Context) error {
-		processedCalls++
-		if processedCalls < 2 {
-			return errors.New("temporary error")
+// TestRetryManager_ProcessDelivery testa o destino de cada entrega SQS
+func TestRetryManager_ProcessDelivery(t *testing.T) {
+	message := &Message{OperationID: "op-123"}
+	transient := pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get balance", errors.New("i/o timeout"))
+
+	t.Run("success", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 3, zap.NewNop())
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return nil })
+
+		assert.Equal(t, DeliverySucceeded, result.Outcome)
+		assert.NoError(t, result.Err)
+	})
+
+	t.Run("transient failure is redelivered with growing visibility", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 3, zap.NewNop())
+		retryManager.config.Jitter = JitterNone
+		processor := func(ctx context.Context) error { return transient }
+
+		first := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, processor)
+		second := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 2}, processor)
+
+		assert.Equal(t, DeliveryRetry, first.Outcome)
+		assert.Equal(t, 30*time.Second, first.Visibility)
+		assert.Equal(t, 60*time.Second, second.Visibility)
+		assert.ErrorIs(t, first.Err, transient)
+		mockDLQ.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("visibility is capped", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 100, zap.NewNop())
+		retryManager.config.Jitter = JitterNone
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 50}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, 15*time.Minute, result.Visibility)
+	})
+
+	t.Run("rpc unavailable waits at least the unavailable backoff", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+		retryManager.config.UnavailableBackoff = time.Minute
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error {
+			return pkgerrors.NewAppError(pkgerrors.ErrRPCUnavailable.Code, "failed to get nonce", nil)
+		})
+
+		assert.Equal(t, DeliveryRetry, result.Outcome)
+		assert.Equal(t, time.Minute, result.Visibility)
+	})
+
+	t.Run("exhausted retries go to DLQ", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 3, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, deadLetterFor(message, "max retries exceeded: "+transient.Error())).Return(nil)
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 4}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		assert.NotErrorIs(t, result.Err, ErrPermanentFailure)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("permanent failure goes to DLQ on first delivery", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 3, zap.NewNop())
+		permanent := pkgerrors.NewAppError(pkgerrors.ErrInsufficientFunds.Code, "failed to sign and send transaction", nil)
+		mockDLQ.On("SendMessage", mock.Anything, deadLetterFor(message, "permanent failure: "+permanent.Error())).Return(nil)
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return permanent })
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		assert.ErrorIs(t, result.Err, permanent)
+		assert.ErrorIs(t, result.Err, ErrPermanentFailure)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("keep message in queue when DLQ send fails", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 0, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, mock.Anything).Return(errors.New("access denied"))
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryRetry, result.Outcome)
+		assert.ErrorContains(t, result.Err, "failed to send to DLQ")
+	})
+
+	t.Run("dead letter carries the attempt history", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryManager := NewRetryManager(mockDLQ, 2, zap.NewNop())
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		retryManager.now = func() time.Time { return now }
+		firstReceivedAt := now.Add(-time.Hour)
+		var sent *DeadLetterMessage
+		mockDLQ.On("SendMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			sent = args.Get(1).(*DeadLetterMessage)
+		}).Return(nil)
+		processor := func(ctx context.Context) error { return transient }
+
+		for receiveCount := 1; receiveCount <= 3; receiveCount++ {
+			now = now.Add(time.Minute)
+			retryManager.ProcessDelivery(context.Background(), message, Delivery{
+				MessageID:       "msg-1",
+				ReceiveCount:    receiveCount,
+				FirstReceivedAt: firstReceivedAt,
+				RequestID:       "request-1",
+			}, processor)
 		}
-		return nil
-	}
 
-	message := &Message{
-		OperationID:   "op-123",
-		ChainType:     "sepolia",
-		OperationType: "TRANSFER",
-	}
-
-	// Act
-	err := retryManager.ProcessWithRetry(context.Background(), message, processorFunc)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.Equal(t, 2, processedCalls)
+		assert.Equal(t, "max retries exceeded: "+transient.Error(), sent.Reason)
+		assert.Equal(t, pkgerrors.ErrRPCFailed.Code, sent.ErrorCode)
+		assert.Equal(t, 3, sent.RetryCount)
+		assert.Equal(t, 3, sent.ApproximateReceiveCount)
+		assert.Equal(t, firstReceivedAt, sent.FirstAttemptAt)
+		assert.Equal(t, now, sent.LastAttemptAt)
+		assert.Equal(t, "request-1", sent.RequestID)
+		assert.Len(t, sent.Attempts, 3)
+		assert.Equal(t, DeliveryAttempt{Attempt: 3, Timestamp: now, Error: transient.Error(), ErrorCode: pkgerrors.ErrRPCFailed.Code}, sent.Attempts[2])
+		assert.Empty(t, retryManager.history)
+	})
+
+	t.Run("success clears the attempt history", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+		delivery := Delivery{MessageID: "msg-1", ReceiveCount: 1}
+
+		retryManager.ProcessDelivery(context.Background(), message, delivery, func(ctx context.Context) error { return transient })
+		assert.Len(t, retryManager.history, 1)
+
+		delivery.ReceiveCount = 2
+		retryManager.ProcessDelivery(context.Background(), message, delivery, func(ctx context.Context) error { return nil })
+		assert.Empty(t, retryManager.history)
+	})
+
+	t.Run("stale history is discarded", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+		now := time.Now()
+		retryManager.now = func() time.Time { return now }
+		processor := func(ctx context.Context) error { return transient }
+
+		retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, processor)
+		now = now.Add(attemptHistoryTTL + time.Minute)
+		retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-2", ReceiveCount: 1}, processor)
+
+		assert.NotContains(t, retryManager.history, "msg-1")
+		assert.Contains(t, retryManager.history, "msg-2")
+	})
 }
 
-// TestRetryManager_ProcessWithRetry_ExhaustedRetries testa esgotamento de retries
-func TestRetryManager_ProcessWithRetry_ExhaustedRetries(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 2, logger)
-
-	processorFunc := func(ctx context.Context) error {
-		return errors.New("persistent error")
-	}
-
-	message := &Message{
-		OperationID:   "op-123",
-		ChainType:     "sepolia",
-		OperationType: "TRANSFER",
-	}
+func TestNewJitterStrategy(t *testing.T) {
+	jitter, err := NewJitterStrategy("Decorrelated")
+	assert.NoError(t, err)
+	assert.Equal(t, JitterDecorrelated, jitter)
 
-	mockDLQ.On("SendMessage", mock.Anything, message, mock.MatchedBy(func(reason string) bool {
-		return reason == "max retries exceeded: persistent error"
-	})).Return(nil)
+	_, err = NewJitterStrategy("equal")
+	assert.EqualError(t, err, "unknown jitter strategy: equal")
+}
 
-	// Act
-	err := retryManager.ProcessWithRetry(context.Background(), message, processorFunc)
+func TestNewRetryMode(t *testing.T) {
+	mode, err := NewRetryMode("in_process")
+	assert.NoError(t, err)
+	assert.Equal(t, RetryModeInProcess, mode)
 
-	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, "max retries exceeded: persistent error", err.Error())
-	mockDLQ.AssertExpectations(t)
+	_, err = NewRetryMode("sleep")
+	assert.EqualError(t, err, "unknown retry mode: sleep")
 }
 
-// TestRetryManager_ProcessWithRetry_DLQError testa erro ao enviar para DLQ
-func TestRetryManager_ProcessWithRetry_DLQError(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 1, logger)
-
-	processorFunc := func(ctx context.Context) error {
-		return errors.New("processing error")
-	}
+func TestNewRetryManagerWithConfig(t *testing.T) {
+	retryManager := NewRetryManagerWithConfig(new(mockDLQHandler), RetryConfig{
+		MaxRetries:        5,
+		InitialVisibility: time.Hour,
+	}, nil, zap.NewNop())
 
-	message := &Message{
-		OperationID:   "op-123",
-		ChainType:     "sepolia",
-		OperationType: "TRANSFER",
-	}
+	config := retryManager.GetRetryConfig()
+	assert.Equal(t, 5, config.MaxRetries)
+	assert.Equal(t, RetryModeVisibility, config.Mode)
+	assert.Equal(t, JitterFull, config.Jitter)
+	assert.Equal(t, 100*time.Millisecond, config.InitialBackoff)
+	assert.Equal(t, 2.0, config.BackoffMultiplier)
+	assert.Equal(t, time.Hour, config.InitialVisibility)
+	assert.Equal(t, time.Hour, config.MaxVisibility)
+}
 
-	mockDLQ.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).
-		Return(errors.New("failed to send to DLQ"))
+func TestRetryManager_Backoff(t *testing.T) {
+	retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+	// maior valor possível do intervalo sorteado
+	retryManager.random = func(n time.Duration) time.Duration { return n }
 
-	// Act
-	err := retryManager.ProcessWithRetry(context.Background(), message, processorFunc)
+	t.Run("exponential without jitter", func(t *testing.T) {
+		retryManager.config.Jitter = JitterNone
 
-	// Assert
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to send to DLQ")
-}
+		assert.Equal(t, time.Second, retryManager.backoff(1, 0, time.Second, time.Minute))
+		assert.Equal(t, 4*time.Second, retryManager.backoff(3, 0, time.Second, time.Minute))
+		assert.Equal(t, time.Minute, retryManager.backoff(10, 0, time.Second, time.Minute))
+	})
 
-// TestRetryManager_ProcessWithRetry_ContextCancelled testa contexto cancelado
-func TestRetryManager_ProcessWithRetry_ContextCancelled(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 3, logger)
+	t.Run("full jitter", func(t *testing.T) {
+		retryManager.config.Jitter = JitterFull
 
-	processorFunc := func(ctx context.Context) error {
-		return ctx.Err()
-	}
+		assert.Equal(t, 4*time.Second, retryManager.backoff(3, 0, time.Second, time.Minute))
 
-	message := &Message{
-		OperationID:   "op-123",
-		ChainType:     "sepolia",
-		OperationType: "TRANSFER",
-	}
+		retryManager.random = func(n time.Duration) time.Duration { return n / 2 }
+		assert.Equal(t, 2*time.Second, retryManager.backoff(3, 0, time.Second, time.Minute))
+		retryManager.random = func(n time.Duration) time.Duration { return n }
+	})
 
-	ctx, cancel := context.WithCancel(context.Background())
-	cancel()
+	t.Run("decorrelated jitter", func(t *testing.T) {
+		retryManager.config.Jitter = JitterDecorrelated
 
-	// Act
-	err := retryManager.ProcessWithRetry(ctx, message, processorFunc)
+		assert.Equal(t, 3*time.Second, retryManager.backoff(1, 0, time.Second, time.Minute))
+		assert.Equal(t, 30*time.Second, retryManager.backoff(2, 10*time.Second, time.Second, time.Minute))
+		assert.Equal(t, time.Minute, retryManager.backoff(3, 30*time.Second, time.Second, time.Minute))
 
-	// Assert
-	assert.Error(t, err)
+		retryManager.random = func(n time.Duration) time.Duration { return 0 }
+		assert.Equal(t, time.Second, retryManager.backoff(3, 30*time.Second, time.Second, time.Minute))
+	})
 }
 
-// TestRetryManager_GetRetryConfig testa obtenção de configuração de retry
-func TestRetryManager_GetRetryConfig(t *testing.T) {
-	// Arrange
-	mockDLQ := new(mockDLQHandler)
-	logger := zap.NewNop()
-	retryManager := NewRetryManager(mockDLQ, 5, logger)
-
-	// Act
-	config := retryManager.GetRetryConfig()
-
-	// Assert
-	assert.Equal(t, 5, config.MaxRetries)
+// TestRetryManager_ProcessDelivery_Policy testa os limites e modos da política de retry
+func TestRetryManager_ProcessDelivery_Policy(t *testing.T) {
+	message := &Message{OperationID: "op-123"}
+	transient := pkgerrors.NewAppError(pkgerrors.ErrRPCFailed.Code, "failed to get balance", errors.New("i/o timeout"))
+	nonceConflict := pkgerrors.NewAppError("NONCE_TOO_LOW", "failed to send transaction", nil)
+
+	t.Run("per category retry limit", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		config := DefaultRetryConfig()
+		config.CategoryMaxRetries = map[pkgerrors.Category]int{pkgerrors.CategoryNonceConflict: 5}
+		retryManager := NewRetryManagerWithConfig(mockDLQ, config, nil, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, deadLetterFor(message, "max retries exceeded: "+transient.Error())).Return(nil)
+
+		nonce := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 4}, func(ctx context.Context) error { return nonceConflict })
+		rpc := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-2", ReceiveCount: 4}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryRetry, nonce.Outcome)
+		assert.Equal(t, DeliveryDeadLettered, rpc.Outcome)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("max elapsed time", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		config := DefaultRetryConfig()
+		config.MaxElapsedTime = 30 * time.Minute
+		retryManager := NewRetryManagerWithConfig(mockDLQ, config, nil, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, deadLetterFor(message, "max elapsed time exceeded: "+transient.Error())).Return(nil)
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{
+			MessageID:       "msg-1",
+			ReceiveCount:    2,
+			FirstReceivedAt: time.Now().Add(-time.Hour),
+		}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("visibility in whole seconds with jitter", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+		retryManager.random = func(n time.Duration) time.Duration { return n/2 + time.Millisecond }
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryRetry, result.Outcome)
+		assert.Equal(t, 16*time.Second, result.Visibility)
+	})
+
+	t.Run("visibility floor with full jitter", func(t *testing.T) {
+		retryManager := NewRetryManager(new(mockDLQHandler), 3, zap.NewNop())
+		// menor valor possível do intervalo sorteado
+		retryManager.random = func(n time.Duration) time.Duration { return 0 }
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryRetry, result.Outcome)
+		assert.Equal(t, 15*time.Second, result.Visibility)
+	})
+
+	t.Run("in process retries within the delivery", func(t *testing.T) {
+		retryMetrics := new(mockRetryMetrics)
+		retryMetrics.On("IncrementRetryAttempt", string(pkgerrors.CategoryRPCTransient)).Return().Twice()
+		config := DefaultRetryConfig()
+		config.Mode = RetryModeInProcess
+		config.InitialBackoff = time.Millisecond
+		config.MaxBackoff = time.Millisecond
+		retryManager := NewRetryManagerWithConfig(new(mockDLQHandler), config, retryMetrics, zap.NewNop())
+
+		calls := 0
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error {
+			if calls++; calls < 3 {
+				return transient
+			}
+			return nil
+		})
+
+		assert.Equal(t, DeliverySucceeded, result.Outcome)
+		assert.Equal(t, 3, calls)
+		retryMetrics.AssertExpectations(t)
+	})
+
+	t.Run("in process dead letters after the limit", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		retryMetrics := new(mockRetryMetrics)
+		retryMetrics.On("IncrementRetryAttempt", mock.Anything).Return()
+		retryMetrics.On("IncrementDeadLetter", string(pkgerrors.CategoryRPCTransient)).Return().Once()
+		config := DefaultRetryConfig()
+		config.MaxRetries = 2
+		config.Mode = RetryModeInProcess
+		config.InitialBackoff = time.Millisecond
+		config.MaxBackoff = time.Millisecond
+		retryManager := NewRetryManagerWithConfig(mockDLQ, config, retryMetrics, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, mock.MatchedBy(func(deadLetter *DeadLetterMessage) bool {
+			return deadLetter.RetryCount == 3 && deadLetter.ApproximateReceiveCount == 1 && len(deadLetter.Attempts) == 3
+		})).Return(nil)
+
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error { return transient })
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		mockDLQ.AssertExpectations(t)
+		retryMetrics.AssertExpectations(t)
+	})
+
+	t.Run("in process stops retrying an unavailable rpc at the limit", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		config := DefaultRetryConfig()
+		config.MaxRetries = 2
+		config.Mode = RetryModeInProcess
+		config.InitialBackoff = time.Millisecond
+		config.MaxBackoff = time.Millisecond
+		config.UnavailableBackoff = time.Millisecond
+		retryManager := NewRetryManagerWithConfig(mockDLQ, config, nil, zap.NewNop())
+		mockDLQ.On("SendMessage", mock.Anything, mock.Anything).Return(nil).Once()
+
+		calls := 0
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error {
+			calls++
+			return pkgerrors.NewAppError(pkgerrors.ErrRPCUnavailable.Code, "failed to get nonce", nil)
+		})
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		assert.Equal(t, 3, calls)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("in process stops at the max elapsed time", func(t *testing.T) {
+		mockDLQ := new(mockDLQHandler)
+		config := DefaultRetryConfig()
+		config.MaxRetries = 100
+		config.MaxElapsedTime = 2 * time.Minute
+		config.Mode = RetryModeInProcess
+		config.InitialBackoff = time.Millisecond
+		config.MaxBackoff = time.Millisecond
+		retryManager := NewRetryManagerWithConfig(mockDLQ, config, nil, zap.NewNop())
+		now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		retryManager.now = func() time.Time { return now }
+		mockDLQ.On("SendMessage", mock.Anything, deadLetterFor(message, "max elapsed time exceeded: "+transient.Error())).Return(nil).Once()
+
+		calls := 0
+		result := retryManager.ProcessDelivery(context.Background(), message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error {
+			calls++
+			now = now.Add(time.Minute)
+			return transient
+		})
+
+		assert.Equal(t, DeliveryDeadLettered, result.Outcome)
+		assert.Equal(t, 2, calls)
+		mockDLQ.AssertExpectations(t)
+	})
+
+	t.Run("in process hands the backoff to SQS near the deadline", func(t *testing.T) {
+		config := DefaultRetryConfig()
+		config.Mode = RetryModeInProcess
+		config.Jitter = JitterNone
+		retryManager := NewRetryManagerWithConfig(new(mockDLQHandler), config, nil, zap.NewNop())
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		calls := 0
+		result := retryManager.ProcessDelivery(ctx, message, Delivery{MessageID: "msg-1", ReceiveCount: 1}, func(ctx context.Context) error {
+			calls++
+			return transient
+		})
+
+		assert.Equal(t, DeliveryRetry, result.Outcome)
+		assert.Equal(t, 30*time.Second, result.Visibility)
+		assert.Equal(t, 1, calls)
+	})
 }
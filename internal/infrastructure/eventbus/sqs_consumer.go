@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
@@ -37,13 +39,35 @@ type Message struct {
 	IdempotencyKey string                 `json:"idempotency_key"`
 }
 
+// ReceiveOptions parâmetros de um ReceiveMessage
+type ReceiveOptions struct {
+	MaxMessages       int32
+	WaitTime          time.Duration
+	VisibilityTimeout time.Duration
+}
+
 // ReceiveMessages recebe mensagens da fila SQS
 func (c *SQSConsumer) ReceiveMessages(ctx context.Context, maxMessages int32) ([]types.Message, error) {
+	return c.Receive(ctx, ReceiveOptions{
+		MaxMessages:       maxMessages,
+		WaitTime:          20 * time.Second,
+		VisibilityTimeout: 300 * time.Second,
+	})
+}
+
+// Receive recebe mensagens da fila com os atributos ApproximateReceiveCount, ApproximateFirstReceiveTimestamp
+// e MessageGroupId
+func (c *SQSConsumer) Receive(ctx context.Context, opts ReceiveOptions) ([]types.Message, error) {
 	input := &sqs.ReceiveMessageInput{
 		QueueUrl:            &c.queueURL,
-		MaxNumberOfMessages: maxMessages,
-		WaitTimeSeconds:     20,
-		VisibilityTimeout:   300,
+		MaxNumberOfMessages: opts.MaxMessages,
+		WaitTimeSeconds:     int32(opts.WaitTime.Seconds()),
+		VisibilityTimeout:   int32(opts.VisibilityTimeout.Seconds()),
+		MessageSystemAttributeNames: []types.MessageSystemAttributeName{
+			types.MessageSystemAttributeNameApproximateReceiveCount,
+			types.MessageSystemAttributeNameApproximateFirstReceiveTimestamp,
+			types.MessageSystemAttributeNameMessageGroupId,
+		},
 	}
 
 	result, err := c.sqsClient.ReceiveMessage(ctx, input)
@@ -56,6 +80,26 @@ func (c *SQSConsumer) ReceiveMessages(ctx context.Context, maxMessages int32) ([
 	return result.Messages, nil
 }
 
+// ReceiveCount número da entrega atual a partir dos atributos da mensagem (ApproximateReceiveCount, 1 na primeira)
+func ReceiveCount(attributes map[string]string) int {
+	count, err := strconv.Atoi(attributes[string(types.MessageSystemAttributeNameApproximateReceiveCount)])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+// NewDelivery dados da entrega a partir do message ID e dos atributos da mensagem
+// (ApproximateReceiveCount e ApproximateFirstReceiveTimestamp)
+func NewDelivery(messageID string, attributes map[string]string) Delivery {
+	delivery := Delivery{MessageID: messageID, ReceiveCount: ReceiveCount(attributes)}
+	firstReceive, err := strconv.ParseInt(attributes[string(types.MessageSystemAttributeNameApproximateFirstReceiveTimestamp)], 10, 64)
+	if err == nil {
+		delivery.FirstReceivedAt = time.UnixMilli(firstReceive).UTC()
+	}
+	return delivery
+}
+
 // ParseMessage transforma a mensagem em estrutura utilizável
 func (c *SQSConsumer) ParseMessage(message types.Message) (*Message, error) {
 	var msg Message
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
@@ -276,3 +277,54 @@ func TestMessage_Marshalling(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, msg.OperationID, parsed.OperationID)
 }
+
+func TestSQSConsumer_Receive_Options(t *testing.T) {
+	t.Parallel()
+
+	mockClient := new(MockSQSClient)
+	consumer := NewSQSConsumer(mockClient, "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", zap.NewNop())
+
+	mockClient.On("ReceiveMessage", mock.Anything, mock.MatchedBy(func(input *sqs.ReceiveMessageInput) bool {
+		return input.MaxNumberOfMessages == 5 &&
+			input.WaitTimeSeconds == 10 &&
+			input.VisibilityTimeout == 60 &&
+			assert.ObjectsAreEqual([]types.MessageSystemAttributeName{
+				types.MessageSystemAttributeNameApproximateReceiveCount,
+				types.MessageSystemAttributeNameApproximateFirstReceiveTimestamp,
+				types.MessageSystemAttributeNameMessageGroupId,
+			}, input.MessageSystemAttributeNames)
+	})).Return(&sqs.ReceiveMessageOutput{}, nil)
+
+	_, err := consumer.Receive(context.Background(), ReceiveOptions{
+		MaxMessages:       5,
+		WaitTime:          10 * time.Second,
+		VisibilityTimeout: 60 * time.Second,
+	})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestReceiveCount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 3, ReceiveCount(map[string]string{"ApproximateReceiveCount": "3"}))
+	assert.Equal(t, 1, ReceiveCount(map[string]string{"ApproximateReceiveCount": "invalid"}))
+	assert.Equal(t, 1, ReceiveCount(nil))
+}
+
+func TestNewDelivery(t *testing.T) {
+	t.Parallel()
+
+	delivery := NewDelivery("msg-1", map[string]string{
+		"ApproximateReceiveCount":          "2",
+		"ApproximateFirstReceiveTimestamp": "1767225600000",
+	})
+
+	assert.Equal(t, Delivery{
+		MessageID:       "msg-1",
+		ReceiveCount:    2,
+		FirstReceivedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, delivery)
+	assert.True(t, NewDelivery("msg-1", nil).FirstReceivedAt.IsZero())
+}
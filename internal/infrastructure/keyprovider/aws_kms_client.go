@@ -0,0 +1,52 @@
+package keyprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// KMSAPI subconjunto do cliente do SDK usado pelo provider, para permitir mocking
+type KMSAPI interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// AWSKMSClient implementa KMSClient sobre o cliente do aws-sdk-go-v2
+type AWSKMSClient struct {
+	api KMSAPI
+}
+
+// NewAWSKMSClient cria um cliente KMS a partir de um cliente do SDK (kms.NewFromConfig)
+func NewAWSKMSClient(api KMSAPI) *AWSKMSClient {
+	return &AWSKMSClient{api: api}
+}
+
+// GetPublicKey retorna a chave pública DER da chave KMS
+func (c *AWSKMSClient) GetPublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	resp, err := c.api.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("KMS GetPublicKey failed: %w", err)
+	}
+	if resp.KeySpec != "" && resp.KeySpec != types.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("unsupported KMS key spec: %s", resp.KeySpec)
+	}
+	return resp.PublicKey, nil
+}
+
+// Sign assina o digest com ECDSA_SHA_256 sem que o KMS aplique hash novamente
+func (c *AWSKMSClient) Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error) {
+	resp, err := c.api.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(keyID),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS Sign failed: %w", err)
+	}
+	return resp.Signature, nil
+}
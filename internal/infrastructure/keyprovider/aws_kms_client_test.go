@@ -0,0 +1,91 @@
+package keyprovider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKMSAPI mock do cliente KMS do SDK
+type MockKMSAPI struct {
+	mock.Mock
+}
+
+func (m *MockKMSAPI) GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kms.GetPublicKeyOutput), args.Error(1)
+}
+
+func (m *MockKMSAPI) Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*kms.SignOutput), args.Error(1)
+}
+
+func TestAWSKMSClientSign(t *testing.T) {
+	digest := make([]byte, 32)
+	digest[0] = 0xab
+
+	api := new(MockKMSAPI)
+	api.On("Sign", mock.Anything, mock.MatchedBy(func(in *kms.SignInput) bool {
+		return aws.ToString(in.KeyId) == "alias/signer" &&
+			in.MessageType == types.MessageTypeDigest &&
+			in.SigningAlgorithm == types.SigningAlgorithmSpecEcdsaSha256 &&
+			assert.ObjectsAreEqual(digest, in.Message)
+	})).Return(&kms.SignOutput{Signature: []byte{0x30, 0x00}}, nil)
+
+	sig, err := NewAWSKMSClient(api).Sign(context.Background(), "alias/signer", digest)
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x30, 0x00}, sig)
+	api.AssertExpectations(t)
+}
+
+func TestAWSKMSClientGetPublicKey(t *testing.T) {
+	t.Run("return secp256k1 keys", func(t *testing.T) {
+		api := new(MockKMSAPI)
+		api.On("GetPublicKey", mock.Anything, &kms.GetPublicKeyInput{KeyId: aws.String("alias/signer")}).
+			Return(&kms.GetPublicKeyOutput{PublicKey: []byte{0x01}, KeySpec: types.KeySpecEccSecgP256k1}, nil)
+
+		pub, err := NewAWSKMSClient(api).GetPublicKey(context.Background(), "alias/signer")
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, pub)
+	})
+
+	t.Run("reject non secp256k1 keys", func(t *testing.T) {
+		api := new(MockKMSAPI)
+		api.On("GetPublicKey", mock.Anything, mock.Anything).
+			Return(&kms.GetPublicKeyOutput{PublicKey: []byte{0x01}, KeySpec: types.KeySpecEccNistP256}, nil)
+
+		pub, err := NewAWSKMSClient(api).GetPublicKey(context.Background(), "alias/signer")
+
+		assert.Nil(t, pub)
+		assert.Error(t, err)
+	})
+
+	t.Run("surface service errors", func(t *testing.T) {
+		notFound := &types.NotFoundException{Message: aws.String("key not found")}
+		api := new(MockKMSAPI)
+		api.On("GetPublicKey", mock.Anything, mock.Anything).Return(nil, notFound)
+
+		pub, err := NewAWSKMSClient(api).GetPublicKey(context.Background(), "alias/missing")
+
+		assert.Nil(t, pub)
+		require.Error(t, err)
+		var target *types.NotFoundException
+		assert.True(t, errors.As(err, &target))
+	})
+}
@@ -0,0 +1,75 @@
+package keyprovider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrKeyNotFound nenhuma chave de assinatura configurada para o endereço
+var ErrKeyNotFound = errors.New("signing key not found for address")
+
+// KeyProvider resolve a chave de assinatura de um from_address
+type KeyProvider interface {
+	// Key retorna a chave capaz de assinar pelo endereço
+	Key(ctx context.Context, address common.Address) (SigningKey, error)
+	// Addresses lista os endereços que o provider consegue assinar
+	Addresses() []common.Address
+}
+
+// SigningKey assina hashes sem expor o material da chave
+type SigningKey interface {
+	Address() common.Address
+	// SignHash retorna a assinatura [R || S || V] (65 bytes, V = 0/1) do hash
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+}
+
+// SignTx assina uma transação com a chave informada
+func SignTx(ctx context.Context, key SigningKey, tx *types.Transaction, signer types.Signer) (*types.Transaction, error) {
+	hash := signer.Hash(tx)
+
+	sig, err := key.SignHash(ctx, hash.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction hash: %w", err)
+	}
+
+	signedTx, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach signature: %w", err)
+	}
+
+	return signedTx, nil
+}
+
+// localKey chave secp256k1 mantida em memória
+type localKey struct {
+	address    common.Address
+	privateKey *ecdsa.PrivateKey
+}
+
+func newLocalKey(privateKey *ecdsa.PrivateKey) *localKey {
+	return &localKey{
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: privateKey,
+	}
+}
+
+// Address retorna o endereço da chave
+func (k *localKey) Address() common.Address {
+	return k.address
+}
+
+// SignHash assina o hash localmente
+func (k *localKey) SignHash(_ context.Context, hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, k.privateKey)
+}
+
+// String evita que o material da chave apareça em logs
+func (k *localKey) String() string {
+	return fmt.Sprintf("localKey(%s)", k.address.Hex())
+}
@@ -0,0 +1,50 @@
+package keyprovider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Conta #0 do Hardhat/Anvil, usada apenas em testes
+	testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testAddress    = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
+
+func TestSignTx(t *testing.T) {
+	pk, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	key := newLocalKey(pk)
+
+	chainID := big.NewInt(11155111)
+	signer := types.NewEIP155Signer(chainID)
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+	tx := types.NewTransaction(3, to, big.NewInt(1000), 21000, big.NewInt(20000000000), nil)
+
+	signedTx, err := SignTx(context.Background(), key, tx, signer)
+	require.NoError(t, err)
+
+	sender, err := types.Sender(signer, signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(testAddress), sender)
+	assert.Equal(t, chainID, signedTx.ChainId())
+}
+
+func TestLocalKeyDoesNotLeakKey(t *testing.T) {
+	pk, err := crypto.HexToECDSA(testPrivateKey)
+	require.NoError(t, err)
+	key := newLocalKey(pk)
+
+	for _, format := range []string{"%v", "%+v", "%s"} {
+		assert.NotContains(t, fmt.Sprintf(format, key), testPrivateKey[:16])
+	}
+	assert.Contains(t, key.String(), testAddress)
+}
@@ -0,0 +1,141 @@
+package keyprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidPassphrase passphrase não decifra o arquivo de keystore
+var ErrInvalidPassphrase = keystore.ErrDecrypt
+
+// KeystoreKeyProvider lê chaves de um diretório de keystore criptografado do go-ethereum (Web3 Secret Storage v3)
+type KeystoreKeyProvider struct {
+	mu         sync.Mutex
+	files      map[common.Address]string
+	unlocked   map[common.Address]*localKey
+	passphrase string
+	logger     *zap.Logger
+}
+
+// keyFileHeader campos do arquivo v3 usados para indexar o keystore; a decifragem fica com keystore.DecryptKey
+type keyFileHeader struct {
+	Address string `json:"address"`
+	Version int    `json:"version"`
+}
+
+// NewKeystoreKeyProvider indexa os arquivos de keystore do diretório; as chaves são decifradas sob demanda
+func NewKeystoreKeyProvider(dir string, passphrase string, logger *zap.Logger) (*KeystoreKeyProvider, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore directory: %w", err)
+	}
+
+	provider := &KeystoreKeyProvider{
+		files:      make(map[common.Address]string),
+		unlocked:   make(map[common.Address]*localKey),
+		passphrase: passphrase,
+		logger:     logger,
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		// Ignorar diretórios, arquivos ocultos e temporários de editores
+		if entry.IsDir() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		_, header, err := readKeyFile(path)
+		if err != nil {
+			logger.Warn("skipping invalid keystore file", zap.String("file", name), zap.Error(err))
+			continue
+		}
+
+		address := common.HexToAddress(header.Address)
+		provider.files[address] = path
+	}
+
+	if len(provider.files) == 0 {
+		return nil, fmt.Errorf("no keystore files found in %s", dir)
+	}
+
+	logger.Info("keystore loaded", zap.Int("accounts", len(provider.files)))
+	return provider, nil
+}
+
+// Key decifra (uma única vez) e retorna a chave do endereço
+func (p *KeystoreKeyProvider) Key(_ context.Context, address common.Address) (SigningKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.unlocked[address]; ok {
+		return key, nil
+	}
+
+	path, ok := p.files[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, address.Hex())
+	}
+
+	keyJSON, _, err := readKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := keystore.DecryptKey(keyJSON, p.passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock %s: %w", address.Hex(), err)
+	}
+
+	key := newLocalKey(decrypted.PrivateKey)
+	if key.address != address {
+		return nil, fmt.Errorf("keystore file for %s contains key for a different address", address.Hex())
+	}
+
+	p.unlocked[address] = key
+	p.logger.Info("keystore account unlocked", zap.String("address", address.Hex()))
+	return key, nil
+}
+
+// Addresses lista os endereços com arquivo no keystore
+func (p *KeystoreKeyProvider) Addresses() []common.Address {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	addresses := make([]common.Address, 0, len(p.files))
+	for address := range p.files {
+		addresses = append(addresses, address)
+	}
+	sortAddresses(addresses)
+	return addresses
+}
+
+// readKeyFile lê o arquivo de keystore e valida o cabeçalho (versão 3 com endereço)
+func readKeyFile(path string) ([]byte, *keyFileHeader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var header keyFileHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse keystore file: %w", err)
+	}
+	if header.Version != 3 {
+		return nil, nil, fmt.Errorf("unsupported keystore version: %d", header.Version)
+	}
+	if !common.IsHexAddress(header.Address) {
+		return nil, nil, fmt.Errorf("keystore file has no address")
+	}
+
+	return data, &header, nil
+}
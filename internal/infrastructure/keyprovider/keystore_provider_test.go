@@ -0,0 +1,122 @@
+package keyprovider
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Arquivos de teste do go-ethereum (accounts/keystore/testdata)
+const (
+	testKeystoreAddress  = "0x7EF5A6135f1FD6a02593eEdC869c6D41D934aef8"
+	testKeystoreJSON     = `{"address":"7ef5a6135f1fd6a02593eedc869c6d41d934aef8","crypto":{"cipher":"aes-128-ctr","ciphertext":"1d0839166e7a15b9c1333fc865d69858b22df26815ccf601b28219b6192974e1","cipherparams":{"iv":"8df6caa7ff1b00c4e871f002cb7921ed"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":8,"p":16,"r":8,"salt":"e5e6ef3f4ea695f496b643ebd3f75c0aa58ef4070e90c80c5d3fb0241bf1595c"},"mac":"6d16dfde774845e4585357f24bce530528bc69f4f84e1e22880d34fa45c273e5"},"id":"950077c7-71e3-4c44-a4a1-143919141ed4","version":3}`
+	testKeystorePassword = "foobar"
+)
+
+func writeKeystore(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestNewKeystoreKeyProvider(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("index valid files and skip invalid ones", func(t *testing.T) {
+		dir := writeKeystore(t, map[string]string{
+			"UTC--2016-03-22T12-57-55.920751759Z--7ef5a6135f1fd6a02593eedc869c6d41d934aef8": testKeystoreJSON,
+			"garbage":     "not json",
+			".hidden":     testKeystoreJSON,
+			"no-address":  `{"crypto":{},"version":3}`,
+			"editor-tmp~": testKeystoreJSON,
+		})
+
+		provider, err := NewKeystoreKeyProvider(dir, testKeystorePassword, logger)
+
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{common.HexToAddress(testKeystoreAddress)}, provider.Addresses())
+	})
+
+	t.Run("fail on empty directory", func(t *testing.T) {
+		provider, err := NewKeystoreKeyProvider(t.TempDir(), testKeystorePassword, logger)
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail on missing directory", func(t *testing.T) {
+		provider, err := NewKeystoreKeyProvider(filepath.Join(t.TempDir(), "missing"), testKeystorePassword, logger)
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+}
+
+func TestKeystoreKeyProviderKey(t *testing.T) {
+	logger := zap.NewNop()
+	dir := writeKeystore(t, map[string]string{"key.json": testKeystoreJSON})
+	address := common.HexToAddress(testKeystoreAddress)
+
+	t.Run("unlock key with passphrase", func(t *testing.T) {
+		provider, err := NewKeystoreKeyProvider(dir, testKeystorePassword, logger)
+		require.NoError(t, err)
+
+		key, err := provider.Key(context.Background(), address)
+		require.NoError(t, err)
+		assert.Equal(t, address, key.Address())
+
+		// Segunda chamada usa a chave já decifrada
+		cached, err := provider.Key(context.Background(), address)
+		require.NoError(t, err)
+		assert.Same(t, key, cached)
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		provider, err := NewKeystoreKeyProvider(dir, "wrong", logger)
+		require.NoError(t, err)
+
+		key, err := provider.Key(context.Background(), address)
+
+		assert.Nil(t, key)
+		assert.ErrorIs(t, err, ErrInvalidPassphrase)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		provider, err := NewKeystoreKeyProvider(dir, testKeystorePassword, logger)
+		require.NoError(t, err)
+
+		key, err := provider.Key(context.Background(), common.HexToAddress("0x1234567890123456789012345678901234567890"))
+
+		assert.Nil(t, key)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestKeystoreKeyProviderKeyPBKDF2(t *testing.T) {
+	// Vetor de teste da especificação Web3 Secret Storage, com o endereço da chave
+	privateKey, err := crypto.HexToECDSA("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	keyJSON := `{"address":"` + hex.EncodeToString(address.Bytes()) + `","crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+
+	provider, err := NewKeystoreKeyProvider(writeKeystore(t, map[string]string{"key.json": keyJSON}), "testpassword", zap.NewNop())
+	require.NoError(t, err)
+
+	key, err := provider.Key(context.Background(), address)
+
+	require.NoError(t, err)
+	assert.Equal(t, address, key.Address())
+	assert.Equal(t, crypto.FromECDSA(privateKey), crypto.FromECDSA(key.(*localKey).privateKey))
+}
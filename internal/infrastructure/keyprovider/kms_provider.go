@@ -0,0 +1,177 @@
+package keyprovider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
+)
+
+// KMSClient assinatura remota com chaves secp256k1 (ECC_SECG_P256K1 no AWS KMS)
+type KMSClient interface {
+	// GetPublicKey retorna a chave pública em DER (SubjectPublicKeyInfo)
+	GetPublicKey(ctx context.Context, keyID string) ([]byte, error)
+	// Sign assina um digest de 32 bytes e retorna a assinatura ECDSA em DER
+	Sign(ctx context.Context, keyID string, digest []byte) ([]byte, error)
+}
+
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// subjectPublicKeyInfo estrutura DER da chave pública
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	PublicKey asn1.BitString
+}
+
+// ecdsaSignature estrutura DER da assinatura
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// KMSKeyProvider assina via KMS; o material da chave nunca sai do serviço
+type KMSKeyProvider struct {
+	keys   map[common.Address]*kmsKey
+	logger *zap.Logger
+}
+
+// NewKMSKeyProvider busca a chave pública de cada key ID e deriva o endereço correspondente
+func NewKMSKeyProvider(ctx context.Context, client KMSClient, keyIDs []string, logger *zap.Logger) (*KMSKeyProvider, error) {
+	provider := &KMSKeyProvider{
+		keys:   make(map[common.Address]*kmsKey),
+		logger: logger,
+	}
+
+	for _, keyID := range keyIDs {
+		if keyID == "" {
+			continue
+		}
+
+		der, err := client.GetPublicKey(ctx, keyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key for KMS key %s: %w", keyID, err)
+		}
+
+		pub, err := parseKMSPublicKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for KMS key %s: %w", keyID, err)
+		}
+
+		key := &kmsKey{
+			client:    client,
+			keyID:     keyID,
+			publicKey: pub,
+			address:   crypto.PubkeyToAddress(*pub),
+		}
+		provider.keys[key.address] = key
+
+		logger.Info("KMS signing key loaded",
+			zap.String("key_id", keyID),
+			zap.String("address", key.address.Hex()))
+	}
+
+	if len(provider.keys) == 0 {
+		return nil, fmt.Errorf("no KMS keys configured")
+	}
+
+	return provider, nil
+}
+
+// Key retorna a chave KMS do endereço
+func (p *KMSKeyProvider) Key(_ context.Context, address common.Address) (SigningKey, error) {
+	key, ok := p.keys[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, address.Hex())
+	}
+	return key, nil
+}
+
+// Addresses lista os endereços das chaves KMS
+func (p *KMSKeyProvider) Addresses() []common.Address {
+	addresses := make([]common.Address, 0, len(p.keys))
+	for address := range p.keys {
+		addresses = append(addresses, address)
+	}
+	sortAddresses(addresses)
+	return addresses
+}
+
+// kmsKey chave remota identificada pelo key ID
+type kmsKey struct {
+	client    KMSClient
+	keyID     string
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+}
+
+// Address retorna o endereço derivado da chave pública
+func (k *kmsKey) Address() common.Address {
+	return k.address
+}
+
+// SignHash assina no KMS e converte a assinatura DER para o formato [R || S || V]
+func (k *kmsKey) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	der, err := k.client.Sign(ctx, k.keyID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("KMS sign failed: %w", err)
+	}
+
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("invalid KMS signature: %w", err)
+	}
+	if sig.R == nil || sig.S == nil || sig.R.Sign() <= 0 || sig.S.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid KMS signature values")
+	}
+
+	// EIP-2: apenas assinaturas com S na metade inferior da curva são aceitas
+	s := sig.S
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+
+	rs := make([]byte, 64)
+	sig.R.FillBytes(rs[:32])
+	s.FillBytes(rs[32:])
+
+	// KMS não retorna o recovery id: testar V = 0 e V = 1
+	expected := crypto.FromECDSAPub(k.publicKey)
+	for _, v := range []byte{0, 1} {
+		candidate := append(append([]byte{}, rs...), v)
+		recovered, err := crypto.Ecrecover(hash, candidate)
+		if err == nil && string(recovered) == string(expected) {
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not recover signer address from KMS signature")
+}
+
+// String evita que detalhes da chave apareçam em logs
+func (k *kmsKey) String() string {
+	return fmt.Sprintf("kmsKey(%s)", k.address.Hex())
+}
+
+func parseKMSPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidECPublicKey) || !info.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("key is not secp256k1")
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.RightAlign())
+}
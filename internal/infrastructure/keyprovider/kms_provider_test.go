@@ -0,0 +1,111 @@
+package keyprovider
+
+import (
+	"context"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type failingKMSClient struct{}
+
+func (failingKMSClient) GetPublicKey(context.Context, string) ([]byte, error) {
+	return nil, errors.New("access denied")
+}
+
+func (failingKMSClient) Sign(context.Context, string, []byte) ([]byte, error) {
+	return nil, errors.New("access denied")
+}
+
+func TestNewKMSKeyProvider(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("derive address from public key", func(t *testing.T) {
+		pk, err := crypto.HexToECDSA(testPrivateKey)
+		require.NoError(t, err)
+		kms := NewLocalKMSClient()
+		kms.ImportKey("alias/signer", pk)
+
+		provider, err := NewKMSKeyProvider(context.Background(), kms, []string{"alias/signer"}, logger)
+
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{common.HexToAddress(testAddress)}, provider.Addresses())
+	})
+
+	t.Run("fail when public key cannot be fetched", func(t *testing.T) {
+		provider, err := NewKMSKeyProvider(context.Background(), failingKMSClient{}, []string{"alias/signer"}, logger)
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+
+	t.Run("fail without key IDs", func(t *testing.T) {
+		provider, err := NewKMSKeyProvider(context.Background(), NewLocalKMSClient(), nil, logger)
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+}
+
+func TestKMSKeySignTx(t *testing.T) {
+	kms := NewLocalKMSClient()
+	require.NoError(t, kms.CreateKey("alias/signer"))
+
+	provider, err := NewKMSKeyProvider(context.Background(), kms, []string{"alias/signer"}, zap.NewNop())
+	require.NoError(t, err)
+	address := provider.Addresses()[0]
+
+	key, err := provider.Key(context.Background(), address)
+	require.NoError(t, err)
+
+	signer := types.NewEIP155Signer(big.NewInt(137))
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+
+	// Nonces diferentes geram hashes com S nas duas metades da curva
+	for nonce := uint64(0); nonce < 8; nonce++ {
+		tx := types.NewTransaction(nonce, to, big.NewInt(1000), 21000, big.NewInt(20000000000), nil)
+
+		signedTx, err := SignTx(context.Background(), key, tx, signer)
+		require.NoError(t, err)
+
+		sender, err := types.Sender(signer, signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, address, sender)
+
+		_, _, s := signedTx.RawSignatureValues()
+		assert.LessOrEqual(t, s.Cmp(secp256k1HalfN), 0)
+	}
+}
+
+func TestKMSKeyProviderUnknownAddress(t *testing.T) {
+	kms := NewLocalKMSClient()
+	require.NoError(t, kms.CreateKey("alias/signer"))
+	provider, err := NewKMSKeyProvider(context.Background(), kms, []string{"alias/signer"}, zap.NewNop())
+	require.NoError(t, err)
+
+	key, err := provider.Key(context.Background(), common.HexToAddress("0x1234567890123456789012345678901234567890"))
+
+	assert.Nil(t, key)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestParseKMSPublicKeyRejectsOtherCurves(t *testing.T) {
+	var info subjectPublicKeyInfo
+	info.Algorithm.Algorithm = oidECPublicKey
+	info.Algorithm.Parameters = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7} // P-256
+	der, err := asn1.Marshal(info)
+	require.NoError(t, err)
+
+	pub, err := parseKMSPublicKey(der)
+
+	assert.Nil(t, pub)
+	assert.Error(t, err)
+}
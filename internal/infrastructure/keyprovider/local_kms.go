@@ -0,0 +1,94 @@
+package keyprovider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LocalKMSClient implementação em memória do KMSClient para testes e desenvolvimento local
+type LocalKMSClient struct {
+	mu   sync.RWMutex
+	keys map[string]*ecdsa.PrivateKey
+}
+
+// NewLocalKMSClient cria um KMS local vazio
+func NewLocalKMSClient() *LocalKMSClient {
+	return &LocalKMSClient{keys: make(map[string]*ecdsa.PrivateKey)}
+}
+
+// CreateKey gera uma nova chave secp256k1 com o key ID informado
+func (c *LocalKMSClient) CreateKey(keyID string) error {
+	pk, err := crypto.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("failed to generate key: %w", err)
+	}
+	c.ImportKey(keyID, pk)
+	return nil
+}
+
+// ImportKey registra uma chave existente com o key ID informado
+func (c *LocalKMSClient) ImportKey(keyID string, pk *ecdsa.PrivateKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keys[keyID] = pk
+}
+
+// GetPublicKey retorna a chave pública em DER, como o AWS KMS
+func (c *LocalKMSClient) GetPublicKey(_ context.Context, keyID string) ([]byte, error) {
+	pk, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var info subjectPublicKeyInfo
+	info.Algorithm.Algorithm = oidECPublicKey
+	info.Algorithm.Parameters = oidSecp256k1
+	pub := crypto.FromECDSAPub(&pk.PublicKey)
+	info.PublicKey = asn1.BitString{Bytes: pub, BitLength: len(pub) * 8}
+
+	return asn1.Marshal(info)
+}
+
+// Sign assina o digest e retorna a assinatura em DER, sem recovery id
+func (c *LocalKMSClient) Sign(_ context.Context, keyID string, digest []byte) ([]byte, error) {
+	pk, err := c.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("digest must be 32 bytes")
+	}
+
+	sig, err := crypto.Sign(digest, pk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign digest: %w", err)
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+
+	// Assim como o AWS KMS, S pode vir na metade superior da curva
+	if digest[0]&1 == 1 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+
+	return asn1.Marshal(ecdsaSignature{R: r, S: s})
+}
+
+func (c *LocalKMSClient) key(keyID string) (*ecdsa.PrivateKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pk, ok := c.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("KMS key not found: %s", keyID)
+	}
+	return pk, nil
+}
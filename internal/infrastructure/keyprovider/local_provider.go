@@ -0,0 +1,112 @@
+package keyprovider
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LocalKeyProvider mantém chaves privadas em memória, carregadas do ambiente ou de um arquivo de segredo
+type LocalKeyProvider struct {
+	keys map[common.Address]*localKey
+}
+
+// NewLocalKeyProvider cria um provider a partir de chaves privadas em hex
+func NewLocalKeyProvider(hexKeys []string) (*LocalKeyProvider, error) {
+	provider := &LocalKeyProvider{keys: make(map[common.Address]*localKey)}
+
+	for i, hexKey := range hexKeys {
+		hexKey = strings.TrimSpace(hexKey)
+		if hexKey == "" {
+			continue
+		}
+
+		pk, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+		if err != nil {
+			// Não incluir o valor da chave na mensagem de erro
+			return nil, fmt.Errorf("invalid private key at position %d", i)
+		}
+
+		key := newLocalKey(pk)
+		provider.keys[key.address] = key
+	}
+
+	if len(provider.keys) == 0 {
+		return nil, fmt.Errorf("no private keys configured")
+	}
+
+	return provider, nil
+}
+
+// NewEnvKeyProvider carrega chaves separadas por vírgula de uma variável de ambiente
+func NewEnvKeyProvider(envVar string) (*LocalKeyProvider, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", envVar)
+	}
+
+	provider, err := NewLocalKeyProvider(strings.Split(value, ","))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys from %s: %w", envVar, err)
+	}
+	return provider, nil
+}
+
+// NewSecretFileKeyProvider carrega uma chave por linha de um arquivo de segredo (ex: secret montado)
+func NewSecretFileKeyProvider(path string) (*LocalKeyProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secret file: %w", err)
+	}
+	defer file.Close()
+
+	var hexKeys []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hexKeys = append(hexKeys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	provider, err := NewLocalKeyProvider(hexKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys from secret file: %w", err)
+	}
+	return provider, nil
+}
+
+// Key retorna a chave do endereço
+func (p *LocalKeyProvider) Key(_ context.Context, address common.Address) (SigningKey, error) {
+	key, ok := p.keys[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, address.Hex())
+	}
+	return key, nil
+}
+
+// Addresses lista os endereços configurados
+func (p *LocalKeyProvider) Addresses() []common.Address {
+	addresses := make([]common.Address, 0, len(p.keys))
+	for address := range p.keys {
+		addresses = append(addresses, address)
+	}
+	sortAddresses(addresses)
+	return addresses
+}
+
+func sortAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Cmp(addresses[j]) < 0
+	})
+}
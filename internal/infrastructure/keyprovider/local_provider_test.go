@@ -0,0 +1,99 @@
+package keyprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocalKeyProvider(t *testing.T) {
+	t.Run("load keys with and without prefix", func(t *testing.T) {
+		provider, err := NewLocalKeyProvider([]string{
+			"0x" + testPrivateKey,
+			"59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, provider.Addresses(), 2)
+		assert.Contains(t, provider.Addresses(), common.HexToAddress(testAddress))
+	})
+
+	t.Run("invalid key does not appear in error", func(t *testing.T) {
+		invalidKey := "deadbeefdeadbeef"
+
+		provider, err := NewLocalKeyProvider([]string{invalidKey})
+
+		assert.Nil(t, provider)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), invalidKey)
+	})
+
+	t.Run("fail without keys", func(t *testing.T) {
+		provider, err := NewLocalKeyProvider([]string{"", "  "})
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+}
+
+func TestLocalKeyProviderKey(t *testing.T) {
+	provider, err := NewLocalKeyProvider([]string{testPrivateKey})
+	require.NoError(t, err)
+
+	t.Run("resolve configured address", func(t *testing.T) {
+		key, err := provider.Key(context.Background(), common.HexToAddress(testAddress))
+
+		require.NoError(t, err)
+		assert.Equal(t, common.HexToAddress(testAddress), key.Address())
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		key, err := provider.Key(context.Background(), common.HexToAddress("0x1234567890123456789012345678901234567890"))
+
+		assert.Nil(t, key)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
+
+func TestNewEnvKeyProvider(t *testing.T) {
+	t.Run("load comma separated keys", func(t *testing.T) {
+		t.Setenv("TEST_SIGNER_KEYS", testPrivateKey+", 59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+
+		provider, err := NewEnvKeyProvider("TEST_SIGNER_KEYS")
+
+		require.NoError(t, err)
+		assert.Len(t, provider.Addresses(), 2)
+	})
+
+	t.Run("fail when variable is not set", func(t *testing.T) {
+		provider, err := NewEnvKeyProvider("TEST_SIGNER_KEYS_UNSET")
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewSecretFileKeyProvider(t *testing.T) {
+	t.Run("load keys ignoring comments and blank lines", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "signer-keys")
+		content := "# signer keys\n\n0x" + testPrivateKey + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		provider, err := NewSecretFileKeyProvider(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{common.HexToAddress(testAddress)}, provider.Addresses())
+	})
+
+	t.Run("fail when file does not exist", func(t *testing.T) {
+		provider, err := NewSecretFileKeyProvider(filepath.Join(t.TempDir(), "missing"))
+
+		assert.Nil(t, provider)
+		assert.Error(t, err)
+	})
+}
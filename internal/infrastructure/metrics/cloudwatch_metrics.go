@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 
 	"go.uber.org/zap"
@@ -15,12 +16,29 @@ type Metrics struct {
 	transactionFailed  int64
 	rpcCallCount       int64
 	rpcCallErrors      int64
+
+	circuitBreakerRejections int64
+	circuitBreakerMu         sync.RWMutex
+	circuitBreakerStates     map[string]string
+
+	cacheMu     sync.Mutex
+	cacheHits   map[string]int64
+	cacheMisses map[string]int64
+
+	retryMu       sync.Mutex
+	retryAttempts map[string]int64
+	deadLetters   map[string]int64
 }
 
 // NewMetrics cria uma nova instância de Metrics
 func NewMetrics(logger *zap.Logger) *Metrics {
 	return &Metrics{
-		logger: logger,
+		logger:               logger,
+		circuitBreakerStates: make(map[string]string),
+		cacheHits:            make(map[string]int64),
+		cacheMisses:          make(map[string]int64),
+		retryAttempts:        make(map[string]int64),
+		deadLetters:          make(map[string]int64),
 	}
 }
 
@@ -49,15 +67,105 @@ func (m *Metrics) IncrementRPCCallError() {
 	atomic.AddInt64(&m.rpcCallErrors, 1)
 }
 
+// SetCircuitBreakerState registra o estado atual do circuit breaker do endpoint
+func (m *Metrics) SetCircuitBreakerState(name string, state string) {
+	m.circuitBreakerMu.Lock()
+	defer m.circuitBreakerMu.Unlock()
+
+	m.circuitBreakerStates[name] = state
+}
+
+// IncrementCircuitBreakerRejection incrementa o contador de chamadas rejeitadas por circuit breakers
+func (m *Metrics) IncrementCircuitBreakerRejection(name string) {
+	atomic.AddInt64(&m.circuitBreakerRejections, 1)
+}
+
+// CircuitBreakerStates retorna uma cópia do estado de cada circuit breaker
+func (m *Metrics) CircuitBreakerStates() map[string]string {
+	m.circuitBreakerMu.RLock()
+	defer m.circuitBreakerMu.RUnlock()
+
+	states := make(map[string]string, len(m.circuitBreakerStates))
+	for name, state := range m.circuitBreakerStates {
+		states[name] = state
+	}
+	return states
+}
+
+// IncrementCacheHit conta uma leitura RPC atendida pelo cache
+func (m *Metrics) IncrementCacheHit(method string) {
+	m.cacheMu.Lock()
+	defer m.cacheMu.Unlock()
+	m.cacheHits[method]++
+}
+
+// IncrementCacheMiss conta uma leitura RPC que foi ao nó
+func (m *Metrics) IncrementCacheMiss(method string) {
+	m.cacheMu.Lock()
+	defer m.cacheMu.Unlock()
+	m.cacheMisses[method]++
+}
+
+// IncrementRetryAttempt conta uma nova tentativa agendada após uma falha da categoria
+func (m *Metrics) IncrementRetryAttempt(category string) {
+	m.retryMu.Lock()
+	defer m.retryMu.Unlock()
+	m.retryAttempts[category]++
+}
+
+// IncrementDeadLetter conta uma mensagem enviada para a DLQ após uma falha da categoria
+func (m *Metrics) IncrementDeadLetter(category string) {
+	m.retryMu.Lock()
+	defer m.retryMu.Unlock()
+	m.deadLetters[category]++
+}
+
 // GetStats retorna as estatísticas atuais
 func (m *Metrics) GetStats(ctx context.Context) map[string]int64 {
-	return map[string]int64{
+	openBreakers := int64(0)
+	for _, state := range m.CircuitBreakerStates() {
+		if state == "OPEN" {
+			openBreakers++
+		}
+	}
+
+	stats := map[string]int64{
 		"transaction_count":   atomic.LoadInt64(&m.transactionCount),
 		"transaction_success": atomic.LoadInt64(&m.transactionSuccess),
 		"transaction_failed":  atomic.LoadInt64(&m.transactionFailed),
 		"rpc_call_count":      atomic.LoadInt64(&m.rpcCallCount),
 		"rpc_call_errors":     atomic.LoadInt64(&m.rpcCallErrors),
+
+		"circuit_breaker_rejections": atomic.LoadInt64(&m.circuitBreakerRejections),
+		"circuit_breakers_open":      openBreakers,
+	}
+
+	// Hits e misses do cache RPC, no total e por método
+	m.cacheMu.Lock()
+	stats["rpc_cache_hits"], stats["rpc_cache_misses"] = 0, 0
+	for method, hits := range m.cacheHits {
+		stats["rpc_cache_hits"] += hits
+		stats["rpc_cache_hits."+method] = hits
+	}
+	for method, misses := range m.cacheMisses {
+		stats["rpc_cache_misses"] += misses
+		stats["rpc_cache_misses."+method] = misses
+	}
+	m.cacheMu.Unlock()
+
+	// Novas tentativas e mensagens na DLQ, no total e por categoria de erro
+	m.retryMu.Lock()
+	defer m.retryMu.Unlock()
+	stats["retry_attempts"], stats["dead_letters"] = 0, 0
+	for category, attempts := range m.retryAttempts {
+		stats["retry_attempts"] += attempts
+		stats["retry_attempts."+category] = attempts
+	}
+	for category, deadLetters := range m.deadLetters {
+		stats["dead_letters"] += deadLetters
+		stats["dead_letters."+category] = deadLetters
 	}
+	return stats
 }
 
 // Reset reseta todas as métricas
@@ -67,5 +175,14 @@ func (m *Metrics) Reset() {
 	atomic.StoreInt64(&m.transactionFailed, 0)
 	atomic.StoreInt64(&m.rpcCallCount, 0)
 	atomic.StoreInt64(&m.rpcCallErrors, 0)
+	atomic.StoreInt64(&m.circuitBreakerRejections, 0)
+	m.cacheMu.Lock()
+	m.cacheHits = make(map[string]int64)
+	m.cacheMisses = make(map[string]int64)
+	m.cacheMu.Unlock()
+	m.retryMu.Lock()
+	m.retryAttempts = make(map[string]int64)
+	m.deadLetters = make(map[string]int64)
+	m.retryMu.Unlock()
 	m.logger.Info("metrics reset")
 }
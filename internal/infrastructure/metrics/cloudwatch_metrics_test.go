@@ -79,3 +79,61 @@ func TestIncrementTransactionFailed(t *testing.T) {
 	stats = m.GetStats(context.Background())
 	assert.Equal(t, int64(3), stats["transaction_failed"])
 }
+
+func TestCircuitBreakerMetrics(t *testing.T) {
+	m := NewMetrics(zap.NewNop())
+
+	m.SetCircuitBreakerState("ETHEREUM/rpc.example.com", "CLOSED")
+	m.SetCircuitBreakerState("POLYGON/rpc.example.com", "CLOSED")
+	m.SetCircuitBreakerState("ETHEREUM/rpc.example.com", "OPEN")
+	m.IncrementCircuitBreakerRejection("ETHEREUM/rpc.example.com")
+	m.IncrementCircuitBreakerRejection("ETHEREUM/rpc.example.com")
+
+	assert.Equal(t, map[string]string{
+		"ETHEREUM/rpc.example.com": "OPEN",
+		"POLYGON/rpc.example.com":  "CLOSED",
+	}, m.CircuitBreakerStates())
+
+	stats := m.GetStats(context.Background())
+	assert.Equal(t, int64(2), stats["circuit_breaker_rejections"])
+	assert.Equal(t, int64(1), stats["circuit_breakers_open"])
+
+	m.Reset()
+	assert.Equal(t, int64(0), m.GetStats(context.Background())["circuit_breaker_rejections"])
+}
+
+func TestCacheMetrics(t *testing.T) {
+	m := NewMetrics(zap.NewNop())
+
+	m.IncrementCacheHit("GetChainID")
+	m.IncrementCacheHit("GetChainID")
+	m.IncrementCacheHit("GetGasPrice")
+	m.IncrementCacheMiss("GetGasPrice")
+
+	stats := m.GetStats(context.Background())
+	assert.Equal(t, int64(3), stats["rpc_cache_hits"])
+	assert.Equal(t, int64(1), stats["rpc_cache_misses"])
+	assert.Equal(t, int64(2), stats["rpc_cache_hits.GetChainID"])
+	assert.Equal(t, int64(1), stats["rpc_cache_misses.GetGasPrice"])
+
+	m.Reset()
+	assert.Equal(t, int64(0), m.GetStats(context.Background())["rpc_cache_hits"])
+}
+
+func TestRetryMetrics(t *testing.T) {
+	m := NewMetrics(zap.NewNop())
+
+	m.IncrementRetryAttempt("RPC_TRANSIENT")
+	m.IncrementRetryAttempt("RPC_TRANSIENT")
+	m.IncrementRetryAttempt("NONCE_CONFLICT")
+	m.IncrementDeadLetter("RPC_PERMANENT")
+
+	stats := m.GetStats(context.Background())
+	assert.Equal(t, int64(3), stats["retry_attempts"])
+	assert.Equal(t, int64(2), stats["retry_attempts.RPC_TRANSIENT"])
+	assert.Equal(t, int64(1), stats["dead_letters"])
+	assert.Equal(t, int64(1), stats["dead_letters.RPC_PERMANENT"])
+
+	m.Reset()
+	assert.Equal(t, int64(0), m.GetStats(context.Background())["retry_attempts"])
+}
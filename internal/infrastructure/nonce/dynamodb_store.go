@@ -0,0 +1,115 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/database"
+	"go.uber.org/zap"
+)
+
+// nonceItem estrutura do estado de nonce no DynamoDB (partition key "nonce_key")
+type nonceItem struct {
+	NonceKey  string   `dynamodbav:"nonce_key"`
+	Next      uint64   `dynamodbav:"next_nonce"`
+	Released  []uint64 `dynamodbav:"released_nonces,omitempty"`
+	Version   int64    `dynamodbav:"version"`
+	UpdatedAt string   `dynamodbav:"updated_at"`
+}
+
+// DynamoDBStore Store com escrita condicional no DynamoDB, compartilhado entre invocações
+type DynamoDBStore struct {
+	dynamoDBClient database.DynamoDBClient
+	tableName      string
+	logger         *zap.Logger
+}
+
+// NewDynamoDBStore cria um Store de nonces na tabela informada
+func NewDynamoDBStore(dynamoDBClient database.DynamoDBClient, tableName string, logger *zap.Logger) *DynamoDBStore {
+	return &DynamoDBStore{
+		dynamoDBClient: dynamoDBClient,
+		tableName:      tableName,
+		logger:         logger,
+	}
+}
+
+// Get lê o estado com leitura consistente
+func (s *DynamoDBStore) Get(ctx context.Context, key string) (*State, error) {
+	result, err := s.dynamoDBClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: &s.tableName,
+		Key: map[string]types.AttributeValue{
+			"nonce_key": &types.AttributeValueMemberS{Value: key},
+		},
+		ConsistentRead: boolPtr(true),
+	})
+	if err != nil {
+		s.logger.Error("failed to get nonce state", zap.String("nonce_key", key), zap.Error(err))
+		return nil, fmt.Errorf("failed to get nonce state: %w", err)
+	}
+	if result.Item == nil {
+		return nil, nil
+	}
+
+	var item nonceItem
+	if err := attributevalue.UnmarshalMap(result.Item, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal nonce state: %w", err)
+	}
+
+	return &State{
+		Next:     item.Next,
+		Released: item.Released,
+		Version:  item.Version,
+	}, nil
+}
+
+// CompareAndSwap grava o estado com a condição de versão; item inexistente exige expectedVersion 0
+func (s *DynamoDBStore) CompareAndSwap(ctx context.Context, key string, expectedVersion int64, state *State) error {
+	av, err := attributevalue.MarshalMap(nonceItem{
+		NonceKey:  key,
+		Next:      state.Next,
+		Released:  state.Released,
+		Version:   state.Version,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal nonce state: %w", err)
+	}
+
+	input := &dynamodb.PutItemInput{
+		TableName: &s.tableName,
+		Item:      av,
+	}
+	if expectedVersion == 0 {
+		input.ConditionExpression = stringPtr("attribute_not_exists(nonce_key)")
+	} else {
+		input.ConditionExpression = stringPtr("version = :expected_version")
+		input.ExpressionAttributeValues = map[string]types.AttributeValue{
+			":expected_version": &types.AttributeValueMemberN{Value: strconv.FormatInt(expectedVersion, 10)},
+		}
+	}
+
+	if _, err := s.dynamoDBClient.PutItem(ctx, input); err != nil {
+		var conditionErr *types.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return ErrConflict
+		}
+		s.logger.Error("failed to save nonce state", zap.String("nonce_key", key), zap.Error(err))
+		return fmt.Errorf("failed to save nonce state: %w", err)
+	}
+
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
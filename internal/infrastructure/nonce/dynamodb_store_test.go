@@ -0,0 +1,165 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockDynamoDBClient mock do cliente DynamoDB
+type MockDynamoDBClient struct {
+	mock.Mock
+}
+
+func (m *MockDynamoDBClient) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.PutItemOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.GetItemOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.UpdateItemOutput), args.Error(1)
+}
+
+func (m *MockDynamoDBClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dynamodb.QueryOutput), args.Error(1)
+}
+
+func TestDynamoDBStore_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decode stored state", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+
+		client.On("GetItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.GetItemInput) bool {
+			key := input.Key["nonce_key"].(*types.AttributeValueMemberS)
+			return *input.TableName == "nonces" && key.Value == "ETHEREUM#0xabc" && *input.ConsistentRead
+		})).Return(&dynamodb.GetItemOutput{Item: map[string]types.AttributeValue{
+			"nonce_key":       &types.AttributeValueMemberS{Value: "ETHEREUM#0xabc"},
+			"next_nonce":      &types.AttributeValueMemberN{Value: "7"},
+			"released_nonces": &types.AttributeValueMemberL{Value: []types.AttributeValue{&types.AttributeValueMemberN{Value: "4"}}},
+			"version":         &types.AttributeValueMemberN{Value: "3"},
+		}}, nil)
+
+		state, err := store.Get(context.Background(), "ETHEREUM#0xabc")
+
+		require.NoError(t, err)
+		assert.Equal(t, &State{Next: 7, Released: []uint64{4}, Version: 3}, state)
+	})
+
+	t.Run("missing item returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("GetItem", mock.Anything, mock.Anything).Return(&dynamodb.GetItemOutput{}, nil)
+
+		state, err := store.Get(context.Background(), "ETHEREUM#0xabc")
+
+		assert.NoError(t, err)
+		assert.Nil(t, state)
+	})
+
+	t.Run("propagate client error", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("GetItem", mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+
+		_, err := store.Get(context.Background(), "ETHEREUM#0xabc")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDynamoDBStore_CompareAndSwap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create item only if absent", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			return *input.ConditionExpression == "attribute_not_exists(nonce_key)"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		err := store.CompareAndSwap(context.Background(), "ETHEREUM#0xabc", 0, &State{Next: 1, Version: 1})
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("update item only on expected version", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("PutItem", mock.Anything, mock.MatchedBy(func(input *dynamodb.PutItemInput) bool {
+			expected := input.ExpressionAttributeValues[":expected_version"].(*types.AttributeValueMemberN)
+			version := input.Item["version"].(*types.AttributeValueMemberN)
+			return *input.ConditionExpression == "version = :expected_version" &&
+				expected.Value == "4" && version.Value == "5"
+		})).Return(&dynamodb.PutItemOutput{}, nil)
+
+		err := store.CompareAndSwap(context.Background(), "ETHEREUM#0xabc", 4, &State{Next: 9, Version: 5})
+
+		assert.NoError(t, err)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("map failed condition to conflict", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("PutItem", mock.Anything, mock.Anything).
+			Return(nil, &types.ConditionalCheckFailedException{Message: stringPtr("condition failed")})
+
+		err := store.CompareAndSwap(context.Background(), "ETHEREUM#0xabc", 4, &State{Next: 9, Version: 5})
+
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("propagate client error", func(t *testing.T) {
+		t.Parallel()
+
+		client := new(MockDynamoDBClient)
+		store := NewDynamoDBStore(client, "nonces", zap.NewNop())
+		client.On("PutItem", mock.Anything, mock.Anything).Return(nil, errors.New("throttled"))
+
+		err := store.CompareAndSwap(context.Background(), "ETHEREUM#0xabc", 4, &State{Next: 9, Version: 5})
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrConflict)
+	})
+}
@@ -0,0 +1,45 @@
+package nonce
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryStore Store em memória; coordena apenas goroutines do mesmo processo
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// NewMemoryStore cria um Store em memória vazio
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string]State)}
+}
+
+// Get retorna uma cópia do estado do endereço
+func (s *MemoryStore) Get(_ context.Context, key string) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[key]
+	if !ok {
+		return nil, nil
+	}
+	state.Released = append([]uint64(nil), state.Released...)
+	return &state, nil
+}
+
+// CompareAndSwap grava o estado se a versão atual for expectedVersion
+func (s *MemoryStore) CompareAndSwap(_ context.Context, key string, expectedVersion int64, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states[key].Version != expectedVersion {
+		return ErrConflict
+	}
+
+	stored := *state
+	stored.Released = append([]uint64(nil), state.Released...)
+	s.states[key] = stored
+	return nil
+}
@@ -0,0 +1,52 @@
+package nonce
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing key returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := NewMemoryStore().Get(context.Background(), "ETHEREUM#0xabc")
+
+		assert.NoError(t, err)
+		assert.Nil(t, state)
+	})
+
+	t.Run("compare and swap checks version", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		ctx := context.Background()
+
+		require.NoError(t, store.CompareAndSwap(ctx, "k", 0, &State{Next: 1, Version: 1}))
+		assert.ErrorIs(t, store.CompareAndSwap(ctx, "k", 0, &State{Next: 2, Version: 1}), ErrConflict)
+		require.NoError(t, store.CompareAndSwap(ctx, "k", 1, &State{Next: 2, Version: 2}))
+
+		state, err := store.Get(ctx, "k")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), state.Next)
+		assert.Equal(t, int64(2), state.Version)
+	})
+
+	t.Run("returned state is a copy", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		ctx := context.Background()
+		require.NoError(t, store.CompareAndSwap(ctx, "k", 0, &State{Released: []uint64{3}, Version: 1}))
+
+		state, _ := store.Get(ctx, "k")
+		state.Released[0] = 99
+
+		stored, _ := store.Get(ctx, "k")
+		assert.Equal(t, []uint64{3}, stored.Released)
+	})
+}
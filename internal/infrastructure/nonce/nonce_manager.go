@@ -0,0 +1,217 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrConflict o estado foi alterado por outra invocação entre a leitura e a escrita
+	ErrConflict = errors.New("nonce state modified concurrently")
+	// ErrTooManyConflicts não foi possível reservar o nonce após várias tentativas
+	ErrTooManyConflicts = errors.New("too many concurrent nonce reservations")
+)
+
+// maxAttempts tentativas de escrita condicional antes de desistir
+const maxAttempts = 10
+
+// Manager reserva nonces por (chain, endereço) para envios concorrentes do mesmo remetente
+type Manager interface {
+	// Acquire reserva o próximo nonce do endereço
+	Acquire(ctx context.Context, chain string, address string, source Source) (uint64, error)
+	// Release devolve um nonce reservado cuja transação não foi enviada
+	Release(ctx context.Context, chain string, address string, nonce uint64) error
+	// Resync realinha o contador com o nonce pendente do node (ex.: após "nonce too low")
+	Resync(ctx context.Context, chain string, address string, source Source) error
+}
+
+// Source fonte do nonce pendente do endereço (implementado por rpc.RPCClient)
+type Source interface {
+	GetNonce(ctx context.Context, address string) (uint64, error)
+}
+
+// State estado persistido de um endereço
+type State struct {
+	// Next próximo nonce nunca reservado
+	Next uint64
+	// Released nonces devolvidos, reutilizados antes de Next para não deixar lacunas
+	Released []uint64
+	// Version versão usada na escrita condicional (0 = ainda não persistido)
+	Version int64
+}
+
+// Store persistência do estado com escrita condicional
+type Store interface {
+	// Get retorna o estado do endereço (nil se não existir)
+	Get(ctx context.Context, key string) (*State, error)
+	// CompareAndSwap grava o estado se a versão persistida for expectedVersion; senão retorna ErrConflict
+	CompareAndSwap(ctx context.Context, key string, expectedVersion int64, state *State) error
+}
+
+// StoreManager implementação do Manager sobre um Store com controle otimista de concorrência
+type StoreManager struct {
+	store  Store
+	logger *zap.Logger
+}
+
+// NewManager cria um gerenciador de nonces sobre o Store informado
+func NewManager(store Store, logger *zap.Logger) *StoreManager {
+	return &StoreManager{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// NewInMemoryManager cria um gerenciador de nonces em memória (testes e execução local)
+func NewInMemoryManager(logger *zap.Logger) *StoreManager {
+	return NewManager(NewMemoryStore(), logger)
+}
+
+// Acquire reserva o menor nonce devolvido ou o próximo do contador, nunca abaixo do pendente no node
+func (m *StoreManager) Acquire(ctx context.Context, chain string, address string, source Source) (uint64, error) {
+	pending, err := source.GetNonce(ctx, address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	var reserved uint64
+	err = m.update(ctx, chain, address, func(state *State) {
+		state.sync(pending)
+		if len(state.Released) > 0 {
+			reserved = state.Released[0]
+			state.Released = state.Released[1:]
+			return
+		}
+		reserved = state.Next
+		state.Next++
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	m.logger.Debug("nonce reserved",
+		zap.String("chain", chain),
+		zap.String("address", address),
+		zap.Uint64("nonce", reserved),
+		zap.Uint64("pending_nonce", pending))
+	return reserved, nil
+}
+
+// Release devolve o nonce; se for o último reservado, o contador volta, senão vira lacuna a ser preenchida
+func (m *StoreManager) Release(ctx context.Context, chain string, address string, nonce uint64) error {
+	err := m.update(ctx, chain, address, func(state *State) {
+		if nonce >= state.Next {
+			return
+		}
+		if nonce == state.Next-1 {
+			state.Next--
+			return
+		}
+		for _, released := range state.Released {
+			if released == nonce {
+				return
+			}
+		}
+		state.Released = append(state.Released, nonce)
+		sort.Slice(state.Released, func(i, j int) bool { return state.Released[i] < state.Released[j] })
+	})
+	if err != nil {
+		return err
+	}
+
+	m.logger.Info("nonce released",
+		zap.String("chain", chain),
+		zap.String("address", address),
+		zap.Uint64("nonce", nonce))
+	return nil
+}
+
+// Resync avança o contador até o nonce pendente do node e descarta as lacunas já usadas na chain; nonces
+// acima do pendente continuam reservados, pois podem pertencer a envios concorrentes ainda não vistos pelo node
+func (m *StoreManager) Resync(ctx context.Context, chain string, address string, source Source) error {
+	pending, err := source.GetNonce(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	err = m.update(ctx, chain, address, func(state *State) {
+		state.sync(pending)
+	})
+	if err != nil {
+		return err
+	}
+
+	m.logger.Warn("nonce resynced with node",
+		zap.String("chain", chain),
+		zap.String("address", address),
+		zap.Uint64("pending_nonce", pending))
+	return nil
+}
+
+// update aplica a mudança com escrita condicional, repetindo em caso de conflito
+func (m *StoreManager) update(ctx context.Context, chain string, address string, mutate func(state *State)) error {
+	key := Key(chain, address)
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		state, err := m.store.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to load nonce state: %w", err)
+		}
+		if state == nil {
+			state = &State{}
+		}
+
+		expectedVersion := state.Version
+		next := &State{
+			Next:     state.Next,
+			Released: append([]uint64(nil), state.Released...),
+			Version:  expectedVersion + 1,
+		}
+		mutate(next)
+
+		err = m.store.CompareAndSwap(ctx, key, expectedVersion, next)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrConflict) {
+			return fmt.Errorf("failed to save nonce state: %w", err)
+		}
+
+		m.logger.Debug("nonce state conflict, retrying",
+			zap.String("key", key),
+			zap.Int("attempt", attempt))
+	}
+
+	return fmt.Errorf("%w: %s", ErrTooManyConflicts, key)
+}
+
+// sync nonces abaixo do pendente já foram usados na chain (por este ou outro remetente)
+func (s *State) sync(pending uint64) {
+	if s.Next < pending {
+		s.Next = pending
+	}
+
+	released := s.Released[:0]
+	for _, nonce := range s.Released {
+		if nonce >= pending {
+			released = append(released, nonce)
+		}
+	}
+	s.Released = released
+}
+
+// Key chave do estado de um endereço em uma chain
+func Key(chain string, address string) string {
+	return strings.ToUpper(chain) + "#" + strings.ToLower(address)
+}
+
+// IsNonceTooLow indica se o node rejeitou a transação por nonce já utilizado; "already known" não conta,
+// pois indica que a própria transação já está no mempool
+func IsNonceTooLow(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
@@ -0,0 +1,291 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testAddress = "0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"
+
+// MockSource mock da fonte de nonce pendente
+type MockSource struct {
+	mock.Mock
+}
+
+func (m *MockSource) GetNonce(ctx context.Context, address string) (uint64, error) {
+	args := m.Called(ctx, address)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+// conflictStore Store que simula escritas concorrentes nas primeiras tentativas
+type conflictStore struct {
+	*MemoryStore
+	conflicts int
+}
+
+func (s *conflictStore) CompareAndSwap(ctx context.Context, key string, expectedVersion int64, state *State) error {
+	if s.conflicts > 0 {
+		s.conflicts--
+		return ErrConflict
+	}
+	return s.MemoryStore.CompareAndSwap(ctx, key, expectedVersion, state)
+}
+
+func newSource(pending uint64) *MockSource {
+	source := new(MockSource)
+	source.On("GetNonce", mock.Anything, testAddress).Return(pending, nil)
+	return source
+}
+
+func TestStoreManager_Acquire(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start from pending nonce and increment", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(5)
+
+		first, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		second, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(5), first)
+		assert.Equal(t, uint64(6), second)
+	})
+
+	t.Run("jump ahead when node is ahead of counter", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		require.NoError(t, store.CompareAndSwap(context.Background(), Key("ETHEREUM", testAddress), 0,
+			&State{Next: 3, Released: []uint64{1, 8}, Version: 1}))
+		manager := NewManager(store, zap.NewNop())
+
+		nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, newSource(7))
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(8), nonce)
+		state, _ := store.Get(context.Background(), Key("ETHEREUM", testAddress))
+		assert.Equal(t, uint64(7), state.Next)
+		assert.Empty(t, state.Released)
+	})
+
+	t.Run("unique nonces for concurrent sends", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(0)
+
+		const senders = 50
+		nonces := make(chan uint64, senders)
+		var wg sync.WaitGroup
+		for i := 0; i < senders; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+				if err == nil {
+					nonces <- nonce
+				}
+			}()
+		}
+		wg.Wait()
+		close(nonces)
+
+		seen := make(map[uint64]bool)
+		for nonce := range nonces {
+			assert.False(t, seen[nonce], "nonce %d reserved twice", nonce)
+			seen[nonce] = true
+		}
+		// Com conflitos além de maxAttempts alguns envios podem falhar, mas nunca duplicar
+		assert.NotEmpty(t, seen)
+	})
+
+	t.Run("separate counters per chain and address", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(2)
+
+		eth, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		polygon, err := manager.Acquire(context.Background(), "POLYGON", testAddress, source)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint64(2), eth)
+		assert.Equal(t, uint64(2), polygon)
+	})
+
+	t.Run("retry on conflict", func(t *testing.T) {
+		t.Parallel()
+
+		store := &conflictStore{MemoryStore: NewMemoryStore(), conflicts: 3}
+		manager := NewManager(store, zap.NewNop())
+
+		nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, newSource(4))
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), nonce)
+	})
+
+	t.Run("fail after too many conflicts", func(t *testing.T) {
+		t.Parallel()
+
+		store := &conflictStore{MemoryStore: NewMemoryStore(), conflicts: maxAttempts}
+		manager := NewManager(store, zap.NewNop())
+
+		_, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, newSource(4))
+
+		assert.ErrorIs(t, err, ErrTooManyConflicts)
+	})
+
+	t.Run("fail when node is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := new(MockSource)
+		source.On("GetNonce", mock.Anything, testAddress).Return(uint64(0), errors.New("connection refused"))
+
+		_, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestStoreManager_Release(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rewind counter when releasing last nonce", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(10)
+
+		nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		require.NoError(t, manager.Release(context.Background(), "ETHEREUM", testAddress, nonce))
+
+		again, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		assert.Equal(t, nonce, again)
+	})
+
+	t.Run("fill gap before taking new nonce", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(0)
+
+		for i := 0; i < 4; i++ {
+			_, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+			require.NoError(t, err)
+		}
+		require.NoError(t, manager.Release(context.Background(), "ETHEREUM", testAddress, 2))
+		require.NoError(t, manager.Release(context.Background(), "ETHEREUM", testAddress, 1))
+		require.NoError(t, manager.Release(context.Background(), "ETHEREUM", testAddress, 1))
+
+		var nonces []uint64
+		for i := 0; i < 3; i++ {
+			nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+			require.NoError(t, err)
+			nonces = append(nonces, nonce)
+		}
+		assert.Equal(t, []uint64{1, 2, 4}, nonces)
+	})
+
+	t.Run("ignore nonce never reserved", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		manager := NewManager(store, zap.NewNop())
+
+		require.NoError(t, manager.Release(context.Background(), "ETHEREUM", testAddress, 9))
+
+		state, _ := store.Get(context.Background(), Key("ETHEREUM", testAddress))
+		assert.Equal(t, uint64(0), state.Next)
+		assert.Empty(t, state.Released)
+	})
+}
+
+func TestStoreManager_Resync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("advance to pending nonce and drop used gaps", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		require.NoError(t, store.CompareAndSwap(context.Background(), Key("ETHEREUM", testAddress), 0,
+			&State{Next: 10, Released: []uint64{6}, Version: 1}))
+		manager := NewManager(store, zap.NewNop())
+		source := newSource(12)
+
+		require.NoError(t, manager.Resync(context.Background(), "ETHEREUM", testAddress, source))
+
+		nonce, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12), nonce)
+	})
+
+	t.Run("keep nonces reserved above pending", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+		require.NoError(t, store.CompareAndSwap(context.Background(), Key("ETHEREUM", testAddress), 0,
+			&State{Next: 20, Released: []uint64{10, 15}, Version: 1}))
+		manager := NewManager(store, zap.NewNop())
+		source := newSource(12)
+
+		require.NoError(t, manager.Resync(context.Background(), "ETHEREUM", testAddress, source))
+
+		// 10 já foi usado na chain; 15 é lacuna válida e 16-19 seguem reservados
+		first, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		second, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(15), first)
+		assert.Equal(t, uint64(20), second)
+	})
+
+	t.Run("no duplicate nonce with a concurrent reservation", func(t *testing.T) {
+		t.Parallel()
+
+		manager := NewInMemoryManager(zap.NewNop())
+		source := newSource(5)
+
+		// Outra invocação reservou 5 e ainda não enviou; o node continua reportando 5 como pendente
+		reserved, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+
+		require.NoError(t, manager.Resync(context.Background(), "ETHEREUM", testAddress, source))
+
+		next, err := manager.Acquire(context.Background(), "ETHEREUM", testAddress, source)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), reserved)
+		assert.NotEqual(t, reserved, next)
+		assert.Equal(t, uint64(6), next)
+	})
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ETHEREUM#0x742d35cc6634c0532925a3b844bc9e7595f0beb0", Key("ethereum", testAddress))
+}
+
+func TestIsNonceTooLow(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsNonceTooLow(errors.New("failed to send transaction: nonce too low")))
+	assert.False(t, IsNonceTooLow(errors.New("already known")))
+	assert.False(t, IsNonceTooLow(errors.New("insufficient funds for gas * price + value")))
+	assert.False(t, IsNonceTooLow(nil))
+}
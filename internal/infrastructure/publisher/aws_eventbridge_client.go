@@ -0,0 +1,110 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// EventBridgeEntry evento enviado no PutEvents
+type EventBridgeEntry struct {
+	EventBusName string `json:"EventBusName,omitempty"`
+	Source       string `json:"Source"`
+	DetailType   string `json:"DetailType"`
+	Detail       string `json:"Detail"`
+}
+
+// AWSEventBridgeClient chama a API JSON do EventBridge (PutEvents) com assinatura SigV4
+type AWSEventBridgeClient struct {
+	httpClient  *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	endpoint    string
+}
+
+// NewAWSEventBridgeClient cria um cliente EventBridge usando região e credenciais da configuração AWS
+func NewAWSEventBridgeClient(awsCfg aws.Config, timeout time.Duration) *AWSEventBridgeClient {
+	return &AWSEventBridgeClient{
+		httpClient:  &http.Client{Timeout: timeout},
+		credentials: awsCfg.Credentials,
+		signer:      v4.NewSigner(),
+		region:      awsCfg.Region,
+		endpoint:    fmt.Sprintf("https://events.%s.amazonaws.com/", awsCfg.Region),
+	}
+}
+
+type putEventsRequest struct {
+	Entries []EventBridgeEntry `json:"Entries"`
+}
+
+type putEventsResponse struct {
+	FailedEntryCount int `json:"FailedEntryCount"`
+	Entries          []struct {
+		EventID      string `json:"EventId"`
+		ErrorCode    string `json:"ErrorCode"`
+		ErrorMessage string `json:"ErrorMessage"`
+	} `json:"Entries"`
+}
+
+// PutEvents envia os eventos; entradas rejeitadas pelo EventBridge retornam erro
+func (c *AWSEventBridgeClient) PutEvents(ctx context.Context, entries []EventBridgeEntry) error {
+	payload, err := json.Marshal(putEventsRequest{Entries: entries})
+	if err != nil {
+		return fmt.Errorf("failed to marshal EventBridge request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create EventBridge request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AWSEvents.PutEvents")
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), "events", c.region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign EventBridge request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("EventBridge request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read EventBridge response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("EventBridge PutEvents returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var output putEventsResponse
+	if err := json.Unmarshal(respBody, &output); err != nil {
+		return fmt.Errorf("failed to parse EventBridge response: %w", err)
+	}
+	if output.FailedEntryCount > 0 {
+		for _, entry := range output.Entries {
+			if entry.ErrorCode != "" {
+				return fmt.Errorf("EventBridge rejected %d event(s): %s: %s", output.FailedEntryCount, entry.ErrorCode, entry.ErrorMessage)
+			}
+		}
+		return fmt.Errorf("EventBridge rejected %d event(s)", output.FailedEntryCount)
+	}
+	return nil
+}
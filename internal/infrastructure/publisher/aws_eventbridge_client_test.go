@@ -0,0 +1,73 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAWSEventBridgeClient(t *testing.T, handler http.HandlerFunc) *AWSEventBridgeClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewAWSEventBridgeClient(aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "SECRET"}, nil
+		}),
+	}, 5*time.Second)
+	client.endpoint = server.URL
+	return client
+}
+
+func TestAWSEventBridgeClientPutEvents(t *testing.T) {
+	entry := EventBridgeEntry{EventBusName: "bus", Source: "chainevm", DetailType: "transaction.failed", Detail: `{"id":"1"}`}
+
+	t.Run("signed request", func(t *testing.T) {
+		client := newTestAWSEventBridgeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "AWSEvents.PutEvents", r.Header.Get("X-Amz-Target"))
+			assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256"))
+			assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/events/")
+
+			var body putEventsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []EventBridgeEntry{entry}, body.Entries)
+
+			_, _ = w.Write([]byte(`{"FailedEntryCount":0,"Entries":[{"EventId":"evt-1"}]}`))
+		})
+
+		assert.NoError(t, client.PutEvents(context.Background(), []EventBridgeEntry{entry}))
+	})
+
+	t.Run("rejected entry", func(t *testing.T) {
+		client := newTestAWSEventBridgeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"FailedEntryCount":1,"Entries":[{"ErrorCode":"InternalFailure","ErrorMessage":"try again"}]}`))
+		})
+
+		err := client.PutEvents(context.Background(), []EventBridgeEntry{entry})
+
+		assert.EqualError(t, err, "EventBridge rejected 1 event(s): InternalFailure: try again")
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		client := newTestAWSEventBridgeClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException"}`))
+		})
+
+		err := client.PutEvents(context.Background(), []EventBridgeEntry{entry})
+
+		assert.ErrorContains(t, err, "returned status 400")
+		assert.ErrorContains(t, err, "ResourceNotFoundException")
+	})
+}
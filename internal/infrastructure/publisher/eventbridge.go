@@ -0,0 +1,57 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"go.uber.org/zap"
+)
+
+// EventBridgeClient envio de eventos usado pelo publisher (implementado por AWSEventBridgeClient)
+type EventBridgeClient interface {
+	PutEvents(ctx context.Context, entries []EventBridgeEntry) error
+}
+
+// EventBridgePublisher publica os envelopes em um event bus do EventBridge; o tipo do evento vai em
+// detail-type e o envelope completo em detail, para que as regras filtrem por tipo ou por campos do envelope
+type EventBridgePublisher struct {
+	client  EventBridgeClient
+	busName string
+	source  string
+	logger  *zap.Logger
+}
+
+// NewEventBridgePublisher cria o publisher do event bus (busName vazio = bus default da conta)
+func NewEventBridgePublisher(client EventBridgeClient, busName, source string, logger *zap.Logger) *EventBridgePublisher {
+	return &EventBridgePublisher{
+		client:  client,
+		busName: busName,
+		source:  sourceOrDefault(source),
+		logger:  logger,
+	}
+}
+
+// Publish envia o envelope do evento para o event bus
+func (p *EventBridgePublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	envelope, body, err := encode(p.source, event)
+	if err != nil {
+		return err
+	}
+
+	entry := EventBridgeEntry{
+		EventBusName: p.busName,
+		Source:       envelope.Source,
+		DetailType:   envelope.Type,
+		Detail:       body,
+	}
+	if err := p.client.PutEvents(ctx, []EventBridgeEntry{entry}); err != nil {
+		return fmt.Errorf("failed to publish event %s to EventBridge: %w", envelope.Type, err)
+	}
+
+	p.logger.Debug("event published to EventBridge",
+		zap.String("event_type", envelope.Type),
+		zap.String("event_id", envelope.ID),
+		zap.String("operation_id", envelope.AggregateID))
+	return nil
+}
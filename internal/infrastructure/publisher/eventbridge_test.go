@@ -0,0 +1,60 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockEventBridgeClient mock do envio de eventos ao EventBridge
+type MockEventBridgeClient struct {
+	mock.Mock
+}
+
+func (m *MockEventBridgeClient) PutEvents(ctx context.Context, entries []EventBridgeEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func TestEventBridgePublisher_Publish(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("puts the envelope as detail", func(t *testing.T) {
+		client := new(MockEventBridgeClient)
+		var entries []EventBridgeEntry
+		client.On("PutEvents", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { entries = args.Get(1).([]EventBridgeEntry) }).
+			Return(nil)
+
+		publisher := NewEventBridgePublisher(client, "chainevm-bus", "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionFailedEvent("op-3", "BSC", "insufficient funds"))
+
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "chainevm-bus", entries[0].EventBusName)
+		assert.Equal(t, "chainevm", entries[0].Source)
+		assert.Equal(t, "transaction.failed", entries[0].DetailType)
+
+		var envelope events.Envelope
+		require.NoError(t, json.Unmarshal([]byte(entries[0].Detail), &envelope))
+		assert.Equal(t, "transaction.failed", envelope.Type)
+		assert.Equal(t, "op-3", envelope.AggregateID)
+	})
+
+	t.Run("put error", func(t *testing.T) {
+		client := new(MockEventBridgeClient)
+		client.On("PutEvents", mock.Anything, mock.Anything).Return(errors.New("throttled"))
+
+		publisher := NewEventBridgePublisher(client, "", "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-3", "BSC"))
+
+		assert.ErrorContains(t, err, "failed to publish event transaction.processing to EventBridge: throttled")
+	})
+}
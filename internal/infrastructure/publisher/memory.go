@@ -0,0 +1,58 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+)
+
+// InMemoryPublisher guarda os envelopes publicados em memória (testes e execução local)
+type InMemoryPublisher struct {
+	mu        sync.Mutex
+	source    string
+	envelopes []events.Envelope
+}
+
+// NewInMemoryPublisher cria o publisher em memória
+func NewInMemoryPublisher(source string) *InMemoryPublisher {
+	return &InMemoryPublisher{source: sourceOrDefault(source)}
+}
+
+// Publish serializa o evento como os demais backends e guarda o envelope
+func (p *InMemoryPublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	envelope, _, err := encode(p.source, event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.envelopes = append(p.envelopes, *envelope)
+	return nil
+}
+
+// Envelopes envelopes publicados, na ordem de publicação
+func (p *InMemoryPublisher) Envelopes() []events.Envelope {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]events.Envelope(nil), p.envelopes...)
+}
+
+// Types tipos dos eventos publicados, na ordem de publicação
+func (p *InMemoryPublisher) Types() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	types := make([]string, len(p.envelopes))
+	for i, envelope := range p.envelopes {
+		types[i] = envelope.Type
+	}
+	return types
+}
+
+// Reset descarta os envelopes publicados
+func (p *InMemoryPublisher) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.envelopes = nil
+}
@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryPublisher(t *testing.T) {
+	t.Run("keeps envelopes in order", func(t *testing.T) {
+		publisher := NewInMemoryPublisher("")
+
+		require.NoError(t, publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM")))
+		require.NoError(t, publisher.Publish(context.Background(), events.NewTransactionFailedEvent("op-1", "ETHEREUM", "reverted")))
+
+		assert.Equal(t, []string{"transaction.processing", "transaction.failed"}, publisher.Types())
+		envelopes := publisher.Envelopes()
+		require.Len(t, envelopes, 2)
+		assert.Equal(t, DefaultSource, envelopes[0].Source)
+		assert.Equal(t, events.EnvelopeVersion, envelopes[1].Version)
+
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal(envelopes[1].Data, &data))
+		assert.Equal(t, "reverted", data["error_message"])
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		publisher := NewInMemoryPublisher("tests")
+		require.NoError(t, publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM")))
+
+		publisher.Reset()
+
+		assert.Empty(t, publisher.Envelopes())
+	})
+
+	t.Run("concurrent publish", func(t *testing.T) {
+		publisher := NewInMemoryPublisher("tests")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM"))
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, publisher.Envelopes(), 20)
+	})
+}
@@ -0,0 +1,45 @@
+// Package publisher implementações de events.EventPublisher: os eventos de domínio são publicados no
+// envelope JSON versionado (events.Envelope) em SNS, SQS ou EventBridge, ou guardados em memória nos testes
+package publisher
+
+import (
+	"strings"
+
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+)
+
+// DefaultSource origem dos envelopes quando nenhuma é configurada
+const DefaultSource = "chainevm"
+
+// Atributos das mensagens publicadas em SNS e SQS, usados em filtros de assinatura sem ler o corpo
+const (
+	attributeEventType    = "EventType"
+	attributeEventVersion = "EventVersion"
+	attributeOperationID  = "OperationID"
+)
+
+// encode envolve o evento e serializa o envelope
+func encode(source string, event events.DomainEvent) (*events.Envelope, string, error) {
+	envelope, err := events.NewEnvelope(source, event)
+	if err != nil {
+		return nil, "", err
+	}
+	body, err := envelope.Marshal()
+	if err != nil {
+		return nil, "", err
+	}
+	return envelope, string(body), nil
+}
+
+// isFIFO filas e tópicos FIFO exigem MessageGroupId; os eventos da mesma operação ficam no mesmo grupo
+func isFIFO(target string) bool {
+	return strings.HasSuffix(target, ".fifo")
+}
+
+// sourceOrDefault origem configurada ou DefaultSource
+func sourceOrDefault(source string) string {
+	if source == "" {
+		return DefaultSource
+	}
+	return source
+}
@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"go.uber.org/zap"
+)
+
+// SNSClient publicação em tópicos usada pelo publisher (implementado por *sns.Client)
+type SNSClient interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSPublisher publica os envelopes em um tópico SNS; as filas assinantes recebem o envelope como corpo
+// da mensagem (com raw message delivery) e podem filtrar pelos atributos EventType e EventVersion
+type SNSPublisher struct {
+	client   SNSClient
+	topicARN string
+	source   string
+	logger   *zap.Logger
+}
+
+// NewSNSPublisher cria o publisher do tópico
+func NewSNSPublisher(client SNSClient, topicARN, source string, logger *zap.Logger) *SNSPublisher {
+	return &SNSPublisher{
+		client:   client,
+		topicARN: topicARN,
+		source:   sourceOrDefault(source),
+		logger:   logger,
+	}
+}
+
+// Publish publica o envelope do evento no tópico
+func (p *SNSPublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	envelope, body, err := encode(p.source, event)
+	if err != nil {
+		return err
+	}
+
+	input := &sns.PublishInput{
+		TopicArn: aws.String(p.topicARN),
+		Message:  aws.String(body),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			attributeEventType:    {DataType: aws.String("String"), StringValue: aws.String(envelope.Type)},
+			attributeEventVersion: {DataType: aws.String("String"), StringValue: aws.String(envelope.Version)},
+			attributeOperationID:  {DataType: aws.String("String"), StringValue: aws.String(envelope.AggregateID)},
+		},
+	}
+	if isFIFO(p.topicARN) {
+		input.MessageGroupId = aws.String(envelope.AggregateID)
+		input.MessageDeduplicationId = aws.String(envelope.ID)
+	}
+
+	if _, err := p.client.Publish(ctx, input); err != nil {
+		return fmt.Errorf("failed to publish event %s to SNS: %w", envelope.Type, err)
+	}
+
+	p.logger.Debug("event published to SNS",
+		zap.String("event_type", envelope.Type),
+		zap.String("event_id", envelope.ID),
+		zap.String("operation_id", envelope.AggregateID))
+	return nil
+}
@@ -0,0 +1,81 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockSNSClient mock da publicação em tópicos SNS
+type MockSNSClient struct {
+	mock.Mock
+}
+
+func (m *MockSNSClient) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sns.PublishOutput), args.Error(1)
+}
+
+func TestSNSPublisher_Publish(t *testing.T) {
+	logger := zap.NewNop()
+	topicARN := "arn:aws:sns:us-east-1:123456789012:chainevm-events"
+
+	t.Run("publishes the envelope with event attributes", func(t *testing.T) {
+		client := new(MockSNSClient)
+		var input *sns.PublishInput
+		client.On("Publish", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { input = args.Get(1).(*sns.PublishInput) }).
+			Return(&sns.PublishOutput{}, nil)
+
+		publisher := NewSNSPublisher(client, topicARN, "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionSucceededEvent("op-2", "POLYGON", "0xhash", 10, 21000))
+
+		require.NoError(t, err)
+		assert.Equal(t, topicARN, aws.ToString(input.TopicArn))
+		assert.Nil(t, input.MessageGroupId)
+		assert.Equal(t, "transaction.succeeded", aws.ToString(input.MessageAttributes["EventType"].StringValue))
+		assert.Equal(t, events.EnvelopeVersion, aws.ToString(input.MessageAttributes["EventVersion"].StringValue))
+
+		var envelope events.Envelope
+		require.NoError(t, json.Unmarshal([]byte(aws.ToString(input.Message)), &envelope))
+		assert.Equal(t, "transaction.succeeded", envelope.Type)
+		assert.Equal(t, "op-2", envelope.AggregateID)
+	})
+
+	t.Run("FIFO topic groups by operation", func(t *testing.T) {
+		client := new(MockSNSClient)
+		var input *sns.PublishInput
+		client.On("Publish", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { input = args.Get(1).(*sns.PublishInput) }).
+			Return(&sns.PublishOutput{}, nil)
+
+		publisher := NewSNSPublisher(client, topicARN+".fifo", "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-2", "POLYGON"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "op-2", aws.ToString(input.MessageGroupId))
+		assert.NotEmpty(t, aws.ToString(input.MessageDeduplicationId))
+	})
+
+	t.Run("publish error", func(t *testing.T) {
+		client := new(MockSNSClient)
+		client.On("Publish", mock.Anything, mock.Anything).Return(nil, errors.New("topic not found"))
+
+		publisher := NewSNSPublisher(client, topicARN, "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-2", "POLYGON"))
+
+		assert.ErrorContains(t, err, "failed to publish event transaction.processing to SNS: topic not found")
+	})
+}
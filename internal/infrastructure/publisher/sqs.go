@@ -0,0 +1,67 @@
+package publisher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"go.uber.org/zap"
+)
+
+// SQSClient envio de mensagens usado pelo publisher (implementado por eventbus.SQSAdapter)
+type SQSClient interface {
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+}
+
+// SQSPublisher publica os envelopes diretamente em uma fila SQS
+type SQSPublisher struct {
+	client   SQSClient
+	queueURL string
+	source   string
+	logger   *zap.Logger
+}
+
+// NewSQSPublisher cria o publisher da fila
+func NewSQSPublisher(client SQSClient, queueURL, source string, logger *zap.Logger) *SQSPublisher {
+	return &SQSPublisher{
+		client:   client,
+		queueURL: queueURL,
+		source:   sourceOrDefault(source),
+		logger:   logger,
+	}
+}
+
+// Publish envia o envelope do evento para a fila
+func (p *SQSPublisher) Publish(ctx context.Context, event events.DomainEvent) error {
+	envelope, body, err := encode(p.source, event)
+	if err != nil {
+		return err
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(p.queueURL),
+		MessageBody: aws.String(body),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			attributeEventType:    {DataType: aws.String("String"), StringValue: aws.String(envelope.Type)},
+			attributeEventVersion: {DataType: aws.String("String"), StringValue: aws.String(envelope.Version)},
+			attributeOperationID:  {DataType: aws.String("String"), StringValue: aws.String(envelope.AggregateID)},
+		},
+	}
+	if isFIFO(p.queueURL) {
+		input.MessageGroupId = aws.String(envelope.AggregateID)
+		input.MessageDeduplicationId = aws.String(envelope.ID)
+	}
+
+	if _, err := p.client.SendMessage(ctx, input); err != nil {
+		return fmt.Errorf("failed to publish event %s to SQS: %w", envelope.Type, err)
+	}
+
+	p.logger.Debug("event published to SQS",
+		zap.String("event_type", envelope.Type),
+		zap.String("event_id", envelope.ID),
+		zap.String("operation_id", envelope.AggregateID))
+	return nil
+}
@@ -0,0 +1,82 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/gabrielksneiva/ChainEVM/internal/domain/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockSQSClient mock do envio de mensagens SQS
+type MockSQSClient struct {
+	mock.Mock
+}
+
+func (m *MockSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sqs.SendMessageOutput), args.Error(1)
+}
+
+func TestSQSPublisher_Publish(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("sends the envelope with event attributes", func(t *testing.T) {
+		client := new(MockSQSClient)
+		var input *sqs.SendMessageInput
+		client.On("SendMessage", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { input = args.Get(1).(*sqs.SendMessageInput) }).
+			Return(&sqs.SendMessageOutput{}, nil)
+
+		publisher := NewSQSPublisher(client, "https://sqs/events", "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://sqs/events", aws.ToString(input.QueueUrl))
+		assert.Nil(t, input.MessageGroupId)
+		assert.Equal(t, "transaction.processing", aws.ToString(input.MessageAttributes["EventType"].StringValue))
+		assert.Equal(t, events.EnvelopeVersion, aws.ToString(input.MessageAttributes["EventVersion"].StringValue))
+		assert.Equal(t, "op-1", aws.ToString(input.MessageAttributes["OperationID"].StringValue))
+
+		var envelope events.Envelope
+		require.NoError(t, json.Unmarshal([]byte(aws.ToString(input.MessageBody)), &envelope))
+		assert.Equal(t, "transaction.processing", envelope.Type)
+		assert.Equal(t, "chainevm", envelope.Source)
+		assert.Equal(t, "op-1", envelope.AggregateID)
+	})
+
+	t.Run("FIFO queue groups by operation", func(t *testing.T) {
+		client := new(MockSQSClient)
+		var input *sqs.SendMessageInput
+		client.On("SendMessage", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { input = args.Get(1).(*sqs.SendMessageInput) }).
+			Return(&sqs.SendMessageOutput{}, nil)
+
+		publisher := NewSQSPublisher(client, "https://sqs/events.fifo", "", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "op-1", aws.ToString(input.MessageGroupId))
+		assert.NotEmpty(t, aws.ToString(input.MessageDeduplicationId))
+	})
+
+	t.Run("send error", func(t *testing.T) {
+		client := new(MockSQSClient)
+		client.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("access denied"))
+
+		publisher := NewSQSPublisher(client, "https://sqs/events", "chainevm", logger)
+		err := publisher.Publish(context.Background(), events.NewTransactionProcessingEvent("op-1", "ETHEREUM"))
+
+		assert.ErrorContains(t, err, "failed to publish event transaction.processing to SQS: access denied")
+	})
+}
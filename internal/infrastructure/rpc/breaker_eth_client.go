@@ -0,0 +1,175 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BreakerEthClient EthClient que passa todas as chamadas pelo circuit breaker do endpoint
+type BreakerEthClient struct {
+	client  EthClient
+	breaker *CircuitBreaker
+}
+
+// NewBreakerEthClient envolve o cliente com o circuit breaker
+func NewBreakerEthClient(client EthClient, breaker *CircuitBreaker) *BreakerEthClient {
+	return &BreakerEthClient{client: client, breaker: breaker}
+}
+
+// Breaker retorna o circuit breaker do endpoint
+func (c *BreakerEthClient) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// call executa fn no breaker; só falhas do endpoint contam para abrir o circuito
+func (c *BreakerEthClient) call(fn func() error) error {
+	var callErr error
+	if err := c.breaker.Call(func() error {
+		callErr = fn()
+		if isEndpointFailure(callErr) {
+			return callErr
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return callErr
+}
+
+// isEndpointFailure indica falha de disponibilidade do endpoint; respostas de erro JSON-RPC (revert,
+// nonce, saldo), "not found" e cancelamentos do chamador mostram que o nó respondeu e não contam
+func isEndpointFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcErr interface{ ErrorCode() int }
+	return !errors.As(err, &rpcErr)
+}
+
+// BalanceAt delega ao cliente pelo breaker
+func (c *BreakerEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = c.call(func() (callErr error) {
+		balance, callErr = c.client.BalanceAt(ctx, account, blockNumber)
+		return callErr
+	})
+	return balance, err
+}
+
+// NonceAt delega ao cliente pelo breaker
+func (c *BreakerEthClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = c.call(func() (callErr error) {
+		nonce, callErr = c.client.NonceAt(ctx, account, blockNumber)
+		return callErr
+	})
+	return nonce, err
+}
+
+// PendingNonceAt delega ao cliente pelo breaker
+func (c *BreakerEthClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = c.call(func() (callErr error) {
+		nonce, callErr = c.client.PendingNonceAt(ctx, account)
+		return callErr
+	})
+	return nonce, err
+}
+
+// SendTransaction delega ao cliente pelo breaker
+func (c *BreakerEthClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.call(func() error {
+		return c.client.SendTransaction(ctx, tx)
+	})
+}
+
+// EstimateGas delega ao cliente pelo breaker
+func (c *BreakerEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	err = c.call(func() (callErr error) {
+		gas, callErr = c.client.EstimateGas(ctx, msg)
+		return callErr
+	})
+	return gas, err
+}
+
+// CallContract delega ao cliente pelo breaker (eth_call)
+func (c *BreakerEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (output []byte, err error) {
+	err = c.call(func() (callErr error) {
+		output, callErr = c.client.CallContract(ctx, msg, blockNumber)
+		return callErr
+	})
+	return output, err
+}
+
+// TransactionReceipt delega ao cliente pelo breaker
+func (c *BreakerEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.call(func() (callErr error) {
+		receipt, callErr = c.client.TransactionReceipt(ctx, txHash)
+		return callErr
+	})
+	return receipt, err
+}
+
+// ChainID delega ao cliente pelo breaker
+func (c *BreakerEthClient) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = c.call(func() (callErr error) {
+		chainID, callErr = c.client.ChainID(ctx)
+		return callErr
+	})
+	return chainID, err
+}
+
+// SuggestGasPrice delega ao cliente pelo breaker
+func (c *BreakerEthClient) SuggestGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	err = c.call(func() (callErr error) {
+		gasPrice, callErr = c.client.SuggestGasPrice(ctx)
+		return callErr
+	})
+	return gasPrice, err
+}
+
+// SuggestGasTipCap delega ao cliente pelo breaker (eth_maxPriorityFeePerGas)
+func (c *BreakerEthClient) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = c.call(func() (callErr error) {
+		tip, callErr = c.client.SuggestGasTipCap(ctx)
+		return callErr
+	})
+	return tip, err
+}
+
+// FeeHistory delega ao cliente pelo breaker (eth_feeHistory)
+func (c *BreakerEthClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (history *ethereum.FeeHistory, err error) {
+	err = c.call(func() (callErr error) {
+		history, callErr = c.client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return callErr
+	})
+	return history, err
+}
+
+// HeaderByNumber delega ao cliente pelo breaker
+func (c *BreakerEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = c.call(func() (callErr error) {
+		header, callErr = c.client.HeaderByNumber(ctx, number)
+		return callErr
+	})
+	return header, err
+}
+
+// BlockNumber delega ao cliente pelo breaker
+func (c *BreakerEthClient) BlockNumber(ctx context.Context) (blockNumber uint64, err error) {
+	err = c.call(func() (callErr error) {
+		blockNumber, callErr = c.client.BlockNumber(ctx)
+		return callErr
+	})
+	return blockNumber, err
+}
+
+// Close fecha o cliente; não passa pelo breaker
+func (c *BreakerEthClient) Close() {
+	c.client.Close()
+}
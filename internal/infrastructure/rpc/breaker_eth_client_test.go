@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// jsonRPCError simula um erro JSON-RPC retornado pelo nó
+type jsonRPCError struct{}
+
+func (e jsonRPCError) Error() string  { return "nonce too low" }
+func (e jsonRPCError) ErrorCode() int { return -32000 }
+
+func newTestBreakerClient(failureThreshold int) (*BreakerEthClient, *MockEthClient) {
+	mockClient := new(MockEthClient)
+	breaker := NewNamedCircuitBreaker("ETHEREUM/rpc.example.com", CircuitBreakerConfig{
+		FailureThreshold: failureThreshold,
+		SuccessThreshold: 1,
+		Timeout:          time.Minute,
+	}, nil, zap.NewNop())
+	return NewBreakerEthClient(mockClient, breaker), mockClient
+}
+
+func TestBreakerEthClient(t *testing.T) {
+	t.Run("open after endpoint failures and reject without calling node", func(t *testing.T) {
+		client, mockClient := newTestBreakerClient(2)
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(0), errors.New("connection refused")).Twice()
+
+		_, err := client.BlockNumber(context.Background())
+		require.Error(t, err)
+		_, err = client.BlockNumber(context.Background())
+		require.Error(t, err)
+		assert.Equal(t, StateOpen, client.Breaker().State())
+
+		_, err = client.ChainID(context.Background())
+
+		require.ErrorIs(t, err, ErrRPCUnavailable)
+		mockClient.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "ChainID", mock.Anything)
+	})
+
+	t.Run("node responses do not trip breaker", func(t *testing.T) {
+		client, mockClient := newTestBreakerClient(1)
+		hash := common.HexToHash("0x01")
+		mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+		mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(jsonRPCError{})
+
+		_, err := client.TransactionReceipt(context.Background(), hash)
+		require.ErrorIs(t, err, ethereum.NotFound)
+		err = client.SendTransaction(context.Background(), nil)
+		require.ErrorIs(t, err, jsonRPCError{})
+
+		assert.Equal(t, StateClosed, client.Breaker().State())
+	})
+
+	t.Run("caller cancellation does not trip breaker", func(t *testing.T) {
+		client, mockClient := newTestBreakerClient(1)
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(nil, context.Canceled)
+
+		_, err := client.SuggestGasPrice(context.Background())
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, StateClosed, client.Breaker().State())
+	})
+
+	t.Run("pass results through", func(t *testing.T) {
+		client, mockClient := newTestBreakerClient(1)
+		addr := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0")
+		mockClient.On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(42), nil)
+		mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(7), nil)
+
+		balance, err := client.BalanceAt(context.Background(), addr, nil)
+		require.NoError(t, err)
+		nonce, err := client.PendingNonceAt(context.Background(), addr)
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(42), balance)
+		assert.Equal(t, uint64(7), nonce)
+	})
+
+	t.Run("rpc client surfaces ErrRPCUnavailable", func(t *testing.T) {
+		breakerClient, mockClient := newTestBreakerClient(1)
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(0), errors.New("503 service unavailable")).Once()
+		rpcClient := &EVMRPCClient{client: breakerClient, timeout: time.Second, logger: zap.NewNop()}
+
+		_, err := rpcClient.GetBlockNumber(context.Background())
+		require.Error(t, err)
+		_, err = rpcClient.GetBlockNumber(context.Background())
+
+		assert.ErrorIs(t, err, ErrRPCUnavailable)
+	})
+}
+
+func TestEndpointName(t *testing.T) {
+	assert.Equal(t, "ETHEREUM/eth-mainnet.g.alchemy.com", EndpointName("ETHEREUM", "https://eth-mainnet.g.alchemy.com/v2/secret-key"))
+	assert.Equal(t, "POLYGON", EndpointName("POLYGON", "not a url"))
+}
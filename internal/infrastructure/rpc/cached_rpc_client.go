@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/pkg/cache"
+	"go.uber.org/zap"
+)
+
+// CacheMetrics recebe os hits e misses do cache de leituras RPC
+type CacheMetrics interface {
+	IncrementCacheHit(method string)
+	IncrementCacheMiss(method string)
+}
+
+// defaultHeadTTL intervalo padrão em que a altura conhecida é reutilizada sem nova consulta
+const defaultHeadTTL = 2 * time.Second
+
+// CacheTTLs TTL de cada leitura em cache; o chain ID não expira
+type CacheTTLs struct {
+	// GasPrice TTL do eth_gasPrice, também invalidado a cada novo bloco
+	GasPrice time.Duration
+	// Balance TTL dos saldos, chaveados pelo bloco mais recente conhecido
+	Balance time.Duration
+	// Receipt TTL dos receipts já finais
+	Receipt time.Duration
+	// Head idade máxima da altura usada na chave dos saldos antes de consultar o bloco de novo
+	Head time.Duration
+}
+
+// CachedRPCClient decorator do RPCClient que guarda leituras em cache com chaves por chain; leituras
+// dependentes do bloco são descartadas quando GetBlockNumber observa um bloco novo
+type CachedRPCClient struct {
+	RPCClient
+	chain   string
+	cache   cache.Cache
+	ttls    CacheTTLs
+	metrics CacheMetrics
+	logger  *zap.Logger
+
+	// finalityDepth confirmações para um receipt ir para o cache
+	finalityDepth uint64
+	// head bloco mais recente observado (0 = desconhecido)
+	head atomic.Uint64
+	// headCheckedAt última consulta da altura (UnixNano)
+	headCheckedAt atomic.Int64
+}
+
+// NewCachedRPCClient envolve o cliente da chain; o cache (memória ou Redis) pode ser compartilhado entre chains
+func NewCachedRPCClient(client RPCClient, chain string, rpcCache cache.Cache, ttls CacheTTLs, finalityDepth uint64, metrics CacheMetrics, logger *zap.Logger) *CachedRPCClient {
+	if ttls.Head <= 0 {
+		ttls.Head = defaultHeadTTL
+	}
+	return &CachedRPCClient{
+		RPCClient:     client,
+		chain:         chain,
+		cache:         rpcCache,
+		ttls:          ttls,
+		metrics:       metrics,
+		logger:        logger,
+		finalityDepth: finalityDepth,
+	}
+}
+
+// GetEthClient expõe o EthClient do cliente envolvido (usado pelo signer, sem cache); nil se ele não expõe
+func (c *CachedRPCClient) GetEthClient() EthClient {
+	if provider, ok := c.RPCClient.(EthClientProvider); ok {
+		return provider.GetEthClient()
+	}
+	return nil
+}
+
+// GetChainID cacheado sem expiração
+func (c *CachedRPCClient) GetChainID(ctx context.Context) (*big.Int, error) {
+	return c.loadBigInt(ctx, "GetChainID", c.key("chain_id"), cache.NoExpiration, c.RPCClient.GetChainID)
+}
+
+// GetGasPrice cacheado por GasPrice e até o próximo bloco
+func (c *CachedRPCClient) GetGasPrice(ctx context.Context) (*big.Int, error) {
+	return c.loadBigInt(ctx, "GetGasPrice", c.blockKey("gas_price"), c.ttls.GasPrice, c.RPCClient.GetGasPrice)
+}
+
+// GetBalance cacheado por endereço e bloco mais recente; a altura é consultada de novo quando passa de
+// ttls.Head, para que o saldo não fique preso a um bloco antigo entre chamadas de GetBlockNumber
+func (c *CachedRPCClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	head := c.head.Load()
+	if head == 0 || time.Since(time.Unix(0, c.headCheckedAt.Load())) >= c.ttls.Head {
+		if _, err := c.GetBlockNumber(ctx); err != nil {
+			return c.RPCClient.GetBalance(ctx, address)
+		}
+		head = c.head.Load()
+	}
+
+	key := c.blockKey(fmt.Sprintf("balance:%s:%d", strings.ToLower(address), head))
+	return c.loadBigInt(ctx, "GetBalance", key, c.ttls.Balance, func(ctx context.Context) (*big.Int, error) {
+		return c.RPCClient.GetBalance(ctx, address)
+	})
+}
+
+// GetTransactionReceipt cacheia só receipts com finalityDepth confirmações em relação ao bloco mais recente
+// conhecido; a confirmação de transações usa o cliente sem cache, que enxerga reorgs
+func (c *CachedRPCClient) GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
+	value, err := c.load(ctx, "GetTransactionReceipt", c.key("receipt:"+strings.ToLower(txHash)), c.ttls.Receipt, func(ctx context.Context) (any, error) {
+		receipt, err := c.RPCClient.GetTransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if receipt.BlockNumber == nil || c.head.Load() < receipt.BlockNumber.Uint64()+c.finalityDepth {
+			return receipt, cache.ErrSkipStore
+		}
+		return receipt, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*types.Receipt), nil
+}
+
+// GetBlockNumber não é cacheado; um bloco novo invalida as leituras dependentes do bloco
+func (c *CachedRPCClient) GetBlockNumber(ctx context.Context) (uint64, error) {
+	blockNumber, err := c.RPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	c.headCheckedAt.Store(time.Now().UnixNano())
+
+	for {
+		head := c.head.Load()
+		if blockNumber <= head {
+			break
+		}
+		if c.head.CompareAndSwap(head, blockNumber) {
+			removed, err := c.cache.DeletePrefix(ctx, c.blockKey(""))
+			if err != nil {
+				c.logger.Warn("failed to invalidate rpc cache on new block",
+					zap.String("chain", c.chain),
+					zap.Uint64("block_number", blockNumber),
+					zap.Error(err))
+			} else if removed > 0 {
+				c.logger.Debug("rpc cache invalidated by new block",
+					zap.String("chain", c.chain),
+					zap.Uint64("block_number", blockNumber),
+					zap.Int("entries", removed))
+			}
+			break
+		}
+	}
+	return blockNumber, nil
+}
+
+// load busca no cache ou carrega uma única vez para chamadas concorrentes, registrando hit ou miss do método
+func (c *CachedRPCClient) load(ctx context.Context, method, key string, ttl time.Duration, loadFn cache.LoadFunc) (any, error) {
+	value, hit, err := c.cache.GetOrLoad(ctx, key, ttl, loadFn)
+	if c.metrics != nil {
+		if hit {
+			c.metrics.IncrementCacheHit(method)
+		} else {
+			c.metrics.IncrementCacheMiss(method)
+		}
+	}
+	return value, err
+}
+
+// loadBigInt load para leituras *big.Int; cada chamador recebe uma cópia do valor em cache
+func (c *CachedRPCClient) loadBigInt(ctx context.Context, method, key string, ttl time.Duration, fetch func(ctx context.Context) (*big.Int, error)) (*big.Int, error) {
+	value, err := c.load(ctx, method, key, ttl, func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(value.(*big.Int)), nil
+}
+
+// key chave com o namespace da chain
+func (c *CachedRPCClient) key(name string) string {
+	return c.chain + ":" + name
+}
+
+// blockKey chave de leitura descartada a cada novo bloco
+func (c *CachedRPCClient) blockKey(name string) string {
+	return c.chain + ":block:" + name
+}
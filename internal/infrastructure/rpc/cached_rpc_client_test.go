@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/pkg/cache"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// countingCacheMetrics conta hits e misses por método
+type countingCacheMetrics struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *countingCacheMetrics) IncrementCacheHit(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits[method]++
+}
+
+func (m *countingCacheMetrics) IncrementCacheMiss(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses[method]++
+}
+
+func newTestCachedClient(rpcCache cache.Cache, chain string) (*CachedRPCClient, *MockEthClient, *countingCacheMetrics) {
+	mockClient := new(MockEthClient)
+	metrics := &countingCacheMetrics{hits: map[string]int{}, misses: map[string]int{}}
+	inner := &EVMRPCClient{client: mockClient, timeout: time.Second, logger: zap.NewNop()}
+	return NewCachedRPCClient(inner, chain, rpcCache, CacheTTLs{
+		GasPrice: time.Minute,
+		Balance:  time.Minute,
+		Receipt:  time.Hour,
+	}, 2, metrics, zap.NewNop()), mockClient, metrics
+}
+
+func TestCachedRPCClient(t *testing.T) {
+	addr := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0")
+
+	t.Run("cache chain id and count hits", func(t *testing.T) {
+		client, mockClient, metrics := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		mockClient.On("ChainID", mock.Anything).Return(big.NewInt(1), nil).Once()
+
+		first, err := client.GetChainID(context.Background())
+		require.NoError(t, err)
+		second, err := client.GetChainID(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(1), first)
+		assert.Equal(t, big.NewInt(1), second)
+		assert.Equal(t, 1, metrics.hits["GetChainID"])
+		assert.Equal(t, 1, metrics.misses["GetChainID"])
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("load once for concurrent misses", func(t *testing.T) {
+		client, mockClient, _ := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		release := make(chan struct{})
+		mockClient.On("ChainID", mock.Anything).Run(func(args mock.Arguments) {
+			<-release
+		}).Return(big.NewInt(1), nil).Once()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				chainID, err := client.GetChainID(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, big.NewInt(1), chainID)
+			}()
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		mockClient.AssertNumberOfCalls(t, "ChainID", 1)
+	})
+
+	t.Run("namespace keys by chain", func(t *testing.T) {
+		rpcCache := cache.NewRPCCache(time.Minute, 100)
+		ethereum, ethereumClient, _ := newTestCachedClient(rpcCache, "ETHEREUM")
+		polygon, polygonClient, _ := newTestCachedClient(rpcCache, "POLYGON")
+		ethereumClient.On("ChainID", mock.Anything).Return(big.NewInt(1), nil).Once()
+		polygonClient.On("ChainID", mock.Anything).Return(big.NewInt(137), nil).Once()
+
+		ethereumID, err := ethereum.GetChainID(context.Background())
+		require.NoError(t, err)
+		polygonID, err := polygon.GetChainID(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(1), ethereumID)
+		assert.Equal(t, big.NewInt(137), polygonID)
+	})
+
+	t.Run("invalidate gas price on new block", func(t *testing.T) {
+		client, mockClient, _ := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(101), nil).Once()
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(10), nil).Once()
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(12), nil).Once()
+
+		_, err := client.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		gasPrice, _ := client.GetGasPrice(context.Background())
+		assert.Equal(t, big.NewInt(10), gasPrice)
+
+		// Mesmo bloco mantém o cache
+		_, err = client.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		gasPrice, _ = client.GetGasPrice(context.Background())
+		assert.Equal(t, big.NewInt(10), gasPrice)
+
+		_, err = client.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		gasPrice, _ = client.GetGasPrice(context.Background())
+		assert.Equal(t, big.NewInt(12), gasPrice)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("cache balance per block", func(t *testing.T) {
+		client, mockClient, _ := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+		mockClient.On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(42), nil).Once()
+
+		first, err := client.GetBalance(context.Background(), addr.Hex())
+		require.NoError(t, err)
+		second, err := client.GetBalance(context.Background(), addr.Hex())
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(42), first)
+		assert.Equal(t, big.NewInt(42), second)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("refresh head for balances after head ttl", func(t *testing.T) {
+		mockClient := new(MockEthClient)
+		inner := &EVMRPCClient{client: mockClient, timeout: time.Second, logger: zap.NewNop()}
+		client := NewCachedRPCClient(inner, "ETHEREUM", cache.NewRPCCache(time.Minute, 100), CacheTTLs{
+			Balance: time.Minute,
+			Head:    10 * time.Millisecond,
+		}, 2, nil, zap.NewNop())
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(101), nil).Once()
+		mockClient.On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(42), nil).Once()
+		mockClient.On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(40), nil).Once()
+
+		first, err := client.GetBalance(context.Background(), addr.Hex())
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		second, err := client.GetBalance(context.Background(), addr.Hex())
+		require.NoError(t, err)
+
+		// Sem chamadas a GetBlockNumber no meio, o bloco novo ainda invalida o saldo
+		assert.Equal(t, big.NewInt(42), first)
+		assert.Equal(t, big.NewInt(40), second)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("do not cache errors", func(t *testing.T) {
+		client, mockClient, _ := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(nil, errors.New("timeout")).Once()
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(10), nil).Once()
+
+		_, err := client.GetGasPrice(context.Background())
+		require.Error(t, err)
+		gasPrice, err := client.GetGasPrice(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(10), gasPrice)
+	})
+
+	t.Run("cache only final receipts", func(t *testing.T) {
+		client, mockClient, _ := newTestCachedClient(cache.NewRPCCache(time.Minute, 100), "ETHEREUM")
+		hash := common.HexToHash("0x01")
+		receipt := &types.Receipt{Status: 1, BlockNumber: big.NewInt(100)}
+		mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil).Twice()
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(101), nil).Once()
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(102), nil).Once()
+
+		// 1 confirmação: ainda não é final
+		_, err := client.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		_, err = client.GetTransactionReceipt(context.Background(), hash.Hex())
+		require.NoError(t, err)
+
+		_, err = client.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		_, err = client.GetTransactionReceipt(context.Background(), hash.Hex())
+		require.NoError(t, err)
+		cached, err := client.GetTransactionReceipt(context.Background(), hash.Hex())
+		require.NoError(t, err)
+
+		assert.Equal(t, receipt, cached)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("share entries between containers through redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		newRedisCache := func() cache.Cache {
+			return cache.NewRedisCache(redis.NewClient(&redis.Options{Addr: server.Addr()}), cache.RedisOptions{Prefix: "chainevm:rpc:"})
+		}
+		hash := common.HexToHash("0x01")
+		receipt := &types.Receipt{Status: 1, BlockNumber: big.NewInt(100), BlockHash: common.HexToHash("0xaa"), TxHash: hash, GasUsed: 21000}
+
+		warm, warmClient, _ := newTestCachedClient(newRedisCache(), "ETHEREUM")
+		warmClient.On("ChainID", mock.Anything).Return(big.NewInt(1), nil).Once()
+		warmClient.On("BlockNumber", mock.Anything).Return(uint64(110), nil).Once()
+		warmClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil).Once()
+		_, err := warm.GetChainID(context.Background())
+		require.NoError(t, err)
+		_, err = warm.GetBlockNumber(context.Background())
+		require.NoError(t, err)
+		_, err = warm.GetTransactionReceipt(context.Background(), hash.Hex())
+		require.NoError(t, err)
+
+		// Container novo (cold start) lê do Redis sem chamar o nó
+		cold, coldClient, metrics := newTestCachedClient(newRedisCache(), "ETHEREUM")
+		chainID, err := cold.GetChainID(context.Background())
+		require.NoError(t, err)
+		cached, err := cold.GetTransactionReceipt(context.Background(), hash.Hex())
+		require.NoError(t, err)
+
+		assert.Equal(t, big.NewInt(1), chainID)
+		assert.Equal(t, receipt.BlockHash, cached.BlockHash)
+		assert.Equal(t, receipt.GasUsed, cached.GasUsed)
+		assert.Equal(t, 1, metrics.hits["GetChainID"])
+		coldClient.AssertNotCalled(t, "ChainID", mock.Anything)
+		coldClient.AssertNotCalled(t, "TransactionReceipt", mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -20,17 +21,38 @@ const (
 	StateHalfOpen CircuitBreakerState = "HALF_OPEN"
 )
 
+// ErrRPCUnavailable o circuit breaker do endpoint está aberto (ou sem vagas de teste) e rejeitou a chamada
+var ErrRPCUnavailable = errors.New("rpc endpoint unavailable")
+
+// BreakerMetrics recebe as transições e rejeições dos circuit breakers
+type BreakerMetrics interface {
+	SetCircuitBreakerState(name string, state string)
+	IncrementCircuitBreakerRejection(name string)
+}
+
+// CircuitBreakerConfig configuração de um circuit breaker
+type CircuitBreakerConfig struct {
+	FailureThreshold int
+	SuccessThreshold int
+	Timeout          time.Duration
+	// HalfOpenMaxCalls chamadas de teste simultâneas permitidas em HALF_OPEN
+	HalfOpenMaxCalls int
+}
+
 // CircuitBreaker implementa o padrão Circuit Breaker para RPC
 type CircuitBreaker struct {
-	mu                 sync.RWMutex
+	mu                 sync.Mutex
+	name               string
 	state              CircuitBreakerState
 	failures           int
 	successes          int
+	halfOpenInFlight   int
 	lastFailureTime    time.Time
 	failureThreshold   int
 	successThreshold   int
 	timeout            time.Duration
 	halfOpenMaxRetries int
+	metrics            BreakerMetrics
 	logger             *zap.Logger
 }
 
@@ -41,50 +63,67 @@ func NewCircuitBreaker(
 	timeout time.Duration,
 	logger *zap.Logger,
 ) *CircuitBreaker {
-	return &CircuitBreaker{
+	return NewNamedCircuitBreaker("", CircuitBreakerConfig{
+		FailureThreshold: failureThreshold,
+		SuccessThreshold: successThreshold,
+		Timeout:          timeout,
+		HalfOpenMaxCalls: 1,
+	}, nil, logger)
+}
+
+// NewNamedCircuitBreaker cria um circuit breaker identificado nos logs e métricas (ex.: "ETHEREUM/rpc.host"); metrics pode ser nil
+func NewNamedCircuitBreaker(name string, config CircuitBreakerConfig, metrics BreakerMetrics, logger *zap.Logger) *CircuitBreaker {
+	if config.HalfOpenMaxCalls <= 0 {
+		config.HalfOpenMaxCalls = 1
+	}
+	if name != "" {
+		logger = logger.With(zap.String("circuit_breaker", name))
+	}
+
+	cb := &CircuitBreaker{
+		name:               name,
 		state:              StateClosed,
-		failures:           0,
-		successes:          0,
-		failureThreshold:   failureThreshold,
-		successThreshold:   successThreshold,
-		timeout:            timeout,
-		halfOpenMaxRetries: 1,
+		failureThreshold:   config.FailureThreshold,
+		successThreshold:   config.SuccessThreshold,
+		timeout:            config.Timeout,
+		halfOpenMaxRetries: config.HalfOpenMaxCalls,
+		metrics:            metrics,
 		logger:             logger,
 	}
+	if metrics != nil {
+		metrics.SetCircuitBreakerState(name, string(StateClosed))
+	}
+	return cb
+}
+
+// Name retorna o identificador do circuit breaker
+func (cb *CircuitBreaker) Name() string {
+	return cb.name
 }
 
 // State retorna o estado atual do circuit breaker
 func (cb *CircuitBreaker) State() CircuitBreakerState {
-	cb.mu.RLock()
-	state := cb.state
-	lastFailureTime := cb.lastFailureTime
-	timeout := cb.timeout
-	cb.mu.RUnlock()
-
-	// Se está OPEN e passou o timeout, transicionar para HALF_OPEN
-	if state == StateOpen && time.Since(lastFailureTime) > timeout {
-		cb.mu.Lock()
-		defer cb.mu.Unlock()
-
-		cb.state = StateHalfOpen
-		cb.successes = 0
-		cb.logger.Info("circuit breaker transitioned to HALF_OPEN")
-		return StateHalfOpen
-	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
-	return state
+	cb.refreshState()
+	return cb.state
 }
 
-// Call executa uma função respeitando o estado do circuit breaker
+// Call executa uma função respeitando o estado do circuit breaker; chamadas rejeitadas retornam ErrRPCUnavailable
 func (cb *CircuitBreaker) Call(fn func() error) error {
-	state := cb.State()
-
-	if state == StateOpen {
-		cb.logger.Warn("circuit breaker is OPEN, rejecting call")
-		return fmt.Errorf("circuit breaker is open")
+	probe, err := cb.acquire()
+	if err != nil {
+		return err
 	}
 
-	err := fn()
+	err = fn()
+
+	if probe {
+		cb.mu.Lock()
+		cb.halfOpenInFlight--
+		cb.mu.Unlock()
+	}
 
 	if err != nil {
 		cb.RecordFailure()
@@ -95,6 +134,68 @@ func (cb *CircuitBreaker) Call(fn func() error) error {
 	return nil
 }
 
+// acquire decide se a chamada pode seguir; probe indica que ela ocupa uma vaga de teste em HALF_OPEN
+func (cb *CircuitBreaker) acquire() (probe bool, err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refreshState()
+
+	switch cb.state {
+	case StateOpen:
+		return false, cb.reject()
+	case StateHalfOpen:
+		if cb.halfOpenInFlight >= cb.halfOpenMaxRetries {
+			return false, cb.reject()
+		}
+		cb.halfOpenInFlight++
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// reject registra a rejeição; chamado com o lock
+func (cb *CircuitBreaker) reject() error {
+	cb.logger.Warn("circuit breaker rejecting call", zap.String("state", string(cb.state)))
+	if cb.metrics != nil {
+		cb.metrics.IncrementCircuitBreakerRejection(cb.name)
+	}
+	if cb.name == "" {
+		return fmt.Errorf("%w: circuit breaker is %s", ErrRPCUnavailable, cb.state)
+	}
+	return fmt.Errorf("%w: circuit breaker %s is %s", ErrRPCUnavailable, cb.name, cb.state)
+}
+
+// refreshState passa de OPEN para HALF_OPEN após o timeout; chamado com o lock
+func (cb *CircuitBreaker) refreshState() {
+	if cb.state == StateOpen && time.Since(cb.lastFailureTime) > cb.timeout {
+		cb.successes = 0
+		cb.halfOpenInFlight = 0
+		cb.transition(StateHalfOpen)
+	}
+}
+
+// transition muda o estado, registrando log e métrica; chamado com o lock
+func (cb *CircuitBreaker) transition(state CircuitBreakerState) {
+	if cb.state == state {
+		return
+	}
+
+	previous := cb.state
+	cb.state = state
+
+	fields := []zap.Field{zap.String("from", string(previous)), zap.String("to", string(state))}
+	if state == StateOpen {
+		cb.logger.Error("circuit breaker state changed", append(fields, zap.Int("failures", cb.failures))...)
+	} else {
+		cb.logger.Info("circuit breaker state changed", fields...)
+	}
+	if cb.metrics != nil {
+		cb.metrics.SetCircuitBreakerState(cb.name, string(state))
+	}
+}
+
 // RecordSuccess registra uma execução bem-sucedida
 func (cb *CircuitBreaker) RecordSuccess() {
 	cb.mu.Lock()
@@ -105,17 +206,16 @@ func (cb *CircuitBreaker) RecordSuccess() {
 		cb.logger.Info("circuit breaker half-open success", zap.Int("successes", cb.successes))
 
 		if cb.successes >= cb.successThreshold {
-			cb.state = StateClosed
 			cb.failures = 0
 			cb.successes = 0
-			cb.logger.Info("circuit breaker CLOSED after successful recovery")
+			cb.transition(StateClosed)
 		}
 	} else if cb.state == StateClosed {
 		cb.failures = 0
 	}
 }
 
-// RecordFailure registra uma falha
+// RecordFailure registra uma falha; em HALF_OPEN qualquer falha reabre o circuito
 func (cb *CircuitBreaker) RecordFailure() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
@@ -127,9 +227,9 @@ func (cb *CircuitBreaker) RecordFailure() {
 		zap.Int("failures", cb.failures),
 		zap.Int("threshold", cb.failureThreshold))
 
-	if cb.failures >= cb.failureThreshold {
-		cb.state = StateOpen
-		cb.logger.Error("circuit breaker OPEN after too many failures")
+	if cb.state == StateHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.successes = 0
+		cb.transition(StateOpen)
 	}
 }
 
@@ -138,8 +238,9 @@ func (cb *CircuitBreaker) Reset() {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
 
-	cb.state = StateClosed
 	cb.failures = 0
 	cb.successes = 0
+	cb.halfOpenInFlight = 0
+	cb.transition(StateClosed)
 	cb.logger.Info("circuit breaker reset to CLOSED")
 }
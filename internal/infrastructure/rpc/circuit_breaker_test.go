@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -107,3 +108,103 @@ func TestCircuitBreakerRecordSuccessWhenClosed(t *testing.T) {
 	cb.RecordFailure()
 	assert.Equal(t, StateClosed, cb.State())
 }
+
+// mockBreakerMetrics registra as transições e rejeições informadas pelo breaker
+type mockBreakerMetrics struct {
+	mu         sync.Mutex
+	states     []string
+	rejections int
+}
+
+func (m *mockBreakerMetrics) SetCircuitBreakerState(name string, state string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states = append(m.states, name+"="+state)
+}
+
+func (m *mockBreakerMetrics) IncrementCircuitBreakerRejection(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejections++
+}
+
+func TestCircuitBreakerRejectsWithErrRPCUnavailable(t *testing.T) {
+	breakerMetrics := &mockBreakerMetrics{}
+	cb := NewNamedCircuitBreaker("ETHEREUM/rpc.example.com", CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 1,
+		Timeout:          time.Minute,
+	}, breakerMetrics, zap.NewNop())
+
+	require.Error(t, cb.Call(func() error { return fmt.Errorf("connection refused") }))
+
+	called := false
+	err := cb.Call(func() error {
+		called = true
+		return nil
+	})
+
+	require.ErrorIs(t, err, ErrRPCUnavailable)
+	assert.Contains(t, err.Error(), "ETHEREUM/rpc.example.com")
+	assert.False(t, called)
+	assert.Equal(t, []string{"ETHEREUM/rpc.example.com=CLOSED", "ETHEREUM/rpc.example.com=OPEN"}, breakerMetrics.states)
+	assert.Equal(t, 1, breakerMetrics.rejections)
+}
+
+func TestCircuitBreakerHalfOpenLimitsConcurrentProbes(t *testing.T) {
+	cb := NewNamedCircuitBreaker("", CircuitBreakerConfig{
+		FailureThreshold: 1,
+		SuccessThreshold: 1,
+		Timeout:          10 * time.Millisecond,
+		HalfOpenMaxCalls: 2,
+	}, nil, zap.NewNop())
+
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = cb.Call(func() error {
+				started <- struct{}{}
+				<-release
+				return nil
+			})
+		}()
+	}
+	<-started
+	<-started
+
+	// As duas vagas de teste estão ocupadas
+	err := cb.Call(func() error { return nil })
+	require.ErrorIs(t, err, ErrRPCUnavailable)
+	assert.Equal(t, StateHalfOpen, cb.State())
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, StateClosed, cb.State())
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	cb := NewNamedCircuitBreaker("", CircuitBreakerConfig{
+		FailureThreshold: 3,
+		SuccessThreshold: 2,
+		Timeout:          10 * time.Millisecond,
+	}, nil, zap.NewNop())
+
+	cb.RecordFailure()
+	cb.RecordFailure()
+	cb.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, StateHalfOpen, cb.State())
+
+	require.NoError(t, cb.Call(func() error { return nil }))
+	assert.Equal(t, StateHalfOpen, cb.State())
+
+	require.Error(t, cb.Call(func() error { return fmt.Errorf("timeout") }))
+	assert.Equal(t, StateOpen, cb.State())
+}
@@ -2,46 +2,28 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
-// mockCallMsg implementa a interface necessária para EstimateGas
-type mockCallMsg struct {
-	from  common.Address
-	to    *common.Address
-	data  []byte
-	value *big.Int
-}
-
-func (m mockCallMsg) GetFrom() common.Address {
-	return m.from
-}
-
-func (m mockCallMsg) GetTo() *common.Address {
-	return m.to
-}
-
-func (m mockCallMsg) GetData() []byte {
-	return m.data
-}
-
-func (m mockCallMsg) GetValue() *big.Int {
-	return m.value
-}
-
 func TestNewEVMRPCClient_InvalidURL(t *testing.T) {
 	t.Parallel()
 
 	logger, _ := zap.NewDevelopment()
 
 	// URL inválida deve retornar erro
-	client, err := NewEVMRPCClient("invalid://url", 30*time.Second, logger)
+	client, err := NewEVMRPCClient("invalid://url", 30*time.Second, nil, logger)
 
 	assert.Error(t, err)
 	assert.Nil(t, client)
@@ -55,7 +37,7 @@ func TestNewEVMRPCClient_Success(t *testing.T) {
 
 	// Valid http URL (will fail to connect but will test the success path of validation)
 	// We expect this to fail since we don't have a real RPC endpoint, but it tests the URL parsing
-	client, err := NewEVMRPCClient("http://localhost:8545", 30*time.Second, logger)
+	client, err := NewEVMRPCClient("http://localhost:8545", 30*time.Second, nil, logger)
 
 	// This might error but should not error on URL parsing
 	if err != nil {
@@ -66,133 +48,142 @@ func TestNewEVMRPCClient_Success(t *testing.T) {
 	}
 }
 
-func TestEstimateGas_NilData(t *testing.T) {
-	t.Parallel()
+// revertDataError simula o erro JSON-RPC do go-ethereum com o campo data
+type revertDataError struct {
+	data string
+}
 
-	logger, _ := zap.NewDevelopment()
-	mockClient := new(MockEthClient)
+func (e revertDataError) Error() string          { return "execution reverted" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+// encodeRevertReason codifica Error(string) como um contrato faria
+func encodeRevertReason(t *testing.T, reason string) string {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return hexutil.Encode(append([]byte{0x08, 0xc3, 0x79, 0xa0}, packed...))
+}
 
-	rpcClient := &EVMRPCClient{
+func newEstimateGasClient() (*MockEthClient, *EVMRPCClient) {
+	mockClient := new(MockEthClient)
+	return mockClient, &EVMRPCClient{
 		client:  mockClient,
 		rpcURL:  "http://localhost:8545",
 		timeout: 30 * time.Second,
-		logger:  logger,
-	}
-
-	msg := mockCallMsg{
-		from:  common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"),
-		to:    nil,
-		data:  nil,
-		value: big.NewInt(0),
+		logger:  zap.NewNop(),
 	}
-
-	gas, err := rpcClient.EstimateGas(context.Background(), msg)
-
-	assert.NoError(t, err)
-	assert.Equal(t, uint64(21000), gas, "Gas for nil data should be base gas (21000)")
 }
 
-func TestEstimateGas_EmptyData(t *testing.T) {
+func TestEstimateGas_CallsNode(t *testing.T) {
 	t.Parallel()
 
-	logger, _ := zap.NewDevelopment()
-	mockClient := new(MockEthClient)
+	mockClient, rpcClient := newEstimateGasClient()
 
-	rpcClient := &EVMRPCClient{
-		client:  mockClient,
-		rpcURL:  "http://localhost:8545",
-		timeout: 30 * time.Second,
-		logger:  logger,
-	}
-
-	msg := mockCallMsg{
-		from:  common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"),
-		to:    nil,
-		data:  []byte{},
-		value: big.NewInt(0),
+	to := common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+	msg := CallMsg{
+		From:  common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"),
+		To:    &to,
+		Data:  []byte{0x01, 0x02, 0x03, 0x04},
+		Value: big.NewInt(10),
 	}
+	mockClient.On("EstimateGas", mock.Anything, ethereum.CallMsg{
+		From:  msg.From,
+		To:    msg.To,
+		Data:  msg.Data,
+		Value: msg.Value,
+	}).Return(uint64(53000), nil)
 
 	gas, err := rpcClient.EstimateGas(context.Background(), msg)
 
 	assert.NoError(t, err)
-	assert.Equal(t, uint64(21000), gas, "Gas for empty data should be base gas (21000)")
+	assert.Equal(t, uint64(53000), gas)
+	mockClient.AssertExpectations(t)
 }
 
-func TestEstimateGas_WithData(t *testing.T) {
+func TestEstimateGas_RevertWithData(t *testing.T) {
 	t.Parallel()
 
-	logger, _ := zap.NewDevelopment()
-	mockClient := new(MockEthClient)
+	mockClient, rpcClient := newEstimateGasClient()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).
+		Return(uint64(0), revertDataError{data: encodeRevertReason(t, "ERC20: insufficient allowance")})
 
-	rpcClient := &EVMRPCClient{
-		client:  mockClient,
-		rpcURL:  "http://localhost:8545",
-		timeout: 30 * time.Second,
-		logger:  logger,
-	}
-
-	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05}
-	msg := mockCallMsg{
-		from:  common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"),
-		to:    nil,
-		data:  data,
-		value: big.NewInt(0),
-	}
+	gas, err := rpcClient.EstimateGas(context.Background(), CallMsg{})
 
-	gas, err := rpcClient.EstimateGas(context.Background(), msg)
+	assert.Equal(t, uint64(0), gas)
+	assert.ErrorIs(t, err, ErrExecutionReverted)
 
-	assert.NoError(t, err)
-	expectedGas := uint64(21000) + uint64(len(data)*4)
-	assert.Equal(t, expectedGas, gas, "Gas should be base + 4 per byte")
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	assert.Equal(t, "ERC20: insufficient allowance", revertErr.Reason)
+	assert.Equal(t, "execution reverted: ERC20: insufficient allowance", err.Error())
 }
 
-func TestEstimateGas_LargeData(t *testing.T) {
+func TestEstimateGas_RevertWithoutData(t *testing.T) {
 	t.Parallel()
 
-	logger, _ := zap.NewDevelopment()
-	mockClient := new(MockEthClient)
+	mockClient, rpcClient := newEstimateGasClient()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).
+		Return(uint64(0), errors.New("execution reverted: Ownable: caller is not the owner"))
 
-	rpcClient := &EVMRPCClient{
-		client:  mockClient,
-		rpcURL:  "http://localhost:8545",
-		timeout: 30 * time.Second,
-		logger:  logger,
-	}
+	_, err := rpcClient.EstimateGas(context.Background(), CallMsg{})
 
-	data := make([]byte, 1000)
-	msg := mockCallMsg{
-		from:  common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0"),
-		to:    nil,
-		data:  data,
-		value: big.NewInt(0),
-	}
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	assert.Equal(t, "Ownable: caller is not the owner", revertErr.Reason)
+}
 
-	gas, err := rpcClient.EstimateGas(context.Background(), msg)
+func TestEstimateGas_RPCError(t *testing.T) {
+	t.Parallel()
 
-	assert.NoError(t, err)
-	expectedGas := uint64(21000) + uint64(1000*4)
-	assert.Equal(t, expectedGas, gas, "Gas should scale with data size")
+	mockClient, rpcClient := newEstimateGasClient()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).
+		Return(uint64(0), errors.New("connection refused"))
+
+	gas, err := rpcClient.EstimateGas(context.Background(), CallMsg{})
+
+	assert.Equal(t, uint64(0), gas)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrExecutionReverted)
+	assert.Contains(t, err.Error(), "failed to estimate gas")
 }
 
-func TestEstimateGas_InvalidMessageType(t *testing.T) {
+func TestCallContract(t *testing.T) {
 	t.Parallel()
 
-	logger, _ := zap.NewDevelopment()
-	mockClient := new(MockEthClient)
+	t.Run("return call output", func(t *testing.T) {
+		mockClient, rpcClient := newEstimateGasClient()
+		to := common.HexToAddress("0x8ba1f109551bD432803012645Ac136ddd64DBA72")
+		mockClient.On("CallContract", mock.Anything, ethereum.CallMsg{To: &to, Data: []byte{0x70, 0xa0, 0x82, 0x31}}, (*big.Int)(nil)).
+			Return([]byte{0x01}, nil)
 
-	rpcClient := &EVMRPCClient{
-		client:  mockClient,
-		rpcURL:  "http://localhost:8545",
-		timeout: 30 * time.Second,
-		logger:  logger,
-	}
+		output, err := rpcClient.CallContract(context.Background(), CallMsg{To: &to, Data: []byte{0x70, 0xa0, 0x82, 0x31}})
 
-	// Passar um tipo inválido
-	invalidMsg := "invalid message"
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, output)
+	})
 
-	gas, err := rpcClient.EstimateGas(context.Background(), invalidMsg)
+	t.Run("decode revert reason", func(t *testing.T) {
+		mockClient, rpcClient := newEstimateGasClient()
+		mockClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, revertDataError{data: encodeRevertReason(t, "not allowed")})
 
-	assert.Error(t, err)
-	assert.Equal(t, uint64(0), gas)
-	assert.Contains(t, err.Error(), "invalid message type")
+		output, err := rpcClient.CallContract(context.Background(), CallMsg{})
+
+		assert.Nil(t, output)
+		var revertErr *RevertError
+		require.ErrorAs(t, err, &revertErr)
+		assert.Equal(t, "not allowed", revertErr.Reason)
+	})
+
+	t.Run("wrap rpc error", func(t *testing.T) {
+		mockClient, rpcClient := newEstimateGasClient()
+		mockClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("timeout"))
+
+		_, err := rpcClient.CallContract(context.Background(), CallMsg{})
+
+		assert.ErrorContains(t, err, "failed to call contract")
+	})
 }
@@ -17,9 +17,13 @@ type EthClient interface {
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	ChainID(ctx context.Context) (*big.Int, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	BlockNumber(ctx context.Context) (uint64, error)
 	Close()
 }
@@ -59,6 +63,11 @@ func (a *EthClientAdapter) EstimateGas(ctx context.Context, msg ethereum.CallMsg
 	return a.client.EstimateGas(ctx, msg)
 }
 
+// CallContract delega ao cliente real (eth_call)
+func (a *EthClientAdapter) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return a.client.CallContract(ctx, msg, blockNumber)
+}
+
 // TransactionReceipt delega ao cliente real
 func (a *EthClientAdapter) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	return a.client.TransactionReceipt(ctx, txHash)
@@ -74,6 +83,21 @@ func (a *EthClientAdapter) SuggestGasPrice(ctx context.Context) (*big.Int, error
 	return a.client.SuggestGasPrice(ctx)
 }
 
+// SuggestGasTipCap delega ao cliente real (eth_maxPriorityFeePerGas)
+func (a *EthClientAdapter) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return a.client.SuggestGasTipCap(ctx)
+}
+
+// FeeHistory delega ao cliente real (eth_feeHistory)
+func (a *EthClientAdapter) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return a.client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
+
+// HeaderByNumber delega ao cliente real
+func (a *EthClientAdapter) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return a.client.HeaderByNumber(ctx, number)
+}
+
 // BlockNumber delega ao cliente real
 func (a *EthClientAdapter) BlockNumber(ctx context.Context) (uint64, error) {
 	return a.client.BlockNumber(ctx)
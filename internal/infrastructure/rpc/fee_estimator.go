@@ -0,0 +1,210 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// ErrFeeCapExceeded base fee atual acima do teto configurado para a chain
+var ErrFeeCapExceeded = errors.New("base fee exceeds configured max fee cap")
+
+// FeeSpeed perfil de prioridade da taxa
+type FeeSpeed string
+
+const (
+	FeeSpeedSlow     FeeSpeed = "slow"
+	FeeSpeedStandard FeeSpeed = "standard"
+	FeeSpeedFast     FeeSpeed = "fast"
+)
+
+// NewFeeSpeed valida um perfil de prioridade
+func NewFeeSpeed(value string) (FeeSpeed, error) {
+	speed := FeeSpeed(value)
+	switch speed {
+	case FeeSpeedSlow, FeeSpeedStandard, FeeSpeedFast:
+		return speed, nil
+	default:
+		return "", fmt.Errorf("invalid fee speed: %s", value)
+	}
+}
+
+// FeeStrategy configuração de estimativa de taxas de uma chain
+type FeeStrategy struct {
+	// DefaultSpeed perfil usado quando o payload não informa fee_speed
+	DefaultSpeed FeeSpeed
+	// RewardPercentiles percentil de eth_feeHistory usado para a gorjeta de cada perfil
+	RewardPercentiles map[FeeSpeed]float64
+	// HistoryBlocks quantidade de blocos consultados em eth_feeHistory
+	HistoryBlocks uint64
+	// BaseFeeMultiplier margem sobre o base fee atual para absorver aumentos nos próximos blocos
+	BaseFeeMultiplier int64
+	// MaxFeeCap teto de maxFeePerGas (ou gasPrice em transações legadas); nil = sem teto
+	MaxFeeCap *big.Int
+	// LegacyOnly força transações legadas mesmo em chains com EIP-1559
+	LegacyOnly bool
+}
+
+// DefaultFeeStrategy estratégia padrão: percentis 10/50/90, 10 blocos e 2x base fee
+func DefaultFeeStrategy() FeeStrategy {
+	return FeeStrategy{
+		DefaultSpeed: FeeSpeedStandard,
+		RewardPercentiles: map[FeeSpeed]float64{
+			FeeSpeedSlow:     10,
+			FeeSpeedStandard: 50,
+			FeeSpeedFast:     90,
+		},
+		HistoryBlocks:     10,
+		BaseFeeMultiplier: 2,
+	}
+}
+
+// FeeData taxas calculadas para uma transação
+type FeeData struct {
+	// GasPrice preenchido apenas em transações legadas
+	GasPrice *big.Int
+	// MaxFeePerGas e MaxPriorityFeePerGas preenchidos em transações EIP-1559
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// IsDynamic indica se as taxas são de uma transação EIP-1559
+func (f *FeeData) IsDynamic() bool {
+	return f.MaxFeePerGas != nil
+}
+
+// FeeEstimator calcula as taxas de uma transação
+type FeeEstimator interface {
+	EstimateFees(ctx context.Context, speed FeeSpeed) (*FeeData, error)
+}
+
+// FeeMarketEstimator estima taxas a partir do base fee, eth_feeHistory e eth_maxPriorityFeePerGas
+type FeeMarketEstimator struct {
+	client   RPCClient
+	strategy FeeStrategy
+	logger   *zap.Logger
+}
+
+// NewFeeMarketEstimator cria um estimador de taxas para a chain do cliente
+func NewFeeMarketEstimator(client RPCClient, strategy FeeStrategy, logger *zap.Logger) *FeeMarketEstimator {
+	defaults := DefaultFeeStrategy()
+	if strategy.DefaultSpeed == "" {
+		strategy.DefaultSpeed = defaults.DefaultSpeed
+	}
+	if strategy.RewardPercentiles == nil {
+		strategy.RewardPercentiles = defaults.RewardPercentiles
+	}
+	if strategy.HistoryBlocks == 0 {
+		strategy.HistoryBlocks = defaults.HistoryBlocks
+	}
+	if strategy.BaseFeeMultiplier <= 0 {
+		strategy.BaseFeeMultiplier = defaults.BaseFeeMultiplier
+	}
+
+	return &FeeMarketEstimator{
+		client:   client,
+		strategy: strategy,
+		logger:   logger,
+	}
+}
+
+// EstimateFees calcula as taxas; chains sem base fee recebem taxas legadas
+func (e *FeeMarketEstimator) EstimateFees(ctx context.Context, speed FeeSpeed) (*FeeData, error) {
+	if speed == "" {
+		speed = e.strategy.DefaultSpeed
+	}
+
+	if e.strategy.LegacyOnly {
+		return e.estimateLegacy(ctx)
+	}
+
+	baseFee, err := e.client.GetBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee == nil {
+		e.logger.Debug("chain does not support EIP-1559, using legacy gas price")
+		return e.estimateLegacy(ctx)
+	}
+
+	tip, err := e.priorityFee(ctx, speed)
+	if err != nil {
+		return nil, err
+	}
+
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(e.strategy.BaseFeeMultiplier))
+	maxFee.Add(maxFee, tip)
+
+	if limit := e.strategy.MaxFeeCap; limit != nil && maxFee.Cmp(limit) > 0 {
+		if baseFee.Cmp(limit) > 0 {
+			return nil, fmt.Errorf("%w: base fee %s, cap %s", ErrFeeCapExceeded, baseFee, limit)
+		}
+		maxFee = new(big.Int).Set(limit)
+		if tip.Cmp(maxFee) > 0 {
+			tip = new(big.Int).Set(maxFee)
+		}
+	}
+
+	e.logger.Debug("dynamic fees estimated",
+		zap.String("speed", string(speed)),
+		zap.String("base_fee", baseFee.String()),
+		zap.String("max_fee_per_gas", maxFee.String()),
+		zap.String("max_priority_fee_per_gas", tip.String()))
+
+	return &FeeData{
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: tip,
+	}, nil
+}
+
+// priorityFee usa a mediana do percentil do perfil em eth_feeHistory; sem histórico, usa eth_maxPriorityFeePerGas
+func (e *FeeMarketEstimator) priorityFee(ctx context.Context, speed FeeSpeed) (*big.Int, error) {
+	percentile, ok := e.strategy.RewardPercentiles[speed]
+	if !ok {
+		return nil, fmt.Errorf("no reward percentile configured for fee speed %s", speed)
+	}
+
+	history, err := e.client.GetFeeHistory(ctx, e.strategy.HistoryBlocks, []float64{percentile})
+	if err != nil {
+		e.logger.Warn("fee history unavailable, using eth_maxPriorityFeePerGas", zap.Error(err))
+	} else if tip := medianReward(history.Reward); tip != nil {
+		return tip, nil
+	}
+
+	return e.client.GetMaxPriorityFeePerGas(ctx)
+}
+
+func (e *FeeMarketEstimator) estimateLegacy(ctx context.Context) (*FeeData, error) {
+	gasPrice, err := e.client.GetGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := e.strategy.MaxFeeCap; limit != nil && gasPrice.Cmp(limit) > 0 {
+		return nil, fmt.Errorf("%w: gas price %s, cap %s", ErrFeeCapExceeded, gasPrice, limit)
+	}
+
+	return &FeeData{GasPrice: gasPrice}, nil
+}
+
+// medianReward mediana das recompensas (primeiro percentil de cada bloco)
+func medianReward(rewards [][]*big.Int) *big.Int {
+	values := make([]*big.Int, 0, len(rewards))
+	for _, blockRewards := range rewards {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			values = append(values, blockRewards[0])
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	return new(big.Int).Set(values[len(values)/2])
+}
@@ -0,0 +1,164 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newFeeTestClient() (*MockEthClient, *EVMRPCClient) {
+	mockClient := new(MockEthClient)
+	return mockClient, &EVMRPCClient{client: mockClient, logger: zap.NewNop(), timeout: time.Second}
+}
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1_000_000_000))
+}
+
+func TestNewFeeSpeed(t *testing.T) {
+	speed, err := NewFeeSpeed("fast")
+	assert.NoError(t, err)
+	assert.Equal(t, FeeSpeedFast, speed)
+
+	_, err = NewFeeSpeed("instant")
+	assert.Error(t, err)
+}
+
+func TestFeeMarketEstimator_EstimateFees(t *testing.T) {
+	t.Run("dynamic fees from base fee and fee history percentile", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: gwei(20)}, nil)
+		mockClient.On("FeeHistory", mock.Anything, uint64(10), (*big.Int)(nil), []float64{90}).Return(&ethereum.FeeHistory{
+			Reward: [][]*big.Int{{gwei(1)}, {gwei(3)}, {gwei(2)}},
+		}, nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedFast)
+
+		require.NoError(t, err)
+		assert.True(t, fees.IsDynamic())
+		assert.Nil(t, fees.GasPrice)
+		assert.Equal(t, gwei(2), fees.MaxPriorityFeePerGas)
+		assert.Equal(t, gwei(42), fees.MaxFeePerGas) // 2 * 20 + 2
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("default speed comes from strategy", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: gwei(10)}, nil)
+		mockClient.On("FeeHistory", mock.Anything, uint64(5), (*big.Int)(nil), []float64{25}).Return(&ethereum.FeeHistory{
+			Reward: [][]*big.Int{{gwei(1)}},
+		}, nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{
+			DefaultSpeed:      FeeSpeedSlow,
+			RewardPercentiles: map[FeeSpeed]float64{FeeSpeedSlow: 25},
+			HistoryBlocks:     5,
+			BaseFeeMultiplier: 3,
+		}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, gwei(31), fees.MaxFeePerGas)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("fall back to eth_maxPriorityFeePerGas without fee history", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: gwei(10)}, nil)
+		mockClient.On("FeeHistory", mock.Anything, uint64(10), (*big.Int)(nil), []float64{50}).Return(nil, errors.New("method not found"))
+		mockClient.On("SuggestGasTipCap", mock.Anything).Return(gwei(1), nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		require.NoError(t, err)
+		assert.Equal(t, gwei(1), fees.MaxPriorityFeePerGas)
+		assert.Equal(t, gwei(21), fees.MaxFeePerGas)
+	})
+
+	t.Run("legacy gas price when chain has no base fee", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{}, nil)
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(5), nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		require.NoError(t, err)
+		assert.False(t, fees.IsDynamic())
+		assert.Equal(t, gwei(5), fees.GasPrice)
+	})
+
+	t.Run("legacy only strategy skips fee market", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(3), nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{LegacyOnly: true}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedFast)
+
+		require.NoError(t, err)
+		assert.Equal(t, gwei(3), fees.GasPrice)
+		mockClient.AssertNotCalled(t, "HeaderByNumber", mock.Anything, mock.Anything)
+	})
+
+	t.Run("max fee is capped", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: gwei(40)}, nil)
+		mockClient.On("FeeHistory", mock.Anything, uint64(10), (*big.Int)(nil), []float64{50}).Return(&ethereum.FeeHistory{
+			Reward: [][]*big.Int{{gwei(5)}},
+		}, nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{MaxFeeCap: gwei(50)}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		require.NoError(t, err)
+		assert.Equal(t, gwei(50), fees.MaxFeePerGas)
+		assert.Equal(t, gwei(5), fees.MaxPriorityFeePerGas)
+	})
+
+	t.Run("fail when base fee exceeds cap", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: gwei(80)}, nil)
+		mockClient.On("FeeHistory", mock.Anything, uint64(10), (*big.Int)(nil), []float64{50}).Return(&ethereum.FeeHistory{
+			Reward: [][]*big.Int{{gwei(1)}},
+		}, nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{MaxFeeCap: gwei(50)}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		assert.Nil(t, fees)
+		assert.ErrorIs(t, err, ErrFeeCapExceeded)
+	})
+
+	t.Run("fail when legacy gas price exceeds cap", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(60), nil)
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{LegacyOnly: true, MaxFeeCap: gwei(50)}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		assert.Nil(t, fees)
+		assert.ErrorIs(t, err, ErrFeeCapExceeded)
+	})
+
+	t.Run("fail when header is unavailable", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(nil, errors.New("timeout"))
+
+		estimator := NewFeeMarketEstimator(client, FeeStrategy{}, zap.NewNop())
+		fees, err := estimator.EstimateFees(context.Background(), FeeSpeedStandard)
+
+		assert.Nil(t, fees)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,154 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrExecutionReverted a execução simulada pelo node reverteu
+	ErrExecutionReverted = errors.New("execution reverted")
+	// ErrGasLimitExceeded estimativa do node acima do gas limit máximo configurado
+	ErrGasLimitExceeded = errors.New("gas estimate exceeds configured max gas limit")
+)
+
+// RevertError revert retornado pelo node, com o motivo decodificado quando disponível
+type RevertError struct {
+	// Reason string de Error(string) ou descrição de Panic(uint256)
+	Reason string
+	// Data dados brutos do revert (ex.: custom errors)
+	Data []byte
+	Err  error
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("execution reverted: %s", hexutil.Encode(e.Data))
+	}
+	return "execution reverted"
+}
+
+// Is permite errors.Is(err, ErrExecutionReverted)
+func (e *RevertError) Is(target error) bool {
+	return target == ErrExecutionReverted
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.Err
+}
+
+// dataError erro JSON-RPC com campo data (implementado pelo cliente RPC do go-ethereum)
+type dataError interface {
+	ErrorData() interface{}
+}
+
+// parseRevertError converte o erro do node em *RevertError; retorna nil se não for um revert
+func parseRevertError(err error) *RevertError {
+	if err == nil {
+		return nil
+	}
+
+	revertErr := &RevertError{Err: err}
+
+	var withData dataError
+	if errors.As(err, &withData) {
+		if raw, ok := withData.ErrorData().(string); ok {
+			if data, decodeErr := hexutil.Decode(raw); decodeErr == nil && len(data) > 0 {
+				revertErr.Data = data
+				if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+					revertErr.Reason = reason
+				}
+				return revertErr
+			}
+		}
+	}
+
+	// Nodes sem campo data: "execution reverted: <motivo>"
+	msg := err.Error()
+	idx := strings.Index(msg, ErrExecutionReverted.Error())
+	if idx < 0 {
+		return nil
+	}
+	revertErr.Reason = strings.TrimSpace(strings.TrimPrefix(msg[idx+len(ErrExecutionReverted.Error()):], ":"))
+	return revertErr
+}
+
+// GasStrategy margens aplicadas à estimativa de gas de uma chain
+type GasStrategy struct {
+	// Multiplier margem sobre eth_estimateGas (ex.: 1.2 = +20%)
+	Multiplier float64
+	// MinGasLimit gas limit mínimo enviado
+	MinGasLimit uint64
+	// MaxGasLimit gas limit máximo; estimativas acima dele falham (0 = sem teto)
+	MaxGasLimit uint64
+}
+
+// DefaultGasStrategy estratégia padrão: +20% sobre a estimativa, mínimo de 21000 e sem teto
+func DefaultGasStrategy() GasStrategy {
+	return GasStrategy{
+		Multiplier:  1.2,
+		MinGasLimit: 21000,
+	}
+}
+
+// GasEstimator calcula o gas limit de uma transação
+type GasEstimator interface {
+	EstimateGasLimit(ctx context.Context, msg CallMsg) (uint64, error)
+}
+
+// NodeGasEstimator estima o gas via eth_estimateGas e aplica a margem e os limites da chain
+type NodeGasEstimator struct {
+	client   RPCClient
+	strategy GasStrategy
+	logger   *zap.Logger
+}
+
+// NewNodeGasEstimator cria um estimador de gas para a chain do cliente
+func NewNodeGasEstimator(client RPCClient, strategy GasStrategy, logger *zap.Logger) *NodeGasEstimator {
+	if strategy.Multiplier < 1 {
+		strategy.Multiplier = 1
+	}
+
+	return &NodeGasEstimator{
+		client:   client,
+		strategy: strategy,
+		logger:   logger,
+	}
+}
+
+// EstimateGasLimit retorna a estimativa do node com margem, dentro dos limites configurados
+func (e *NodeGasEstimator) EstimateGasLimit(ctx context.Context, msg CallMsg) (uint64, error) {
+	estimate, err := e.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+
+	maxGas := e.strategy.MaxGasLimit
+	if maxGas > 0 && estimate > maxGas {
+		return 0, fmt.Errorf("%w: estimate %d, max %d", ErrGasLimitExceeded, estimate, maxGas)
+	}
+
+	gasLimit := uint64(math.Ceil(float64(estimate) * e.strategy.Multiplier))
+	if gasLimit < e.strategy.MinGasLimit {
+		gasLimit = e.strategy.MinGasLimit
+	}
+	if maxGas > 0 && gasLimit > maxGas {
+		gasLimit = maxGas
+	}
+
+	e.logger.Debug("gas limit estimated",
+		zap.Uint64("estimate", estimate),
+		zap.Uint64("gas_limit", gasLimit))
+
+	return gasLimit, nil
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNodeGasEstimator_EstimateGasLimit(t *testing.T) {
+	t.Run("apply multiplier to node estimate", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+
+		estimator := NewNodeGasEstimator(client, DefaultGasStrategy(), zap.NewNop())
+		gas, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(60000), gas)
+	})
+
+	t.Run("raise to min gas limit", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+
+		estimator := NewNodeGasEstimator(client, GasStrategy{Multiplier: 1, MinGasLimit: 30000}, zap.NewNop())
+		gas, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(30000), gas)
+	})
+
+	t.Run("clamp margin to max gas limit", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(900000), nil)
+
+		estimator := NewNodeGasEstimator(client, GasStrategy{Multiplier: 1.5, MaxGasLimit: 1000000}, zap.NewNop())
+		gas, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000000), gas)
+	})
+
+	t.Run("fail when estimate exceeds max gas limit", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(2000000), nil)
+
+		estimator := NewNodeGasEstimator(client, GasStrategy{Multiplier: 1.2, MaxGasLimit: 1000000}, zap.NewNop())
+		gas, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		assert.Equal(t, uint64(0), gas)
+		assert.ErrorIs(t, err, ErrGasLimitExceeded)
+	})
+
+	t.Run("multiplier below one is ignored", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+
+		estimator := NewNodeGasEstimator(client, GasStrategy{Multiplier: 0.5}, zap.NewNop())
+		gas, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50000), gas)
+	})
+
+	t.Run("propagate revert error", func(t *testing.T) {
+		mockClient, client := newEstimateGasClient()
+		mockClient.On("EstimateGas", mock.Anything, mock.Anything).
+			Return(uint64(0), revertDataError{data: encodeRevertReason(t, "paused")})
+
+		estimator := NewNodeGasEstimator(client, DefaultGasStrategy(), zap.NewNop())
+		_, err := estimator.EstimateGasLimit(context.Background(), CallMsg{})
+
+		var revertErr *RevertError
+		require.ErrorAs(t, err, &revertErr)
+		assert.Equal(t, "paused", revertErr.Reason)
+	})
+}
+
+func TestParseRevertError(t *testing.T) {
+	t.Run("not a revert", func(t *testing.T) {
+		assert.Nil(t, parseRevertError(errors.New("timeout")))
+		assert.Nil(t, parseRevertError(nil))
+	})
+
+	t.Run("panic code", func(t *testing.T) {
+		// Panic(uint256) com código 0x11 (overflow aritmético)
+		data := "0x4e487b710000000000000000000000000000000000000000000000000000000000000011"
+		revertErr := parseRevertError(revertDataError{data: data})
+
+		require.NotNil(t, revertErr)
+		assert.Contains(t, revertErr.Reason, "overflow")
+	})
+
+	t.Run("custom error keeps raw data", func(t *testing.T) {
+		revertErr := parseRevertError(revertDataError{data: "0xdeadbeef"})
+
+		require.NotNil(t, revertErr)
+		assert.Empty(t, revertErr.Reason)
+		assert.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, revertErr.Data)
+		assert.Equal(t, "execution reverted: 0xdeadbeef", revertErr.Error())
+	})
+}
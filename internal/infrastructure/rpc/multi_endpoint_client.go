@@ -0,0 +1,549 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// SelectionStrategy ordem em que os endpoints saudáveis de uma chain são tentados
+type SelectionStrategy string
+
+const (
+	// SelectionPriority sempre na ordem configurada; os seguintes são apenas failover
+	SelectionPriority SelectionStrategy = "priority"
+	// SelectionRoundRobin alterna o primeiro endpoint a cada chamada
+	SelectionRoundRobin SelectionStrategy = "round_robin"
+	// SelectionLowestLatency menor latência média primeiro
+	SelectionLowestLatency SelectionStrategy = "lowest_latency"
+)
+
+const (
+	// minHealthyScore abaixo desse score o endpoint só é tentado depois dos saudáveis
+	minHealthyScore = 0.3
+	// latencyWeight peso da última medição na latência média
+	latencyWeight = 0.2
+	// defaultHealthCheckInterval intervalo padrão entre verificações de altura e saúde
+	defaultHealthCheckInterval = 15 * time.Second
+	// defaultHealthCheckTimeout limite da verificação em segundo plano quando não há AttemptTimeout
+	defaultHealthCheckTimeout = 5 * time.Second
+)
+
+// ErrNoEndpoints nenhum endpoint configurado para a chain
+var ErrNoEndpoints = errors.New("no rpc endpoints configured")
+
+// NewSelectionStrategy valida a estratégia; vazio equivale a priority
+func NewSelectionStrategy(value string) (SelectionStrategy, error) {
+	switch strategy := SelectionStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return SelectionPriority, nil
+	case SelectionPriority, SelectionRoundRobin, SelectionLowestLatency:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid rpc selection strategy: %s", value)
+	}
+}
+
+// Endpoint endpoint RPC de uma chain
+type Endpoint struct {
+	// Name identificação nos logs (ver EndpointName)
+	Name   string
+	Client EthClient
+}
+
+// MultiEndpointConfig seleção e verificação dos endpoints de uma chain
+type MultiEndpointConfig struct {
+	Strategy SelectionStrategy
+	// MaxBlockLag blocos de atraso em relação ao endpoint mais adiantado para o endpoint ser evitado (0 = não verifica)
+	MaxBlockLag uint64
+	// HealthCheckInterval intervalo entre as consultas de altura de todos os endpoints
+	HealthCheckInterval time.Duration
+	// AttemptTimeout timeout de cada tentativa antes do failover (0 = só o do contexto)
+	AttemptTimeout time.Duration
+	// Quorum leituras de saldo, nonce, eth_call e receipts por consenso (Required 0 = desativado)
+	Quorum QuorumConfig
+}
+
+// EndpointStatus estado observado de um endpoint
+type EndpointStatus struct {
+	Name        string
+	Score       float64
+	Latency     time.Duration
+	BlockHeight uint64
+	Lagging     bool
+}
+
+type endpointState struct {
+	Endpoint
+	// score média móvel de sucesso (1 = saudável)
+	score       float64
+	latency     time.Duration
+	blockHeight uint64
+}
+
+// EndpointPool EthClient que distribui as chamadas entre os endpoints da chain com failover
+type EndpointPool struct {
+	endpoints []*endpointState
+	config    MultiEndpointConfig
+	logger    *zap.Logger
+
+	mu            sync.Mutex
+	next          int
+	lastCheckedAt time.Time
+	checking      bool
+}
+
+// NewEndpointPool cria o pool na ordem de prioridade informada
+func NewEndpointPool(endpoints []Endpoint, config MultiEndpointConfig, logger *zap.Logger) *EndpointPool {
+	if config.Strategy == "" {
+		config.Strategy = SelectionPriority
+	}
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	states := make([]*endpointState, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		states = append(states, &endpointState{Endpoint: endpoint, score: 1})
+	}
+
+	// Até a primeira verificação as alturas vêm das próprias chamadas (BlockNumber e header mais recente)
+	return &EndpointPool{
+		endpoints:     states,
+		config:        config,
+		logger:        logger,
+		lastCheckedAt: time.Now(),
+	}
+}
+
+// Status retorna o estado atual de cada endpoint, na ordem configurada
+func (p *EndpointPool) Status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	head := p.headLocked()
+	status := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		status = append(status, EndpointStatus{
+			Name:        endpoint.Name,
+			Score:       endpoint.score,
+			Latency:     endpoint.latency,
+			BlockHeight: endpoint.blockHeight,
+			Lagging:     p.laggingLocked(endpoint, head),
+		})
+	}
+	return status
+}
+
+// CheckHealth consulta a altura de todos os endpoints, atualizando score e atraso
+func (p *EndpointPool) CheckHealth(ctx context.Context) {
+	p.mu.Lock()
+	p.lastCheckedAt = time.Now()
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, endpoint := range p.endpoints {
+		wg.Add(1)
+		go func(endpoint *endpointState) {
+			defer wg.Done()
+
+			attemptCtx, cancel := p.attemptContext(ctx)
+			defer cancel()
+
+			start := time.Now()
+			height, err := endpoint.Client.BlockNumber(attemptCtx)
+			if err != nil {
+				p.recordFailure(endpoint, err)
+				return
+			}
+			p.recordSuccess(endpoint, time.Since(start))
+			p.recordHeight(endpoint, height)
+		}(endpoint)
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	head := p.headLocked()
+	for _, endpoint := range p.endpoints {
+		if p.laggingLocked(endpoint, head) {
+			p.logger.Warn("rpc endpoint lagging behind chain head",
+				zap.String("endpoint", endpoint.Name),
+				zap.Uint64("block_height", endpoint.blockHeight),
+				zap.Uint64("head", head))
+		}
+	}
+	p.mu.Unlock()
+}
+
+// maybeCheckHealth dispara a verificação dos endpoints em segundo plano quando o intervalo expirou, sem
+// atrasar a chamada; apenas uma verificação roda por vez
+func (p *EndpointPool) maybeCheckHealth(ctx context.Context) {
+	if len(p.endpoints) < 2 {
+		return
+	}
+
+	p.mu.Lock()
+	due := !p.checking && time.Since(p.lastCheckedAt) >= p.config.HealthCheckInterval
+	if due {
+		p.lastCheckedAt = time.Now()
+		p.checking = true
+	}
+	p.mu.Unlock()
+	if !due {
+		return
+	}
+
+	// A verificação sobrevive ao fim da chamada que a disparou, limitada pelo próprio timeout
+	timeout := p.config.AttemptTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	go func() {
+		defer cancel()
+		p.CheckHealth(checkCtx)
+
+		p.mu.Lock()
+		p.checking = false
+		p.mu.Unlock()
+	}()
+}
+
+// candidates ordena os endpoints: saudáveis pela estratégia, depois os com score baixo e por último os atrasados
+func (p *EndpointPool) candidates() []*endpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	head := p.headLocked()
+	var healthy, unhealthy, lagging []*endpointState
+	for _, endpoint := range p.endpoints {
+		switch {
+		case p.laggingLocked(endpoint, head):
+			lagging = append(lagging, endpoint)
+		case endpoint.score < minHealthyScore:
+			unhealthy = append(unhealthy, endpoint)
+		default:
+			healthy = append(healthy, endpoint)
+		}
+	}
+
+	switch p.config.Strategy {
+	case SelectionRoundRobin:
+		if len(healthy) > 1 {
+			start := p.next % len(healthy)
+			p.next++
+			healthy = append(append([]*endpointState{}, healthy[start:]...), healthy[:start]...)
+		}
+	case SelectionLowestLatency:
+		// Endpoints ainda sem medição (latência zero) são tentados primeiro
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].latency < healthy[j].latency
+		})
+	}
+
+	ordered := make([]*endpointState, 0, len(p.endpoints))
+	ordered = append(ordered, healthy...)
+	ordered = append(ordered, unhealthy...)
+	return append(ordered, lagging...)
+}
+
+// do executa fn no melhor endpoint, passando ao seguinte em falhas de disponibilidade (rede, timeout,
+// circuit breaker aberto); erros respondidos pelo nó são retornados sem failover
+func (p *EndpointPool) do(ctx context.Context, fn func(ctx context.Context, endpoint *endpointState) error) error {
+	if len(p.endpoints) == 0 {
+		return ErrNoEndpoints
+	}
+	p.maybeCheckHealth(ctx)
+
+	var lastErr error
+	for i, endpoint := range p.candidates() {
+		if ctx.Err() != nil {
+			break
+		}
+
+		attemptCtx, cancel := p.attemptContext(ctx)
+		start := time.Now()
+		err := fn(attemptCtx, endpoint)
+		cancel()
+
+		if !isEndpointFailure(err) {
+			p.recordSuccess(endpoint, time.Since(start))
+			if i > 0 {
+				p.logger.Info("rpc call served by failover endpoint",
+					zap.String("endpoint", endpoint.Name),
+					zap.Int("attempt", i+1))
+			}
+			return err
+		}
+
+		p.recordFailure(endpoint, err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return ctx.Err()
+	}
+	return lastErr
+}
+
+// attemptContext aplica o timeout de cada tentativa
+func (p *EndpointPool) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.config.AttemptTimeout > 0 {
+		return context.WithTimeout(ctx, p.config.AttemptTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (p *EndpointPool) recordSuccess(endpoint *endpointState, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	endpoint.score += (1 - endpoint.score) * 0.25
+	if endpoint.latency == 0 {
+		endpoint.latency = latency
+	} else {
+		endpoint.latency = time.Duration((1-latencyWeight)*float64(endpoint.latency) + latencyWeight*float64(latency))
+	}
+}
+
+func (p *EndpointPool) recordFailure(endpoint *endpointState, err error) {
+	p.mu.Lock()
+	endpoint.score *= 0.5
+	score := endpoint.score
+	p.mu.Unlock()
+
+	p.logger.Warn("rpc endpoint failed",
+		zap.String("endpoint", endpoint.Name),
+		zap.Float64("score", score),
+		zap.Error(err))
+}
+
+func (p *EndpointPool) recordHeight(endpoint *endpointState, height uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if height > endpoint.blockHeight {
+		endpoint.blockHeight = height
+	}
+}
+
+// headLocked maior altura observada entre os endpoints; chamado com o lock
+func (p *EndpointPool) headLocked() uint64 {
+	var head uint64
+	for _, endpoint := range p.endpoints {
+		if endpoint.blockHeight > head {
+			head = endpoint.blockHeight
+		}
+	}
+	return head
+}
+
+// laggingLocked indica endpoint atrasado em relação à altura head; altura desconhecida não conta
+func (p *EndpointPool) laggingLocked(endpoint *endpointState, head uint64) bool {
+	return p.config.MaxBlockLag > 0 && endpoint.blockHeight > 0 && head-endpoint.blockHeight > p.config.MaxBlockLag
+}
+
+// IsAlreadyKnown o nó já tem a transação (enviada por uma tentativa anterior que falhou por timeout)
+func IsAlreadyKnown(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "already known")
+}
+
+// BalanceAt executa no pool com failover
+func (p *EndpointPool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		balance, callErr = endpoint.Client.BalanceAt(ctx, account, blockNumber)
+		return callErr
+	})
+	return balance, err
+}
+
+// NonceAt executa no pool com failover
+func (p *EndpointPool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		nonce, callErr = endpoint.Client.NonceAt(ctx, account, blockNumber)
+		return callErr
+	})
+	return nonce, err
+}
+
+// PendingNonceAt executa no pool com failover
+func (p *EndpointPool) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		nonce, callErr = endpoint.Client.PendingNonceAt(ctx, account)
+		return callErr
+	})
+	return nonce, err
+}
+
+// SendTransaction executa no pool com failover; "already known" após uma tentativa falha indica que o
+// envio anterior chegou ao mempool
+func (p *EndpointPool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	attempts := 0
+	return p.do(ctx, func(ctx context.Context, endpoint *endpointState) error {
+		attempts++
+		err := endpoint.Client.SendTransaction(ctx, tx)
+		if attempts > 1 && IsAlreadyKnown(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// EstimateGas executa no pool com failover
+func (p *EndpointPool) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		gas, callErr = endpoint.Client.EstimateGas(ctx, msg)
+		return callErr
+	})
+	return gas, err
+}
+
+// CallContract executa no pool com failover (eth_call)
+func (p *EndpointPool) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (output []byte, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		output, callErr = endpoint.Client.CallContract(ctx, msg, blockNumber)
+		return callErr
+	})
+	return output, err
+}
+
+// TransactionReceipt executa no pool com failover
+func (p *EndpointPool) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		receipt, callErr = endpoint.Client.TransactionReceipt(ctx, txHash)
+		return callErr
+	})
+	return receipt, err
+}
+
+// ChainID executa no pool com failover
+func (p *EndpointPool) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		chainID, callErr = endpoint.Client.ChainID(ctx)
+		return callErr
+	})
+	return chainID, err
+}
+
+// SuggestGasPrice executa no pool com failover
+func (p *EndpointPool) SuggestGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		gasPrice, callErr = endpoint.Client.SuggestGasPrice(ctx)
+		return callErr
+	})
+	return gasPrice, err
+}
+
+// SuggestGasTipCap executa no pool com failover (eth_maxPriorityFeePerGas)
+func (p *EndpointPool) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		tip, callErr = endpoint.Client.SuggestGasTipCap(ctx)
+		return callErr
+	})
+	return tip, err
+}
+
+// FeeHistory executa no pool com failover (eth_feeHistory)
+func (p *EndpointPool) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (history *ethereum.FeeHistory, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		history, callErr = endpoint.Client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return callErr
+	})
+	return history, err
+}
+
+// HeaderByNumber executa no pool com failover; o header mais recente atualiza a altura do endpoint
+func (p *EndpointPool) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		header, callErr = endpoint.Client.HeaderByNumber(ctx, number)
+		if callErr == nil && number == nil && header != nil && header.Number != nil {
+			p.recordHeight(endpoint, header.Number.Uint64())
+		}
+		return callErr
+	})
+	return header, err
+}
+
+// BlockNumber executa no pool com failover, atualizando a altura do endpoint
+func (p *EndpointPool) BlockNumber(ctx context.Context) (blockNumber uint64, err error) {
+	err = p.do(ctx, func(ctx context.Context, endpoint *endpointState) (callErr error) {
+		blockNumber, callErr = endpoint.Client.BlockNumber(ctx)
+		if callErr == nil {
+			p.recordHeight(endpoint, blockNumber)
+		}
+		return callErr
+	})
+	return blockNumber, err
+}
+
+// Close fecha todos os endpoints
+func (p *EndpointPool) Close() {
+	for _, endpoint := range p.endpoints {
+		endpoint.Client.Close()
+	}
+}
+
+// MultiEndpointRPCClient RPCClient de uma chain sobre vários endpoints, com failover e balanceamento
+type MultiEndpointRPCClient struct {
+	*EVMRPCClient
+	pool *EndpointPool
+}
+
+// NewMultiEndpointRPCClient cria o cliente sobre os endpoints na ordem de prioridade; timeout limita cada
+// tentativa e o failover pode usar até um timeout por endpoint
+func NewMultiEndpointRPCClient(endpoints []Endpoint, config MultiEndpointConfig, timeout time.Duration, logger *zap.Logger) (*MultiEndpointRPCClient, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if config.AttemptTimeout <= 0 {
+		config.AttemptTimeout = timeout
+	}
+
+	names := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		names = append(names, endpoint.Name)
+	}
+	logger.Info("rpc endpoint pool created",
+		zap.Strings("endpoints", names),
+		zap.String("strategy", string(config.Strategy)),
+		zap.Uint64("max_block_lag", config.MaxBlockLag))
+
+	pool := NewEndpointPool(endpoints, config, logger)
+	var client EthClient = pool
+	if config.Quorum.Required > 0 {
+		quorum, err := NewQuorumEthClient(pool, endpoints, config.Quorum, config.AttemptTimeout, logger)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("rpc quorum reads enabled",
+			zap.Int("required", config.Quorum.Required),
+			zap.Int("endpoints", len(endpoints)),
+			zap.Uint64("max_block_skew", config.Quorum.MaxBlockSkew))
+		client = quorum
+	}
+
+	return &MultiEndpointRPCClient{
+		EVMRPCClient: &EVMRPCClient{
+			client:  client,
+			rpcURL:  strings.Join(names, ","),
+			timeout: timeout * time.Duration(len(endpoints)),
+			logger:  logger,
+		},
+		pool: pool,
+	}, nil
+}
+
+// Endpoints retorna o estado atual de cada endpoint
+func (c *MultiEndpointRPCClient) Endpoints() []EndpointStatus {
+	return c.pool.Status()
+}
@@ -0,0 +1,256 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestEndpoints(names ...string) ([]Endpoint, []*MockEthClient) {
+	endpoints := make([]Endpoint, 0, len(names))
+	clients := make([]*MockEthClient, 0, len(names))
+	for _, name := range names {
+		client := new(MockEthClient)
+		endpoints = append(endpoints, Endpoint{Name: name, Client: client})
+		clients = append(clients, client)
+	}
+	return endpoints, clients
+}
+
+func TestNewSelectionStrategy(t *testing.T) {
+	strategy, err := NewSelectionStrategy("")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionPriority, strategy)
+
+	strategy, err = NewSelectionStrategy(" Round_Robin ")
+	require.NoError(t, err)
+	assert.Equal(t, SelectionRoundRobin, strategy)
+
+	_, err = NewSelectionStrategy("random")
+	assert.Error(t, err)
+}
+
+func TestMultiEndpointRPCClient(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("fail over to next endpoint on network error", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{}, time.Second, logger)
+		require.NoError(t, err)
+
+		clients[0].On("ChainID", mock.Anything).Return(nil, errors.New("dial tcp: connection refused"))
+		clients[1].On("ChainID", mock.Anything).Return(big.NewInt(1), nil)
+
+		chainID, err := client.GetChainID(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1), chainID)
+		status := client.Endpoints()
+		assert.Less(t, status[0].Score, status[1].Score)
+	})
+
+	t.Run("fail over when circuit breaker is open", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		breaker := NewNamedCircuitBreaker("ETHEREUM/a", CircuitBreakerConfig{FailureThreshold: 1, SuccessThreshold: 1, Timeout: time.Minute}, nil, logger)
+		breaker.RecordFailure()
+		endpoints[0].Client = NewBreakerEthClient(clients[0], breaker)
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{}, time.Second, logger)
+		require.NoError(t, err)
+
+		clients[1].On("SuggestGasPrice", mock.Anything).Return(big.NewInt(7), nil)
+
+		gasPrice, err := client.GetGasPrice(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(7), gasPrice)
+		clients[0].AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+	})
+
+	t.Run("fail over on attempt timeout", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{AttemptTimeout: 20 * time.Millisecond}, time.Second, logger)
+		require.NoError(t, err)
+
+		clients[0].On("BlockNumber", mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(uint64(0), context.DeadlineExceeded)
+		clients[1].On("BlockNumber", mock.Anything).Return(uint64(100), nil)
+
+		blockNumber, err := client.GetBlockNumber(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), blockNumber)
+	})
+
+	t.Run("return node errors without failover", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{}, time.Second, logger)
+		require.NoError(t, err)
+
+		hash := common.HexToHash("0x01")
+		clients[0].On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+
+		_, err = client.GetTransactionReceipt(context.Background(), hash.Hex())
+
+		require.ErrorIs(t, err, ethereum.NotFound)
+		clients[1].AssertNotCalled(t, "TransactionReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("return last error when all endpoints fail", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{}, time.Second, logger)
+		require.NoError(t, err)
+
+		clients[0].On("ChainID", mock.Anything).Return(nil, errors.New("502 bad gateway"))
+		clients[1].On("ChainID", mock.Anything).Return(nil, errors.New("503 service unavailable"))
+
+		_, err = client.GetChainID(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503 service unavailable")
+	})
+
+	t.Run("treat already known after failover as sent", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("ETHEREUM/a", "ETHEREUM/b")
+		client, err := NewMultiEndpointRPCClient(endpoints, MultiEndpointConfig{}, time.Second, logger)
+		require.NoError(t, err)
+
+		tx := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1)})
+		clients[0].On("SendTransaction", mock.Anything, tx).Return(context.DeadlineExceeded)
+		clients[1].On("SendTransaction", mock.Anything, tx).Return(errors.New("already known"))
+
+		err = client.SendTransaction(context.Background(), tx)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("fail without endpoints", func(t *testing.T) {
+		_, err := NewMultiEndpointRPCClient(nil, MultiEndpointConfig{}, time.Second, logger)
+
+		assert.ErrorIs(t, err, ErrNoEndpoints)
+	})
+}
+
+func TestEndpointPoolSelection(t *testing.T) {
+	logger := zap.NewNop()
+
+	order := func(pool *EndpointPool) []string {
+		names := []string{}
+		for _, endpoint := range pool.candidates() {
+			names = append(names, endpoint.Name)
+		}
+		return names
+	}
+
+	t.Run("priority keeps configured order", func(t *testing.T) {
+		endpoints, _ := newTestEndpoints("a", "b", "c")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{Strategy: SelectionPriority}, logger)
+
+		assert.Equal(t, []string{"a", "b", "c"}, order(pool))
+		assert.Equal(t, []string{"a", "b", "c"}, order(pool))
+	})
+
+	t.Run("round robin rotates first endpoint", func(t *testing.T) {
+		endpoints, _ := newTestEndpoints("a", "b", "c")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{Strategy: SelectionRoundRobin}, logger)
+
+		assert.Equal(t, []string{"a", "b", "c"}, order(pool))
+		assert.Equal(t, []string{"b", "c", "a"}, order(pool))
+		assert.Equal(t, []string{"c", "a", "b"}, order(pool))
+	})
+
+	t.Run("lowest latency first", func(t *testing.T) {
+		endpoints, _ := newTestEndpoints("a", "b", "c")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{Strategy: SelectionLowestLatency}, logger)
+		pool.recordSuccess(pool.endpoints[0], 300*time.Millisecond)
+		pool.recordSuccess(pool.endpoints[1], 50*time.Millisecond)
+		pool.recordSuccess(pool.endpoints[2], 120*time.Millisecond)
+
+		assert.Equal(t, []string{"b", "c", "a"}, order(pool))
+	})
+
+	t.Run("unhealthy endpoints go last", func(t *testing.T) {
+		endpoints, _ := newTestEndpoints("a", "b")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{}, logger)
+		pool.recordFailure(pool.endpoints[0], errors.New("timeout"))
+		pool.recordFailure(pool.endpoints[0], errors.New("timeout"))
+
+		assert.Equal(t, []string{"b", "a"}, order(pool))
+
+		// Sucessos recuperam o score
+		pool.recordSuccess(pool.endpoints[0], time.Millisecond)
+		pool.recordSuccess(pool.endpoints[0], time.Millisecond)
+		assert.Equal(t, []string{"a", "b"}, order(pool))
+	})
+
+	t.Run("skip lagging endpoint after health check", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("a", "b", "c")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{MaxBlockLag: 5}, logger)
+		clients[0].On("BlockNumber", mock.Anything).Return(uint64(90), nil)
+		clients[1].On("BlockNumber", mock.Anything).Return(uint64(100), nil)
+		clients[2].On("BlockNumber", mock.Anything).Return(uint64(97), nil)
+
+		pool.CheckHealth(context.Background())
+
+		assert.Equal(t, []string{"b", "c", "a"}, order(pool))
+		status := pool.Status()
+		assert.True(t, status[0].Lagging)
+		assert.Equal(t, uint64(90), status[0].BlockHeight)
+		assert.False(t, status[2].Lagging)
+	})
+
+	t.Run("health check does not block the call", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("a", "b")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{HealthCheckInterval: time.Nanosecond}, logger)
+		release := make(chan time.Time)
+		for _, client := range clients {
+			client.On("BlockNumber", mock.Anything).WaitUntil(release).Return(uint64(100), nil)
+		}
+
+		done := make(chan struct{})
+		go func() {
+			pool.maybeCheckHealth(context.Background())
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("health check blocked the call")
+		}
+
+		// Uma verificação em andamento não dispara outra
+		pool.maybeCheckHealth(context.Background())
+		close(release)
+
+		assert.Eventually(t, func() bool {
+			status := pool.Status()
+			return status[0].BlockHeight == 100 && status[1].BlockHeight == 100
+		}, time.Second, 10*time.Millisecond)
+		clients[0].AssertNumberOfCalls(t, "BlockNumber", 1)
+		clients[1].AssertNumberOfCalls(t, "BlockNumber", 1)
+	})
+
+	t.Run("track height from block number calls", func(t *testing.T) {
+		endpoints, clients := newTestEndpoints("a", "b")
+		pool := NewEndpointPool(endpoints, MultiEndpointConfig{MaxBlockLag: 2}, logger)
+		pool.recordHeight(pool.endpoints[1], 200)
+		clients[0].On("BlockNumber", mock.Anything).Return(uint64(150), nil).Once()
+
+		// "a" ainda não tem altura conhecida e é tentado primeiro
+		blockNumber, err := pool.BlockNumber(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(150), blockNumber)
+		assert.Equal(t, []string{"b", "a"}, order(pool))
+	})
+}
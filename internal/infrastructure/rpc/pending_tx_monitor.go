@@ -0,0 +1,315 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrTransactionNotTracked a operação não tem transação pendente no monitor
+	ErrTransactionNotTracked = errors.New("transaction not tracked")
+	// ErrMaxReplacements a operação atingiu o limite de substituições
+	ErrMaxReplacements = errors.New("max transaction replacements reached")
+)
+
+// TransactionMonitor acompanha transações enviadas e as substitui quando presas no mempool
+type TransactionMonitor interface {
+	// Track passa a acompanhar a transação enviada para a operação
+	Track(operationID string, fromAddress string, tx *types.Transaction, txHash string)
+	// Restore retoma o acompanhamento a partir da transação persistida (ex.: enviada por outra instância)
+	Restore(pending PendingTransaction)
+	// Pending retorna uma cópia da transação acompanhada
+	Pending(operationID string) (PendingTransaction, bool)
+	// Forget encerra o acompanhamento da operação
+	Forget(operationID string)
+	// Receipt retorna o receipt e o hash da versão minerada (original ou substituta), ou nil se nenhuma foi minerada;
+	// falhas de RPC diferentes de ethereum.NotFound retornam erro
+	Receipt(ctx context.Context, operationID string) (*types.Receipt, string, error)
+	// ReplaceIfStuck reenvia com taxas maiores se a transação passou do limite sem ser minerada
+	ReplaceIfStuck(ctx context.Context, operationID string) (string, bool, error)
+	// SpeedUp reenvia imediatamente com taxas maiores
+	SpeedUp(ctx context.Context, operationID string) (string, error)
+	// Cancel ocupa o nonce com uma transferência de valor zero para o próprio remetente
+	Cancel(ctx context.Context, operationID string, fromAddress string, nonce uint64, previous *FeeData) (string, error)
+}
+
+// PendingTransaction transação acompanhada pelo monitor
+type PendingTransaction struct {
+	OperationID string
+	FromAddress string
+	// Tx última versão enviada (não assinada)
+	Tx *types.Transaction
+	// Hashes todas as versões enviadas, da original à mais recente
+	Hashes []string
+	// Replacements substituições enviadas (aceleração e cancelamento)
+	Replacements    int
+	Cancelled       bool
+	LastBroadcastAt time.Time
+}
+
+// PendingTxMonitor TransactionMonitor em memória para uma chain
+type PendingTxMonitor struct {
+	client       RPCClient
+	signer       SignedTransactionClient
+	feeEstimator FeeEstimator
+	policy       ReplacementPolicy
+	logger       *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]*PendingTransaction
+}
+
+// NewPendingTxMonitor cria o monitor; sem feeEstimator as taxas atuais vêm de eth_gasPrice
+func NewPendingTxMonitor(
+	client RPCClient,
+	signer SignedTransactionClient,
+	feeEstimator FeeEstimator,
+	policy ReplacementPolicy,
+	logger *zap.Logger,
+) *PendingTxMonitor {
+	return &PendingTxMonitor{
+		client:       client,
+		signer:       signer,
+		feeEstimator: feeEstimator,
+		policy:       policy,
+		logger:       logger,
+		pending:      make(map[string]*PendingTransaction),
+	}
+}
+
+// Track passa a acompanhar a transação enviada para a operação
+func (m *PendingTxMonitor) Track(operationID string, fromAddress string, tx *types.Transaction, txHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pending[operationID] = &PendingTransaction{
+		OperationID:     operationID,
+		FromAddress:     fromAddress,
+		Tx:              tx,
+		Hashes:          []string{txHash},
+		LastBroadcastAt: time.Now(),
+	}
+}
+
+// Restore retoma o acompanhamento a partir da transação persistida; não sobrescreve o estado em memória,
+// que é sempre o mais recente nesta instância
+func (m *PendingTxMonitor) Restore(pending PendingTransaction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.pending[pending.OperationID]; ok {
+		return
+	}
+	restored := pending
+	restored.Hashes = append([]string(nil), pending.Hashes...)
+	m.pending[pending.OperationID] = &restored
+}
+
+// Forget encerra o acompanhamento da operação
+func (m *PendingTxMonitor) Forget(operationID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, operationID)
+}
+
+// Pending retorna uma cópia da transação acompanhada
+func (m *PendingTxMonitor) Pending(operationID string) (PendingTransaction, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pending, ok := m.pending[operationID]
+	if !ok {
+		return PendingTransaction{}, false
+	}
+	snapshot := *pending
+	snapshot.Hashes = append([]string(nil), pending.Hashes...)
+	return snapshot, true
+}
+
+// Receipt procura o receipt de todas as versões enviadas, da mais recente à original
+func (m *PendingTxMonitor) Receipt(ctx context.Context, operationID string) (*types.Receipt, string, error) {
+	pending, ok := m.Pending(operationID)
+	if !ok {
+		return nil, "", ErrTransactionNotTracked
+	}
+
+	var rpcErr error
+	for i := len(pending.Hashes) - 1; i >= 0; i-- {
+		receipt, err := m.client.GetTransactionReceipt(ctx, pending.Hashes[i])
+		if err == nil && receipt != nil {
+			return receipt, pending.Hashes[i], nil
+		}
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			rpcErr = err
+		}
+	}
+	if rpcErr != nil {
+		return nil, "", fmt.Errorf("failed to get transaction receipt: %w", rpcErr)
+	}
+	return nil, "", nil
+}
+
+// ReplaceIfStuck reenvia com taxas maiores se o último envio passou de StuckAfter sem ser minerado
+func (m *PendingTxMonitor) ReplaceIfStuck(ctx context.Context, operationID string) (string, bool, error) {
+	pending, ok := m.Pending(operationID)
+	if !ok {
+		return "", false, ErrTransactionNotTracked
+	}
+	if time.Since(pending.LastBroadcastAt) < m.policy.StuckAfter {
+		return "", false, nil
+	}
+
+	// Sem certeza de que nenhuma versão foi minerada, não substitui
+	receipt, _, err := m.Receipt(ctx, operationID)
+	if err != nil {
+		return "", false, err
+	}
+	if receipt != nil {
+		return "", false, nil
+	}
+
+	m.logger.Warn("transaction stuck in mempool",
+		zap.String("operation_id", operationID),
+		zap.String("tx_hash", pending.Hashes[len(pending.Hashes)-1]),
+		zap.Duration("pending_for", time.Since(pending.LastBroadcastAt)))
+
+	txHash, err := m.SpeedUp(ctx, operationID)
+	if err != nil {
+		return "", false, err
+	}
+	return txHash, true, nil
+}
+
+// SpeedUp reenvia a última versão com o mesmo nonce e taxas aumentadas
+func (m *PendingTxMonitor) SpeedUp(ctx context.Context, operationID string) (string, error) {
+	pending, ok := m.Pending(operationID)
+	if !ok {
+		return "", ErrTransactionNotTracked
+	}
+	if pending.Replacements >= m.policy.MaxReplacements {
+		return "", fmt.Errorf("%w: %d", ErrMaxReplacements, pending.Replacements)
+	}
+
+	fees, err := m.replacementFees(ctx, TransactionFees(pending.Tx))
+	if err != nil {
+		return "", err
+	}
+
+	var replacement *types.Transaction
+	if pending.Cancelled {
+		replacement = CancelTransaction(common.HexToAddress(pending.FromAddress), pending.Tx.Nonce(), fees)
+	} else {
+		replacement = SpeedUpTransaction(pending.Tx, fees)
+	}
+	return m.broadcast(ctx, pending.OperationID, pending.FromAddress, replacement, pending.Cancelled)
+}
+
+// Cancel substitui a transação por uma transferência de valor zero para o remetente;
+// sem acompanhamento em memória usa o nonce e as taxas informados (ex.: da operação persistida)
+func (m *PendingTxMonitor) Cancel(ctx context.Context, operationID string, fromAddress string, nonce uint64, previous *FeeData) (string, error) {
+	if pending, ok := m.Pending(operationID); ok {
+		previous = TransactionFees(pending.Tx)
+		nonce = pending.Tx.Nonce()
+		fromAddress = pending.FromAddress
+	} else if previous == nil {
+		return "", ErrTransactionNotTracked
+	}
+
+	fees, err := m.replacementFees(ctx, previous)
+	if err != nil {
+		return "", err
+	}
+
+	return m.broadcast(ctx, operationID, fromAddress, CancelTransaction(common.HexToAddress(fromAddress), nonce, fees), true)
+}
+
+// CheckStuck substitui as transações presas e encerra as já mineradas; retorna os novos hashes por operação
+func (m *PendingTxMonitor) CheckStuck(ctx context.Context) map[string]string {
+	m.mu.Lock()
+	operationIDs := make([]string, 0, len(m.pending))
+	for operationID := range m.pending {
+		operationIDs = append(operationIDs, operationID)
+	}
+	m.mu.Unlock()
+
+	replaced := make(map[string]string)
+	for _, operationID := range operationIDs {
+		if receipt, _, err := m.Receipt(ctx, operationID); err == nil && receipt != nil {
+			m.Forget(operationID)
+			continue
+		}
+
+		txHash, ok, err := m.ReplaceIfStuck(ctx, operationID)
+		if err != nil {
+			m.logger.Error("failed to replace stuck transaction",
+				zap.String("operation_id", operationID),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			replaced[operationID] = txHash
+		}
+	}
+	return replaced
+}
+
+// replacementFees taxas anteriores com aumento mínimo, atualizadas pelas taxas rápidas da rede
+func (m *PendingTxMonitor) replacementFees(ctx context.Context, previous *FeeData) (*FeeData, error) {
+	var current *FeeData
+	if m.feeEstimator != nil {
+		fees, err := m.feeEstimator.EstimateFees(ctx, FeeSpeedFast)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate replacement fees: %w", err)
+		}
+		current = fees
+	} else {
+		gasPrice, err := m.client.GetGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		current = &FeeData{GasPrice: gasPrice}
+	}
+
+	return ReplacementFees(previous, current, m.policy.FeeBumpPercent), nil
+}
+
+// broadcast assina e envia a substituição, registrando o novo hash na operação
+func (m *PendingTxMonitor) broadcast(ctx context.Context, operationID string, fromAddress string, tx *types.Transaction, cancelled bool) (string, error) {
+	txHash, err := m.signer.SignAndSendTransaction(ctx, tx, fromAddress)
+	if err != nil {
+		m.logger.Error("failed to send replacement transaction",
+			zap.String("operation_id", operationID),
+			zap.Uint64("nonce", tx.Nonce()),
+			zap.Error(err))
+		return "", fmt.Errorf("failed to send replacement transaction: %w", err)
+	}
+
+	m.mu.Lock()
+	pending, ok := m.pending[operationID]
+	if !ok {
+		pending = &PendingTransaction{OperationID: operationID, FromAddress: fromAddress}
+		m.pending[operationID] = pending
+	}
+	pending.Tx = tx
+	pending.Hashes = append(pending.Hashes, txHash)
+	pending.Replacements++
+	pending.Cancelled = cancelled
+	pending.LastBroadcastAt = time.Now()
+	m.mu.Unlock()
+
+	m.logger.Info("replacement transaction sent",
+		zap.String("operation_id", operationID),
+		zap.String("tx_hash", txHash),
+		zap.Uint64("nonce", tx.Nonce()),
+		zap.Bool("cancellation", cancelled))
+	return txHash, nil
+}
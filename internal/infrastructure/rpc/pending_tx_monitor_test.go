@@ -0,0 +1,326 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const monitorFromAddress = "0x1234567890123456789012345678901234567890"
+
+// MockSignedTransactionClient mock do signer
+type MockSignedTransactionClient struct {
+	mock.Mock
+}
+
+func (m *MockSignedTransactionClient) SignAndSendTransaction(ctx context.Context, tx *types.Transaction, fromAddress string) (string, error) {
+	args := m.Called(ctx, tx, fromAddress)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSignedTransactionClient) WaitForConfirmations(ctx context.Context, txHash string, requiredConfirmations int) (*types.Receipt, error) {
+	args := m.Called(ctx, txHash, requiredConfirmations)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Receipt), args.Error(1)
+}
+
+// MockFeeEstimator mock do estimador de taxas
+type MockFeeEstimator struct {
+	mock.Mock
+}
+
+func (m *MockFeeEstimator) EstimateFees(ctx context.Context, speed FeeSpeed) (*FeeData, error) {
+	args := m.Called(ctx, speed)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*FeeData), args.Error(1)
+}
+
+func newMonitorTestTx() *types.Transaction {
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+	return types.NewTx(&types.LegacyTx{Nonce: 4, GasPrice: gwei(10), Gas: 21000, To: &to, Value: big.NewInt(1)})
+}
+
+func newTestMonitor(policy ReplacementPolicy) (*MockEthClient, *MockSignedTransactionClient, *PendingTxMonitor) {
+	mockClient, client := newFeeTestClient()
+	signer := new(MockSignedTransactionClient)
+	return mockClient, signer, NewPendingTxMonitor(client, signer, nil, policy, zap.NewNop())
+}
+
+func TestPendingTxMonitor_ReplaceIfStuck(t *testing.T) {
+	t.Run("replace stuck transaction with bumped fees and same nonce", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{FeeBumpPercent: 10, MaxReplacements: 2})
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+
+		mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(9), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4 && tx.GasPrice().Cmp(gwei(11)) == 0 && tx.Value().Int64() == 1
+		}), monitorFromAddress).Return("0xbbb", nil)
+
+		txHash, replaced, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		assert.True(t, replaced)
+		assert.Equal(t, "0xbbb", txHash)
+		pending, ok := monitor.Pending("op-1")
+		require.True(t, ok)
+		assert.Equal(t, []string{"0xaaa", "0xbbb"}, pending.Hashes)
+		assert.Equal(t, 1, pending.Replacements)
+	})
+
+	t.Run("keep waiting before threshold", func(t *testing.T) {
+		_, signer, monitor := newTestMonitor(ReplacementPolicy{StuckAfter: time.Hour, MaxReplacements: 2})
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+
+		txHash, replaced, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		assert.False(t, replaced)
+		assert.Empty(t, txHash)
+		signer.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("skip replacement when a version was mined", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{MaxReplacements: 2})
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+		mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0xaaa")).Return(&types.Receipt{BlockNumber: big.NewInt(5)}, nil)
+
+		_, replaced, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		assert.False(t, replaced)
+		signer.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stop after max replacements", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{MaxReplacements: 1})
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+		mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.Anything, monitorFromAddress).Return("0xbbb", nil).Once()
+
+		_, _, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+		require.NoError(t, err)
+		_, _, err = monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+		assert.ErrorIs(t, err, ErrMaxReplacements)
+	})
+
+	t.Run("untracked operation", func(t *testing.T) {
+		_, _, monitor := newTestMonitor(DefaultReplacementPolicy())
+
+		_, _, err := monitor.ReplaceIfStuck(context.Background(), "op-unknown")
+
+		assert.ErrorIs(t, err, ErrTransactionNotTracked)
+	})
+}
+
+func TestPendingTxMonitor_SpeedUp(t *testing.T) {
+	t.Run("use fast market fees from estimator", func(t *testing.T) {
+		mockClient, client := newFeeTestClient()
+		signer := new(MockSignedTransactionClient)
+		estimator := new(MockFeeEstimator)
+		monitor := NewPendingTxMonitor(client, signer, estimator, DefaultReplacementPolicy(), zap.NewNop())
+
+		to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+		monitor.Track("op-1", monitorFromAddress, types.NewTx(&types.DynamicFeeTx{
+			Nonce: 2, GasTipCap: gwei(1), GasFeeCap: gwei(20), Gas: 21000, To: &to, Value: big.NewInt(0),
+		}), "0xaaa")
+
+		estimator.On("EstimateFees", mock.Anything, FeeSpeedFast).Return(&FeeData{MaxFeePerGas: gwei(50), MaxPriorityFeePerGas: gwei(2)}, nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 2 && tx.GasFeeCap().Cmp(gwei(50)) == 0 && tx.GasTipCap().Cmp(gwei(2)) == 0
+		}), monitorFromAddress).Return("0xbbb", nil)
+
+		txHash, err := monitor.SpeedUp(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "0xbbb", txHash)
+		mockClient.AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+	})
+
+	t.Run("keep previous version tracked when send fails", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(DefaultReplacementPolicy())
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.Anything, monitorFromAddress).
+			Return("", errors.New("replacement transaction underpriced"))
+
+		_, err := monitor.SpeedUp(context.Background(), "op-1")
+
+		assert.Error(t, err)
+		pending, _ := monitor.Pending("op-1")
+		assert.Equal(t, []string{"0xaaa"}, pending.Hashes)
+		assert.Equal(t, 0, pending.Replacements)
+	})
+}
+
+func TestPendingTxMonitor_Cancel(t *testing.T) {
+	t.Run("cancel tracked transaction with self transfer", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(DefaultReplacementPolicy())
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4 &&
+				tx.To().Hex() == monitorFromAddress &&
+				tx.Value().Sign() == 0 &&
+				tx.GasPrice().Cmp(gwei(11)) > 0
+		}), monitorFromAddress).Return("0xccc", nil)
+
+		txHash, err := monitor.Cancel(context.Background(), "op-1", "", 0, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "0xccc", txHash)
+		pending, _ := monitor.Pending("op-1")
+		assert.True(t, pending.Cancelled)
+		assert.Equal(t, []string{"0xaaa", "0xccc"}, pending.Hashes)
+	})
+
+	t.Run("cancel untracked transaction from stored nonce and fees", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(DefaultReplacementPolicy())
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 11 && tx.GasFeeCap().Cmp(gwei(33)) >= 0
+		}), monitorFromAddress).Return("0xddd", nil)
+
+		txHash, err := monitor.Cancel(context.Background(), "op-2", monitorFromAddress, 11,
+			&FeeData{MaxFeePerGas: gwei(30), MaxPriorityFeePerGas: gwei(1)})
+
+		require.NoError(t, err)
+		assert.Equal(t, "0xddd", txHash)
+		pending, ok := monitor.Pending("op-2")
+		require.True(t, ok)
+		assert.Equal(t, []string{"0xddd"}, pending.Hashes)
+	})
+
+	t.Run("speed up a cancellation keeps it a cancellation", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(DefaultReplacementPolicy())
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.To().Hex() == monitorFromAddress && tx.Value().Sign() == 0
+		}), monitorFromAddress).Return("0xeee", nil)
+
+		_, err := monitor.Cancel(context.Background(), "op-1", "", 0, nil)
+		require.NoError(t, err)
+		_, err = monitor.SpeedUp(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		signer.AssertNumberOfCalls(t, "SignAndSendTransaction", 2)
+	})
+
+	t.Run("untracked without fees", func(t *testing.T) {
+		_, _, monitor := newTestMonitor(DefaultReplacementPolicy())
+
+		_, err := monitor.Cancel(context.Background(), "op-3", monitorFromAddress, 1, nil)
+
+		assert.ErrorIs(t, err, ErrTransactionNotTracked)
+	})
+}
+
+func TestPendingTxMonitor_Restore(t *testing.T) {
+	t.Run("replace transaction restored from persisted record", func(t *testing.T) {
+		mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{StuckAfter: time.Minute, FeeBumpPercent: 10, MaxReplacements: 2})
+		monitor.Restore(PendingTransaction{
+			OperationID:     "op-1",
+			FromAddress:     monitorFromAddress,
+			Tx:              newMonitorTestTx(),
+			Hashes:          []string{"0xaaa", "0xbbb"},
+			Replacements:    1,
+			LastBroadcastAt: time.Now().Add(-time.Hour),
+		})
+		mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+		mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(9), nil)
+		signer.On("SignAndSendTransaction", mock.Anything, mock.MatchedBy(func(tx *types.Transaction) bool {
+			return tx.Nonce() == 4 && tx.GasPrice().Cmp(gwei(11)) == 0
+		}), monitorFromAddress).Return("0xccc", nil)
+
+		txHash, replaced, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+		require.NoError(t, err)
+		assert.True(t, replaced)
+		assert.Equal(t, "0xccc", txHash)
+		pending, _ := monitor.Pending("op-1")
+		assert.Equal(t, []string{"0xaaa", "0xbbb", "0xccc"}, pending.Hashes)
+		assert.Equal(t, 2, pending.Replacements)
+	})
+
+	t.Run("keep state tracked in memory", func(t *testing.T) {
+		_, _, monitor := newTestMonitor(DefaultReplacementPolicy())
+		monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+
+		monitor.Restore(PendingTransaction{OperationID: "op-1", FromAddress: monitorFromAddress, Tx: newMonitorTestTx(), Hashes: []string{"0xold"}})
+
+		pending, ok := monitor.Pending("op-1")
+		require.True(t, ok)
+		assert.Equal(t, []string{"0xaaa"}, pending.Hashes)
+	})
+}
+
+func TestPendingTxMonitor_Receipt(t *testing.T) {
+	mockClient, _, monitor := newTestMonitor(ReplacementPolicy{MaxReplacements: 1})
+	monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0xbbb")).Return(nil, ethereum.NotFound)
+	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0xaaa")).Return(&types.Receipt{BlockNumber: big.NewInt(8)}, nil)
+
+	signer := monitor.signer.(*MockSignedTransactionClient)
+	signer.On("SignAndSendTransaction", mock.Anything, mock.Anything, monitorFromAddress).Return("0xbbb", nil)
+	_, err := monitor.SpeedUp(context.Background(), "op-1")
+	require.NoError(t, err)
+
+	// A original foi minerada antes da substituta se propagar
+	receipt, txHash, err := monitor.Receipt(context.Background(), "op-1")
+
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	assert.Equal(t, "0xaaa", txHash)
+}
+
+func TestPendingTxMonitor_Receipt_TransientError(t *testing.T) {
+	mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{MaxReplacements: 1})
+	monitor.Track("op-1", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0xaaa")).Return(nil, errors.New("connection reset by peer"))
+
+	receipt, _, err := monitor.Receipt(context.Background(), "op-1")
+
+	require.Error(t, err)
+	assert.Nil(t, receipt)
+
+	// Sem certeza de que a transação não foi minerada, não substitui
+	_, replaced, err := monitor.ReplaceIfStuck(context.Background(), "op-1")
+
+	require.Error(t, err)
+	assert.False(t, replaced)
+	signer.AssertNotCalled(t, "SignAndSendTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPendingTxMonitor_CheckStuck(t *testing.T) {
+	mockClient, signer, monitor := newTestMonitor(ReplacementPolicy{MaxReplacements: 1})
+	monitor.Track("op-mined", monitorFromAddress, newMonitorTestTx(), "0xaaa")
+	monitor.Track("op-stuck", monitorFromAddress, newMonitorTestTx(), "0xbbb")
+	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0xaaa")).Return(&types.Receipt{BlockNumber: big.NewInt(8)}, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(gwei(1), nil)
+	signer.On("SignAndSendTransaction", mock.Anything, mock.Anything, monitorFromAddress).Return("0xccc", nil)
+
+	replaced := monitor.CheckStuck(context.Background())
+
+	assert.Equal(t, map[string]string{"op-stuck": "0xccc"}, replaced)
+	_, tracked := monitor.Pending("op-mined")
+	assert.False(t, tracked)
+}
@@ -0,0 +1,312 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+)
+
+// ErrQuorumNotReached os endpoints não chegaram ao número mínimo de respostas iguais
+var ErrQuorumNotReached = errors.New("rpc quorum not reached")
+
+// notFoundAnswer resposta "não encontrado" (ex.: receipt ainda não minerado), que também conta para o quórum
+const notFoundAnswer = "not found"
+
+// QuorumConfig leituras por consenso entre os endpoints da chain
+type QuorumConfig struct {
+	// Required respostas iguais exigidas (0 = desativado)
+	Required int
+	// MaxBlockSkew diferença máxima de altura em relação ao endpoint mais adiantado para participar
+	MaxBlockSkew uint64
+}
+
+// ProviderAnswer resposta de um endpoint numa leitura por quórum
+type ProviderAnswer struct {
+	Endpoint    string
+	BlockHeight uint64
+	Answer      string
+	Err         error
+}
+
+func (a ProviderAnswer) String() string {
+	if a.Err != nil {
+		return fmt.Sprintf("%s@%d: error: %v", a.Endpoint, a.BlockHeight, a.Err)
+	}
+	return fmt.Sprintf("%s@%d: %s", a.Endpoint, a.BlockHeight, a.Answer)
+}
+
+// QuorumError leitura sem quórum, com a resposta de cada endpoint
+type QuorumError struct {
+	Method   string
+	Required int
+	Answers  []ProviderAnswer
+}
+
+func (e *QuorumError) Error() string {
+	answers := make([]string, 0, len(e.Answers))
+	for _, answer := range e.Answers {
+		answers = append(answers, answer.String())
+	}
+	return fmt.Sprintf("%s: %s requires %d matching answers [%s]", ErrQuorumNotReached, e.Method, e.Required, strings.Join(answers, "; "))
+}
+
+// Is permite errors.Is(err, ErrQuorumNotReached)
+func (e *QuorumError) Is(target error) bool {
+	return target == ErrQuorumNotReached
+}
+
+// QuorumEthClient EthClient que consulta saldo, nonce, eth_call e receipts em todos os endpoints em paralelo e
+// só responde quando Required deles concordam; as demais chamadas vão para o cliente primário (ex.: EndpointPool)
+type QuorumEthClient struct {
+	EthClient
+	endpoints []Endpoint
+	config    QuorumConfig
+	// attemptTimeout timeout de cada consulta a um endpoint (0 = só o do contexto)
+	attemptTimeout time.Duration
+	logger         *zap.Logger
+}
+
+// NewQuorumEthClient cria o cliente; primary atende as chamadas fora do consenso
+func NewQuorumEthClient(primary EthClient, endpoints []Endpoint, config QuorumConfig, attemptTimeout time.Duration, logger *zap.Logger) (*QuorumEthClient, error) {
+	if config.Required <= 0 || config.Required > len(endpoints) {
+		return nil, fmt.Errorf("invalid rpc quorum: %d of %d endpoints", config.Required, len(endpoints))
+	}
+
+	return &QuorumEthClient{
+		EthClient:      primary,
+		endpoints:      endpoints,
+		config:         config,
+		attemptTimeout: attemptTimeout,
+		logger:         logger,
+	}, nil
+}
+
+// participant endpoint dentro da tolerância de altura
+type participant struct {
+	Endpoint
+	height uint64
+}
+
+// quorumAnswer resposta de um participante
+type quorumAnswer[T any] struct {
+	participant
+	value T
+	key   string
+	err   error
+}
+
+// BalanceAt saldo por consenso; sem bloco informado, consulta todos na menor altura entre os participantes
+func (q *QuorumEthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return quorumRead(ctx, q, "BalanceAt", blockNumber, true, func(ctx context.Context, client EthClient, block *big.Int) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, block)
+	}, func(balance *big.Int) string {
+		return balance.String()
+	}, nil)
+}
+
+// NonceAt nonce confirmado por consenso, na menor altura entre os participantes
+func (q *QuorumEthClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return quorumRead(ctx, q, "NonceAt", blockNumber, true, func(ctx context.Context, client EthClient, block *big.Int) (uint64, error) {
+		return client.NonceAt(ctx, account, block)
+	}, func(nonce uint64) string {
+		return strconv.FormatUint(nonce, 10)
+	}, nil)
+}
+
+// CallContract eth_call por consenso, na menor altura entre os participantes
+func (q *QuorumEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return quorumRead(ctx, q, "CallContract", blockNumber, true, func(ctx context.Context, client EthClient, block *big.Int) ([]byte, error) {
+		return client.CallContract(ctx, msg, block)
+	}, func(output []byte) string {
+		return hexutil.Encode(output)
+	}, nil)
+}
+
+// TransactionReceipt receipt por consenso (bloco, hash do bloco, status e gas); "not found" de um endpoint
+// que ainda não chegou ao bloco do receipt não conta contra o quórum
+func (q *QuorumEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return quorumRead(ctx, q, "TransactionReceipt", nil, false, func(ctx context.Context, client EthClient, _ *big.Int) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	}, func(receipt *types.Receipt) string {
+		return fmt.Sprintf("block=%s/%s status=%d gas=%d", receipt.BlockNumber, receipt.BlockHash.Hex(), receipt.Status, receipt.GasUsed)
+	}, func(answer quorumAnswer[*types.Receipt], answers []quorumAnswer[*types.Receipt]) bool {
+		if answer.key != notFoundAnswer {
+			return false
+		}
+		for _, other := range answers {
+			if other.err == nil && other.key != notFoundAnswer && other.value.BlockNumber != nil &&
+				answer.height < other.value.BlockNumber.Uint64() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// quorumRead consulta os participantes em paralelo e retorna a resposta dada por pelo menos Required deles;
+// pinned fixa o bloco na menor altura dos participantes quando o chamador não informou um; abstain descarta
+// respostas que não devem contar
+func quorumRead[T any](
+	ctx context.Context,
+	q *QuorumEthClient,
+	method string,
+	blockNumber *big.Int,
+	pinned bool,
+	read func(ctx context.Context, client EthClient, block *big.Int) (T, error),
+	key func(T) string,
+	abstain func(answer quorumAnswer[T], answers []quorumAnswer[T]) bool,
+) (T, error) {
+	var zero T
+
+	participants, heights := q.participants(ctx)
+	if len(participants) < q.config.Required {
+		return zero, q.fail(method, heights)
+	}
+
+	block := blockNumber
+	if block == nil && pinned {
+		reference := participants[0].height
+		for _, p := range participants {
+			if p.height < reference {
+				reference = p.height
+			}
+		}
+		block = new(big.Int).SetUint64(reference)
+	}
+
+	answers := make([]quorumAnswer[T], len(participants))
+	var wg sync.WaitGroup
+	for i, p := range participants {
+		wg.Add(1)
+		go func(i int, p participant) {
+			defer wg.Done()
+
+			attemptCtx, cancel := q.attemptContext(ctx)
+			defer cancel()
+
+			value, err := read(attemptCtx, p.Client, block)
+			answer := quorumAnswer[T]{participant: p, value: value, err: err}
+			switch {
+			case errors.Is(err, ethereum.NotFound):
+				answer.key, answer.err = notFoundAnswer, nil
+			case err == nil:
+				answer.key = key(value)
+			}
+			answers[i] = answer
+		}(i, p)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	var winner *quorumAnswer[T]
+	for i := range answers {
+		answer := answers[i]
+		if answer.err != nil || (abstain != nil && abstain(answer, answers)) {
+			continue
+		}
+		counts[answer.key]++
+		if counts[answer.key] >= q.config.Required && winner == nil {
+			winner = &answers[i]
+		}
+	}
+
+	provided := providerAnswers(answers)
+	if winner == nil {
+		return zero, q.fail(method, provided)
+	}
+	if counts[winner.key] < len(answers) {
+		q.logger.Warn("rpc providers disagree, quorum reached",
+			zap.String("method", method),
+			zap.String("answer", winner.key),
+			zap.Strings("answers", answerStrings(provided)))
+	}
+
+	if winner.key == notFoundAnswer {
+		return zero, ethereum.NotFound
+	}
+	return winner.value, nil
+}
+
+// participants consulta a altura de todos os endpoints; participam os que estão a até MaxBlockSkew do mais adiantado
+func (q *QuorumEthClient) participants(ctx context.Context) ([]participant, []ProviderAnswer) {
+	heights := make([]ProviderAnswer, len(q.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range q.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+
+			attemptCtx, cancel := q.attemptContext(ctx)
+			defer cancel()
+
+			height, err := endpoint.Client.BlockNumber(attemptCtx)
+			heights[i] = ProviderAnswer{Endpoint: endpoint.Name, BlockHeight: height, Answer: "block " + strconv.FormatUint(height, 10), Err: err}
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	var head uint64
+	for _, height := range heights {
+		if height.Err == nil && height.BlockHeight > head {
+			head = height.BlockHeight
+		}
+	}
+
+	participants := make([]participant, 0, len(q.endpoints))
+	for i, height := range heights {
+		if height.Err != nil || head-height.BlockHeight > q.config.MaxBlockSkew {
+			continue
+		}
+		participants = append(participants, participant{Endpoint: q.endpoints[i], height: height.BlockHeight})
+	}
+	return participants, heights
+}
+
+// fail registra a resposta de cada endpoint e retorna o QuorumError
+func (q *QuorumEthClient) fail(method string, answers []ProviderAnswer) error {
+	q.logger.Error("rpc quorum not reached",
+		zap.String("method", method),
+		zap.Int("required", q.config.Required),
+		zap.Strings("answers", answerStrings(answers)))
+	return &QuorumError{Method: method, Required: q.config.Required, Answers: answers}
+}
+
+// attemptContext aplica o timeout de cada consulta
+func (q *QuorumEthClient) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if q.attemptTimeout > 0 {
+		return context.WithTimeout(ctx, q.attemptTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func providerAnswers[T any](answers []quorumAnswer[T]) []ProviderAnswer {
+	provided := make([]ProviderAnswer, 0, len(answers))
+	for _, answer := range answers {
+		provided = append(provided, ProviderAnswer{
+			Endpoint:    answer.Name,
+			BlockHeight: answer.height,
+			Answer:      answer.key,
+			Err:         answer.err,
+		})
+	}
+	return provided
+}
+
+func answerStrings(answers []ProviderAnswer) []string {
+	values := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		values = append(values, answer.String())
+	}
+	return values
+}
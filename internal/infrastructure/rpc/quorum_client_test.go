@@ -0,0 +1,148 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestQuorumClient(t *testing.T, required int, maxBlockSkew uint64, heights ...uint64) (*QuorumEthClient, []*MockEthClient) {
+	names := make([]string, 0, len(heights))
+	for _, name := range []string{"a", "b", "c", "d"}[:len(heights)] {
+		names = append(names, "ETHEREUM/"+name)
+	}
+	endpoints, clients := newTestEndpoints(names...)
+	for i, height := range heights {
+		clients[i].On("BlockNumber", mock.Anything).Return(height, nil)
+	}
+
+	primary := new(MockEthClient)
+	client, err := NewQuorumEthClient(primary, endpoints, QuorumConfig{Required: required, MaxBlockSkew: maxBlockSkew}, time.Second, zap.NewNop())
+	require.NoError(t, err)
+	return client, clients
+}
+
+func TestQuorumEthClient(t *testing.T) {
+	addr := common.HexToAddress("0x742d35Cc6634C0532925a3b844Bc9e7595f0bEb0")
+
+	t.Run("return balance agreed by quorum at common block", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 101, 102)
+		atBlock := big.NewInt(100)
+		clients[0].On("BalanceAt", mock.Anything, addr, atBlock).Return(big.NewInt(42), nil)
+		clients[1].On("BalanceAt", mock.Anything, addr, atBlock).Return(big.NewInt(42), nil)
+		clients[2].On("BalanceAt", mock.Anything, addr, atBlock).Return(big.NewInt(41), nil)
+
+		balance, err := client.BalanceAt(context.Background(), addr, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(42), balance)
+	})
+
+	t.Run("return typed error with each answer on disagreement", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 100, 100)
+		clients[0].On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(1), nil)
+		clients[1].On("BalanceAt", mock.Anything, addr, mock.Anything).Return(big.NewInt(2), nil)
+		clients[2].On("BalanceAt", mock.Anything, addr, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		_, err := client.BalanceAt(context.Background(), addr, nil)
+
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+		var quorumErr *QuorumError
+		require.ErrorAs(t, err, &quorumErr)
+		assert.Equal(t, "BalanceAt", quorumErr.Method)
+		require.Len(t, quorumErr.Answers, 3)
+		assert.Equal(t, "1", quorumErr.Answers[0].Answer)
+		assert.Equal(t, "2", quorumErr.Answers[1].Answer)
+		assert.Error(t, quorumErr.Answers[2].Err)
+	})
+
+	t.Run("exclude endpoints beyond block skew", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 90, 101)
+		atBlock := big.NewInt(100)
+		clients[0].On("NonceAt", mock.Anything, addr, atBlock).Return(uint64(5), nil)
+		clients[2].On("NonceAt", mock.Anything, addr, atBlock).Return(uint64(5), nil)
+
+		nonce, err := client.NonceAt(context.Background(), addr, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), nonce)
+		clients[1].AssertNotCalled(t, "NonceAt", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fail when too few endpoints are within skew", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 1, 100, 90)
+
+		_, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &addr}, nil)
+
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+		clients[0].AssertNotCalled(t, "CallContract", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("return receipt agreed by quorum", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 100, 100)
+		hash := common.HexToHash("0x01")
+		receipt := &types.Receipt{Status: 1, BlockNumber: big.NewInt(99), BlockHash: common.HexToHash("0xaa"), GasUsed: 21000}
+		forked := &types.Receipt{Status: 1, BlockNumber: big.NewInt(99), BlockHash: common.HexToHash("0xbb"), GasUsed: 21000}
+		clients[0].On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+		clients[1].On("TransactionReceipt", mock.Anything, hash).Return(forked, nil)
+		clients[2].On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+
+		result, err := client.TransactionReceipt(context.Background(), hash)
+
+		require.NoError(t, err)
+		assert.Equal(t, receipt.BlockHash, result.BlockHash)
+	})
+
+	t.Run("endpoint behind receipt block abstains", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 100, 99)
+		hash := common.HexToHash("0x01")
+		receipt := &types.Receipt{Status: 1, BlockNumber: big.NewInt(100), BlockHash: common.HexToHash("0xaa")}
+		clients[0].On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+		clients[1].On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+		clients[2].On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+
+		// "c" ainda não chegou ao bloco 100 e não conta; "a" e "b" discordam
+		_, err := client.TransactionReceipt(context.Background(), hash)
+
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+	})
+
+	t.Run("return not found agreed by quorum", func(t *testing.T) {
+		client, clients := newTestQuorumClient(t, 2, 2, 100, 100)
+		hash := common.HexToHash("0x01")
+		clients[0].On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+		clients[1].On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+
+		_, err := client.TransactionReceipt(context.Background(), hash)
+
+		assert.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("delegate other calls to primary", func(t *testing.T) {
+		client, _ := newTestQuorumClient(t, 1, 0, 100)
+		client.EthClient.(*MockEthClient).On("PendingNonceAt", mock.Anything, addr).Return(uint64(3), nil)
+
+		nonce, err := client.PendingNonceAt(context.Background(), addr)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), nonce)
+	})
+
+	t.Run("reject invalid quorum", func(t *testing.T) {
+		endpoints, _ := newTestEndpoints("a", "b")
+
+		_, err := NewQuorumEthClient(new(MockEthClient), endpoints, QuorumConfig{Required: 3}, time.Second, zap.NewNop())
+
+		assert.Error(t, err)
+	})
+}
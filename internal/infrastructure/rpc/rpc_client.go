@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"net/url"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -17,13 +19,36 @@ type RPCClient interface {
 	GetBalance(ctx context.Context, address string) (*big.Int, error)
 	GetNonce(ctx context.Context, address string) (uint64, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
-	EstimateGas(ctx context.Context, msg interface{}) (uint64, error)
+	EstimateGas(ctx context.Context, msg CallMsg) (uint64, error)
+	CallContract(ctx context.Context, msg CallMsg) ([]byte, error)
 	GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error)
+	GetBlockNumber(ctx context.Context) (uint64, error)
+	GetBlockHash(ctx context.Context, blockNumber uint64) (string, error)
 	GetChainID(ctx context.Context) (*big.Int, error)
 	GetGasPrice(ctx context.Context) (*big.Int, error)
+	GetBaseFee(ctx context.Context) (*big.Int, error)
+	GetMaxPriorityFeePerGas(ctx context.Context) (*big.Int, error)
+	GetFeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
 	Close() error
 }
 
+// CallMsg mensagem de chamada usada em eth_estimateGas e eth_call
+type CallMsg struct {
+	From  common.Address
+	To    *common.Address // nil para deploy de contrato
+	Data  []byte
+	Value *big.Int
+}
+
+func (m CallMsg) toEthereum() ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:  m.From,
+		To:    m.To,
+		Data:  m.Data,
+		Value: m.Value,
+	}
+}
+
 // EVMRPCClient implementação do RPCClient para Ethereum
 type EVMRPCClient struct {
 	client  EthClient
@@ -32,8 +57,30 @@ type EVMRPCClient struct {
 	logger  *zap.Logger
 }
 
-// NewEVMRPCClient cria uma nova instância do cliente EVM
-func NewEVMRPCClient(rpcURL string, timeout time.Duration, logger *zap.Logger) (RPCClient, error) {
+// EthClientProvider cliente RPC que expõe o EthClient usado pelo signer
+type EthClientProvider interface {
+	RPCClient
+	GetEthClient() EthClient
+}
+
+// NewEVMRPCClient cria uma nova instância do cliente EVM; com breaker, todas as chamadas ao nó
+// (inclusive as do signer, via GetEthClient) passam pelo circuit breaker do endpoint
+func NewEVMRPCClient(rpcURL string, timeout time.Duration, breaker *CircuitBreaker, logger *zap.Logger) (RPCClient, error) {
+	ethClient, err := DialEthClient(rpcURL, timeout, breaker, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EVMRPCClient{
+		client:  ethClient,
+		rpcURL:  rpcURL,
+		timeout: timeout,
+		logger:  logger,
+	}, nil
+}
+
+// DialEthClient conecta ao endpoint, envolvendo o cliente no circuit breaker quando informado
+func DialEthClient(rpcURL string, timeout time.Duration, breaker *CircuitBreaker, logger *zap.Logger) (EthClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -45,12 +92,19 @@ func NewEVMRPCClient(rpcURL string, timeout time.Duration, logger *zap.Logger) (
 
 	logger.Info("connected to EVM RPC", zap.String("rpc_url", rpcURL))
 
-	return &EVMRPCClient{
-		client:  NewEthClientAdapter(client),
-		rpcURL:  rpcURL,
-		timeout: timeout,
-		logger:  logger,
-	}, nil
+	var ethClient EthClient = NewEthClientAdapter(client)
+	if breaker != nil {
+		ethClient = NewBreakerEthClient(ethClient, breaker)
+	}
+	return ethClient, nil
+}
+
+// EndpointName identifica o endpoint nos logs e métricas sem expor o caminho da URL (que costuma conter a API key)
+func EndpointName(chainName string, rpcURL string) string {
+	if parsed, err := url.Parse(rpcURL); err == nil && parsed.Host != "" {
+		return chainName + "/" + parsed.Host
+	}
+	return chainName
 }
 
 // GetBalance retorna o saldo de um endereço
@@ -98,28 +152,40 @@ func (c *EVMRPCClient) SendTransaction(ctx context.Context, tx *types.Transactio
 	return nil
 }
 
-// EstimateGas estima o gas necessário para uma transação
-func (c *EVMRPCClient) EstimateGas(ctx context.Context, msg interface{}) (uint64, error) {
-	_ = ctx // Context is passed but not used for local estimation
+// EstimateGas consulta eth_estimateGas; reverts retornam *RevertError com o motivo decodificado
+func (c *EVMRPCClient) EstimateGas(ctx context.Context, msg CallMsg) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
 
-	// msg deve ser um types.CallMsg convertido
-	callMsg, ok := msg.(interface {
-		GetFrom() common.Address
-		GetTo() *common.Address
-		GetData() []byte
-		GetValue() *big.Int
-	})
-	if !ok {
-		return 0, fmt.Errorf("invalid message type for gas estimation")
+	gas, err := c.client.EstimateGas(ctx, msg.toEthereum())
+	if err != nil {
+		if revertErr := parseRevertError(err); revertErr != nil {
+			c.logger.Warn("gas estimation reverted", zap.String("reason", revertErr.Reason))
+			return 0, revertErr
+		}
+		c.logger.Error("failed to estimate gas", zap.Error(err))
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 
-	// Simplificação para demo - em produção seria mais complexo
-	gas := uint64(21000)
-	if callMsg.GetData() != nil {
-		gas += uint64(len(callMsg.GetData()) * 4)
+	return gas, nil
+}
+
+// CallContract executa eth_call no bloco mais recente; reverts retornam *RevertError
+func (c *EVMRPCClient) CallContract(ctx context.Context, msg CallMsg) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	output, err := c.client.CallContract(ctx, msg.toEthereum(), nil)
+	if err != nil {
+		if revertErr := parseRevertError(err); revertErr != nil {
+			c.logger.Warn("contract call reverted", zap.String("reason", revertErr.Reason))
+			return nil, revertErr
+		}
+		c.logger.Error("failed to call contract", zap.Error(err))
+		return nil, fmt.Errorf("failed to call contract: %w", err)
 	}
 
-	return gas, nil
+	return output, nil
 }
 
 // GetTransactionReceipt retorna o recebimento de uma transação
@@ -137,6 +203,34 @@ func (c *EVMRPCClient) GetTransactionReceipt(ctx context.Context, txHash string)
 	return receipt, nil
 }
 
+// GetBlockNumber retorna o número do último bloco
+func (c *EVMRPCClient) GetBlockNumber(ctx context.Context) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	blockNumber, err := c.client.BlockNumber(ctx)
+	if err != nil {
+		c.logger.Error("failed to get block number", zap.Error(err))
+		return 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	return blockNumber, nil
+}
+
+// GetBlockHash retorna o hash do bloco canônico na altura informada
+func (c *EVMRPCClient) GetBlockHash(ctx context.Context, blockNumber uint64) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		c.logger.Error("failed to get block header", zap.Uint64("block_number", blockNumber), zap.Error(err))
+		return "", fmt.Errorf("failed to get block header: %w", err)
+	}
+
+	return header.Hash().Hex(), nil
+}
+
 // GetChainID retorna o ID da chain
 func (c *EVMRPCClient) GetChainID(ctx context.Context) (*big.Int, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
@@ -165,6 +259,48 @@ func (c *EVMRPCClient) GetGasPrice(ctx context.Context) (*big.Int, error) {
 	return gasPrice, nil
 }
 
+// GetBaseFee retorna o base fee do último bloco; nil se a chain não suporta EIP-1559
+func (c *EVMRPCClient) GetBaseFee(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		c.logger.Error("failed to get latest header", zap.Error(err))
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	return header.BaseFee, nil
+}
+
+// GetMaxPriorityFeePerGas retorna a gorjeta sugerida pelo nó (eth_maxPriorityFeePerGas)
+func (c *EVMRPCClient) GetMaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	tip, err := c.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		c.logger.Error("failed to get max priority fee", zap.Error(err))
+		return nil, fmt.Errorf("failed to get max priority fee: %w", err)
+	}
+
+	return tip, nil
+}
+
+// GetFeeHistory retorna o histórico de taxas dos últimos blocos (eth_feeHistory)
+func (c *EVMRPCClient) GetFeeHistory(ctx context.Context, blockCount uint64, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	history, err := c.client.FeeHistory(ctx, blockCount, nil, rewardPercentiles)
+	if err != nil {
+		c.logger.Error("failed to get fee history", zap.Error(err))
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+
+	return history, nil
+}
+
 // GetEthClient retorna o cliente Ethereum subjacente
 func (c *EVMRPCClient) GetEthClient() EthClient {
 	return c.client
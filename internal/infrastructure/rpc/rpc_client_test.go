@@ -47,6 +47,14 @@ func (m *MockEthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (
 	return args.Get(0).(uint64), args.Error(1)
 }
 
+func (m *MockEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args := m.Called(ctx, msg, blockNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockEthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
 	args := m.Called(ctx, txHash)
 	if args.Get(0) == nil {
@@ -71,6 +79,30 @@ func (m *MockEthClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockEthClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockEthClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	args := m.Called(ctx, blockCount, lastBlock, rewardPercentiles)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ethereum.FeeHistory), args.Error(1)
+}
+
+func (m *MockEthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.Header), args.Error(1)
+}
+
 func (m *MockEthClient) BlockNumber(ctx context.Context) (uint64, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(uint64), args.Error(1)
@@ -360,6 +392,68 @@ func TestEVMRPCClient_GetTransactionReceipt_Error(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestEVMRPCClient_GetBlockNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockEthClient)
+		rpcClient := &EVMRPCClient{client: mockClient, logger: zap.NewNop()}
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(1234), nil)
+
+		blockNumber, err := rpcClient.GetBlockNumber(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1234), blockNumber)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockEthClient)
+		rpcClient := &EVMRPCClient{client: mockClient, logger: zap.NewNop()}
+		mockClient.On("BlockNumber", mock.Anything).Return(uint64(0), errors.New("rpc error"))
+
+		_, err := rpcClient.GetBlockNumber(context.Background())
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get block number")
+	})
+}
+
+func TestEVMRPCClient_GetBlockHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("return canonical header hash", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockEthClient)
+		rpcClient := &EVMRPCClient{client: mockClient, logger: zap.NewNop()}
+		header := &types.Header{Number: big.NewInt(100)}
+		mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(100)).Return(header, nil)
+
+		blockHash, err := rpcClient.GetBlockHash(context.Background(), 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, header.Hash().Hex(), blockHash)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		mockClient := new(MockEthClient)
+		rpcClient := &EVMRPCClient{client: mockClient, logger: zap.NewNop()}
+		mockClient.On("HeaderByNumber", mock.Anything, mock.Anything).Return(nil, errors.New("rpc error"))
+
+		_, err := rpcClient.GetBlockHash(context.Background(), 100)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get block header")
+	})
+}
+
 func TestEVMRPCClient_Close(t *testing.T) {
 	t.Parallel()
 
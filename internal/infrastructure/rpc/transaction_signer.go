@@ -2,20 +2,24 @@ package rpc
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/keyprovider"
 	"go.uber.org/zap"
 )
 
-// SignedTransactionClient interface para operações de assinatura e envio
+// ErrChainIDMismatch chain ID retornado pelo nó difere do esperado na configuração
+var ErrChainIDMismatch = errors.New("chain ID mismatch")
+
+// SignedTransactionClient interface para operações de assinatura e envio; "already known" do node conta
+// como envio bem-sucedido e retorna o hash da transação assinada, que também acompanha as falhas de envio
 type SignedTransactionClient interface {
-	SignAndSendTransaction(ctx context.Context, tx *types.Transaction, privateKey string) (string, error)
+	SignAndSendTransaction(ctx context.Context, tx *types.Transaction, fromAddress string) (string, error)
 	WaitForConfirmations(ctx context.Context, txHash string, requiredConfirmations int) (*types.Receipt, error)
 }
 
@@ -23,49 +27,81 @@ type SignedTransactionClient interface {
 type TransactionSigner struct {
 	client       EthClient
 	chainID      *big.Int
+	keys         keyprovider.KeyProvider
 	logger       *zap.Logger
 	timeout      time.Duration
 	pollInterval time.Duration
 }
 
-// NewTransactionSigner cria um novo signer
-func NewTransactionSigner(client EthClient, chainID *big.Int, logger *zap.Logger, timeout time.Duration) *TransactionSigner {
+// NewTransactionSigner cria um novo signer; as chaves são resolvidas pelo KeyProvider
+func NewTransactionSigner(client EthClient, chainID *big.Int, keys keyprovider.KeyProvider, logger *zap.Logger, timeout time.Duration) *TransactionSigner {
 	return &TransactionSigner{
 		client:       client,
 		chainID:      chainID,
+		keys:         keys,
 		logger:       logger,
 		timeout:      timeout,
 		pollInterval: 3 * time.Second,
 	}
 }
 
-// SignAndSendTransaction assina e envia uma transação
-func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, tx *types.Transaction, privateKeyHex string) (string, error) {
+// NewChainSigner cria o signer de uma chain usando o chain ID informado pelo nó RPC
+func NewChainSigner(ctx context.Context, client EthClientProvider, expectedChainID *big.Int, keys keyprovider.KeyProvider, logger *zap.Logger, timeout time.Duration) (*TransactionSigner, error) {
+	chainID, err := client.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if expectedChainID != nil && chainID.Cmp(expectedChainID) != 0 {
+		return nil, fmt.Errorf("%w: node reports %s, expected %s", ErrChainIDMismatch, chainID, expectedChainID)
+	}
+
+	return NewTransactionSigner(client.GetEthClient(), chainID, keys, logger, timeout), nil
+}
+
+// ChainID retorna o chain ID usado na assinatura
+func (s *TransactionSigner) ChainID() *big.Int {
+	return new(big.Int).Set(s.chainID)
+}
+
+// SignAndSendTransaction assina com a chave do from_address e envia a transação
+func (s *TransactionSigner) SignAndSendTransaction(ctx context.Context, tx *types.Transaction, fromAddress string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.timeout)
 	defer cancel()
 
-	// Parse private key
-	pk, err := parsePrivateKey(privateKeyHex)
+	if !common.IsHexAddress(fromAddress) {
+		return "", fmt.Errorf("invalid from address: %s", fromAddress)
+	}
+
+	// Resolve signing key
+	key, err := s.keys.Key(ctx, common.HexToAddress(fromAddress))
 	if err != nil {
-		s.logger.Error("failed to parse private key", zap.Error(err))
-		return "", fmt.Errorf("invalid private key: %w", err)
+		s.logger.Error("failed to resolve signing key",
+			zap.String("from_address", fromAddress),
+			zap.Error(err))
+		return "", fmt.Errorf("failed to resolve signing key: %w", err)
 	}
 
 	// Sign transaction
-	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(s.chainID), pk)
+	// London signer assina tanto DynamicFeeTx quanto transações legacy (EIP-155)
+	signedTx, err := keyprovider.SignTx(ctx, key, tx, types.NewLondonSigner(s.chainID))
 	if err != nil {
 		s.logger.Error("failed to sign transaction", zap.Error(err))
 		return "", fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	// Send transaction
+	// Send transaction; "already known" indica que a mesma transação assinada já está no mempool
+	txHash := signedTx.Hash().Hex()
 	err = s.client.SendTransaction(ctx, signedTx)
+	if IsAlreadyKnown(err) {
+		s.logger.Info("transaction already known by the node", zap.String("tx_hash", txHash))
+		return txHash, nil
+	}
 	if err != nil {
-		s.logger.Error("failed to send signed transaction", zap.Error(err))
-		return "", fmt.Errorf("failed to send transaction: %w", err)
+		s.logger.Error("failed to send signed transaction", zap.String("tx_hash", txHash), zap.Error(err))
+		return txHash, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	txHash := signedTx.Hash().Hex()
 	s.logger.Info("transaction sent", zap.String("tx_hash", txHash))
 	return txHash, nil
 }
@@ -111,17 +147,3 @@ func (s *TransactionSigner) WaitForConfirmations(ctx context.Context, txHashHex
 		}
 	}
 }
-
-// parsePrivateKey parses hex private key to ECDSA private key
-func parsePrivateKey(hexKey string) (*ecdsa.PrivateKey, error) {
-	// Remove '0x' prefix if present
-	if len(hexKey) > 2 && hexKey[:2] == "0x" {
-		hexKey = hexKey[2:]
-	}
-
-	pk, err := crypto.HexToECDSA(hexKey)
-	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
-	}
-	return pk, nil
-}
@@ -10,8 +10,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gabrielksneiva/ChainEVM/internal/infrastructure/keyprovider"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -21,7 +23,7 @@ func TestNewTransactionSigner(t *testing.T) {
 	chainID := big.NewInt(11155111)
 	timeout := 30 * time.Second
 
-	signer := NewTransactionSigner(mockClient, chainID, logger, timeout)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, timeout)
 
 	assert.NotNil(t, signer)
 	assert.Equal(t, chainID, signer.chainID)
@@ -29,11 +31,49 @@ func TestNewTransactionSigner(t *testing.T) {
 	assert.Equal(t, 3*time.Second, signer.pollInterval)
 }
 
+func TestNewChainSigner(t *testing.T) {
+	logger := zap.NewNop()
+
+	t.Run("use chain ID reported by node", func(t *testing.T) {
+		mockClient := new(MockEthClient)
+		mockClient.On("ChainID", mock.Anything).Return(big.NewInt(137), nil)
+		client := &EVMRPCClient{client: mockClient, logger: logger, timeout: time.Second}
+
+		signer, err := NewChainSigner(context.Background(), client, big.NewInt(137), testKeyProvider(t), logger, time.Second)
+
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(137), signer.ChainID())
+		assert.Equal(t, mockClient, signer.client)
+	})
+
+	t.Run("fail on chain ID mismatch", func(t *testing.T) {
+		mockClient := new(MockEthClient)
+		mockClient.On("ChainID", mock.Anything).Return(big.NewInt(11155111), nil)
+		client := &EVMRPCClient{client: mockClient, logger: logger, timeout: time.Second}
+
+		signer, err := NewChainSigner(context.Background(), client, big.NewInt(1), testKeyProvider(t), logger, time.Second)
+
+		assert.Nil(t, signer)
+		assert.ErrorIs(t, err, ErrChainIDMismatch)
+	})
+
+	t.Run("fail when node is unreachable", func(t *testing.T) {
+		mockClient := new(MockEthClient)
+		mockClient.On("ChainID", mock.Anything).Return(nil, errors.New("connection refused"))
+		client := &EVMRPCClient{client: mockClient, logger: logger, timeout: time.Second}
+
+		signer, err := NewChainSigner(context.Background(), client, big.NewInt(1), testKeyProvider(t), logger, time.Second)
+
+		assert.Nil(t, signer)
+		assert.Error(t, err)
+	})
+}
+
 func TestWaitForConfirmations_Success(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 10*time.Second)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 10*time.Second)
 
 	txHash := common.HexToHash("0x123abc")
 	blockNumber := uint64(1000)
@@ -57,7 +97,7 @@ func TestWaitForConfirmations_Timeout(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 100*time.Millisecond)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 100*time.Millisecond)
 
 	txHash := common.HexToHash("0x123abc")
 
@@ -76,7 +116,7 @@ func TestWaitForConfirmations_ErrorOnBlockNumber(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 5*time.Second)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
 
 	txHash := common.HexToHash("0x123abc")
 
@@ -94,91 +134,156 @@ func TestWaitForConfirmations_ErrorOnBlockNumber(t *testing.T) {
 	assert.Nil(t, receipt)
 }
 
-func TestParsePrivateKey_Valid(t *testing.T) {
-	// Valid test private key (secp256k1)
-	validKey := "ac0974bec39a17e36ba4a6b4d238ff944bacb476c6b8d6c1f02b28d62f0a77fc"
+const (
+	// Conta #0 do Hardhat/Anvil, usada apenas em testes
+	testPrivateKey  = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	testFromAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+)
 
-	pk, err := parsePrivateKey(validKey)
+func testKeyProvider(t *testing.T) keyprovider.KeyProvider {
+	t.Helper()
 
-	assert.NoError(t, err)
-	assert.NotNil(t, pk)
+	provider, err := keyprovider.NewLocalKeyProvider([]string{testPrivateKey})
+	require.NoError(t, err)
+	return provider
 }
 
-func TestParsePrivateKey_WithPrefix(t *testing.T) {
-	// Valid test private key with 0x prefix
-	validKey := "0xac0974bec39a17e36ba4a6b4d238ff944bacb476c6b8d6c1f02b28d62f0a77fc"
-
-	pk, err := parsePrivateKey(validKey)
-
-	assert.NoError(t, err)
-	assert.NotNil(t, pk)
-}
+func TestSignAndSendTransaction_InvalidFromAddress(t *testing.T) {
+	mockClient := new(MockEthClient)
+	logger := zap.NewNop()
+	chainID := big.NewInt(11155111)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
 
-func TestParsePrivateKey_Invalid(t *testing.T) {
-	invalidKey := "invalid"
+	ctx := context.Background()
+	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
 
-	pk, err := parsePrivateKey(invalidKey)
+	txHash, err := signer.SignAndSendTransaction(ctx, tx, "invalid_address")
 
 	assert.Error(t, err)
-	assert.Nil(t, pk)
+	assert.Empty(t, txHash)
 }
 
-func TestSignAndSendTransaction_InvalidPrivateKey(t *testing.T) {
+func TestSignAndSendTransaction_UnknownAccount(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 5*time.Second)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
 
 	ctx := context.Background()
 	tx := types.NewTransaction(0, common.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
 
-	txHash, err := signer.SignAndSendTransaction(ctx, tx, "invalid_key")
+	txHash, err := signer.SignAndSendTransaction(ctx, tx, "0x1234567890123456789012345678901234567890")
 
 	assert.Error(t, err)
+	assert.ErrorIs(t, err, keyprovider.ErrKeyNotFound)
 	assert.Empty(t, txHash)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
 }
 
 func TestSignAndSendTransaction_Success(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 5*time.Second)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
 
 	ctx := context.Background()
 	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
 	tx := types.NewTransaction(0, to, big.NewInt(1000), 21000, big.NewInt(20000000000), nil)
 
-	// Valid Ethereum private key (32 bytes hex)
-	validPrivateKey := "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
-
-	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).Return(nil)
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil)
 
-	txHash, err := signer.SignAndSendTransaction(ctx, tx, validPrivateKey)
+	txHash, err := signer.SignAndSendTransaction(ctx, tx, testFromAddress)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, txHash)
 	assert.True(t, strings.HasPrefix(txHash, "0x"))
 	mockClient.AssertExpectations(t)
+
+	// A assinatura deve recuperar o from_address
+	sender, err := types.Sender(types.NewEIP155Signer(chainID), sent)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(testFromAddress), sender)
+}
+
+func TestSignAndSendTransaction_DynamicFeeTx(t *testing.T) {
+	mockClient := new(MockEthClient)
+	logger := zap.NewNop()
+	chainID := big.NewInt(137)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
+
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     4,
+		GasTipCap: big.NewInt(2000000000),
+		GasFeeCap: big.NewInt(30000000000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+	})
+
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil)
+
+	txHash, err := signer.SignAndSendTransaction(context.Background(), tx, testFromAddress)
+
+	require.NoError(t, err)
+	assert.Equal(t, sent.Hash().Hex(), txHash)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), sent.Type())
+	assert.Equal(t, chainID, sent.ChainId())
+
+	sender, err := types.Sender(types.NewLondonSigner(chainID), sent)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(testFromAddress), sender)
 }
 
 func TestSignAndSendTransaction_SendError(t *testing.T) {
 	mockClient := new(MockEthClient)
 	logger := zap.NewNop()
 	chainID := big.NewInt(11155111)
-	signer := NewTransactionSigner(mockClient, chainID, logger, 5*time.Second)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
 
 	ctx := context.Background()
 	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
 	tx := types.NewTransaction(0, to, big.NewInt(1000), 21000, big.NewInt(20000000000), nil)
 
-	validPrivateKey := "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(errors.New("send failed"))
 
-	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).Return(errors.New("send failed"))
-
-	txHash, err := signer.SignAndSendTransaction(ctx, tx, validPrivateKey)
+	txHash, err := signer.SignAndSendTransaction(ctx, tx, testFromAddress)
 
 	assert.Error(t, err)
-	assert.Empty(t, txHash)
+	// O hash da transação assinada acompanha a falha de envio
+	require.NotNil(t, sent)
+	assert.Equal(t, sent.Hash().Hex(), txHash)
 	assert.Contains(t, err.Error(), "send failed")
 	mockClient.AssertExpectations(t)
 }
+
+func TestSignAndSendTransaction_AlreadyKnown(t *testing.T) {
+	mockClient := new(MockEthClient)
+	logger := zap.NewNop()
+	chainID := big.NewInt(11155111)
+	signer := NewTransactionSigner(mockClient, chainID, testKeyProvider(t), logger, 5*time.Second)
+
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+	tx := types.NewTransaction(0, to, big.NewInt(1000), 21000, big.NewInt(20000000000), nil)
+
+	// A transação assinada já está no mempool: conta como envio bem-sucedido
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.AnythingOfType("*types.Transaction")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(errors.New("already known")).Once()
+
+	txHash, err := signer.SignAndSendTransaction(context.Background(), tx, testFromAddress)
+
+	require.NoError(t, err)
+	assert.Equal(t, sent.Hash().Hex(), txHash)
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+}
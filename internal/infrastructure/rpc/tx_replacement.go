@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MinFeeBumpPercent aumento mínimo de taxas aceito pelos nodes para substituir uma transação com o mesmo nonce
+const MinFeeBumpPercent = 10
+
+// cancelGasLimit gas de uma transferência simples (cancelamento)
+const cancelGasLimit = 21000
+
+// ReplacementPolicy regras de substituição de transações presas no mempool
+type ReplacementPolicy struct {
+	// StuckAfter tempo sem mineração após o último envio para considerar a transação presa
+	StuckAfter time.Duration
+	// FeeBumpPercent aumento percentual das taxas a cada substituição (mínimo MinFeeBumpPercent)
+	FeeBumpPercent int64
+	// MaxReplacements substituições por operação antes de desistir
+	MaxReplacements int
+}
+
+// DefaultReplacementPolicy política padrão de substituição
+func DefaultReplacementPolicy() ReplacementPolicy {
+	return ReplacementPolicy{
+		StuckAfter:      3 * time.Minute,
+		FeeBumpPercent:  12,
+		MaxReplacements: 3,
+	}
+}
+
+// TransactionFees taxas de uma transação não assinada
+func TransactionFees(tx *types.Transaction) *FeeData {
+	if tx.Type() == types.DynamicFeeTxType {
+		return &FeeData{
+			MaxFeePerGas:         new(big.Int).Set(tx.GasFeeCap()),
+			MaxPriorityFeePerGas: new(big.Int).Set(tx.GasTipCap()),
+		}
+	}
+	return &FeeData{GasPrice: new(big.Int).Set(tx.GasPrice())}
+}
+
+// ReplacementFees taxas da substituição: as anteriores com o aumento mínimo, nunca abaixo das taxas atuais da rede
+func ReplacementFees(previous *FeeData, current *FeeData, bumpPercent int64) *FeeData {
+	if bumpPercent < MinFeeBumpPercent {
+		bumpPercent = MinFeeBumpPercent
+	}
+
+	if previous.IsDynamic() {
+		tip := bumpFee(previous.MaxPriorityFeePerGas, bumpPercent)
+		feeCap := bumpFee(previous.MaxFeePerGas, bumpPercent)
+		if current != nil {
+			tip = maxFee(tip, current.MaxPriorityFeePerGas)
+			feeCap = maxFee(feeCap, current.MaxFeePerGas, current.GasPrice)
+		}
+		return &FeeData{
+			MaxFeePerGas:         maxFee(feeCap, tip),
+			MaxPriorityFeePerGas: tip,
+		}
+	}
+
+	gasPrice := bumpFee(previous.GasPrice, bumpPercent)
+	if current != nil {
+		gasPrice = maxFee(gasPrice, current.GasPrice, current.MaxFeePerGas)
+	}
+	return &FeeData{GasPrice: gasPrice}
+}
+
+// SpeedUpTransaction a mesma transação (nonce, destino, valor e dados) com novas taxas
+func SpeedUpTransaction(tx *types.Transaction, fees *FeeData) *types.Transaction {
+	return newReplacementTx(tx.Nonce(), tx.To(), tx.Value(), tx.Gas(), tx.Data(), fees)
+}
+
+// CancelTransaction transferência de valor zero para o próprio remetente, ocupando o nonce da transação cancelada
+func CancelTransaction(fromAddress common.Address, nonce uint64, fees *FeeData) *types.Transaction {
+	return newReplacementTx(nonce, &fromAddress, big.NewInt(0), cancelGasLimit, nil, fees)
+}
+
+// EncodeTransaction serializa a transação não assinada (hex) para persistência
+func EncodeTransaction(tx *types.Transaction) (string, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	return hexutil.Encode(raw), nil
+}
+
+// DecodeTransaction reconstrói a transação serializada por EncodeTransaction
+func DecodeTransaction(raw string) (*types.Transaction, error) {
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// newReplacementTx monta a transação no formato das taxas (EIP-1559 ou legacy)
+func newReplacementTx(nonce uint64, to *common.Address, value *big.Int, gas uint64, data []byte, fees *FeeData) *types.Transaction {
+	if fees.IsDynamic() {
+		// ChainID é preenchido pelo signer na assinatura
+		return types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasTipCap: fees.MaxPriorityFeePerGas,
+			GasFeeCap: fees.MaxFeePerGas,
+			Gas:       gas,
+			To:        to,
+			Value:     value,
+			Data:      data,
+		})
+	}
+
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: fees.GasPrice,
+		Gas:      gas,
+		To:       to,
+		Value:    value,
+		Data:     data,
+	})
+}
+
+// bumpFee aumenta a taxa em percent%, arredondando para cima
+func bumpFee(fee *big.Int, percent int64) *big.Int {
+	if fee == nil {
+		return big.NewInt(0)
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// maxFee maior valor entre as taxas informadas, ignorando nil
+func maxFee(fee *big.Int, others ...*big.Int) *big.Int {
+	result := fee
+	for _, other := range others {
+		if other != nil && other.Cmp(result) > 0 {
+			result = other
+		}
+	}
+	return new(big.Int).Set(result)
+}
@@ -0,0 +1,124 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplacementFees(t *testing.T) {
+	t.Run("bump dynamic fees by policy percent", func(t *testing.T) {
+		fees := ReplacementFees(&FeeData{MaxFeePerGas: gwei(100), MaxPriorityFeePerGas: gwei(2)}, nil, 12)
+
+		assert.Equal(t, gwei(112), fees.MaxFeePerGas)
+		assert.Equal(t, new(big.Int).Div(gwei(224), big.NewInt(100)), fees.MaxPriorityFeePerGas)
+		assert.Nil(t, fees.GasPrice)
+	})
+
+	t.Run("never bump below node minimum", func(t *testing.T) {
+		fees := ReplacementFees(&FeeData{GasPrice: gwei(50)}, nil, 1)
+
+		assert.Equal(t, gwei(55), fees.GasPrice)
+	})
+
+	t.Run("round bump up", func(t *testing.T) {
+		fees := ReplacementFees(&FeeData{GasPrice: big.NewInt(15)}, nil, 10)
+
+		assert.Equal(t, big.NewInt(17), fees.GasPrice)
+	})
+
+	t.Run("follow current market when higher", func(t *testing.T) {
+		fees := ReplacementFees(
+			&FeeData{MaxFeePerGas: gwei(30), MaxPriorityFeePerGas: gwei(1)},
+			&FeeData{MaxFeePerGas: gwei(80), MaxPriorityFeePerGas: gwei(3)},
+			10,
+		)
+
+		assert.Equal(t, gwei(80), fees.MaxFeePerGas)
+		assert.Equal(t, gwei(3), fees.MaxPriorityFeePerGas)
+	})
+
+	t.Run("legacy transaction with dynamic market fees", func(t *testing.T) {
+		fees := ReplacementFees(&FeeData{GasPrice: gwei(10)}, &FeeData{MaxFeePerGas: gwei(40), MaxPriorityFeePerGas: gwei(2)}, 10)
+
+		assert.False(t, fees.IsDynamic())
+		assert.Equal(t, gwei(40), fees.GasPrice)
+	})
+
+	t.Run("fee cap covers bumped tip", func(t *testing.T) {
+		fees := ReplacementFees(&FeeData{MaxFeePerGas: gwei(1), MaxPriorityFeePerGas: gwei(1)}, &FeeData{MaxPriorityFeePerGas: gwei(5)}, 10)
+
+		assert.Equal(t, gwei(5), fees.MaxFeePerGas)
+	})
+}
+
+func TestSpeedUpTransaction(t *testing.T) {
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+	original := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     7,
+		GasTipCap: gwei(1),
+		GasFeeCap: gwei(20),
+		Gas:       50000,
+		To:        &to,
+		Value:     big.NewInt(1000),
+		Data:      []byte{0xa9, 0x05, 0x9c, 0xbb},
+	})
+
+	replacement := SpeedUpTransaction(original, &FeeData{MaxFeePerGas: gwei(30), MaxPriorityFeePerGas: gwei(2)})
+
+	assert.Equal(t, uint8(types.DynamicFeeTxType), replacement.Type())
+	assert.Equal(t, original.Nonce(), replacement.Nonce())
+	assert.Equal(t, original.To(), replacement.To())
+	assert.Equal(t, original.Value(), replacement.Value())
+	assert.Equal(t, original.Data(), replacement.Data())
+	assert.Equal(t, original.Gas(), replacement.Gas())
+	assert.Equal(t, gwei(30), replacement.GasFeeCap())
+	assert.Equal(t, gwei(2), replacement.GasTipCap())
+	assert.Equal(t, &FeeData{MaxFeePerGas: gwei(30), MaxPriorityFeePerGas: gwei(2)}, TransactionFees(replacement))
+}
+
+func TestCancelTransaction(t *testing.T) {
+	from := common.HexToAddress("0x1234567890123456789012345678901234567890")
+
+	cancel := CancelTransaction(from, 9, &FeeData{GasPrice: gwei(11)})
+
+	assert.Equal(t, uint8(types.LegacyTxType), cancel.Type())
+	assert.Equal(t, uint64(9), cancel.Nonce())
+	assert.Equal(t, &from, cancel.To())
+	assert.Equal(t, int64(0), cancel.Value().Int64())
+	assert.Empty(t, cancel.Data())
+	assert.Equal(t, uint64(21000), cancel.Gas())
+	assert.Equal(t, &FeeData{GasPrice: gwei(11)}, TransactionFees(cancel))
+}
+
+func TestEncodeDecodeTransaction(t *testing.T) {
+	to := common.HexToAddress("0x0987654321098765432109876543210987654321")
+
+	for name, tx := range map[string]*types.Transaction{
+		"legacy":  types.NewTx(&types.LegacyTx{Nonce: 4, GasPrice: gwei(10), Gas: 21000, To: &to, Value: big.NewInt(1)}),
+		"dynamic": types.NewTx(&types.DynamicFeeTx{Nonce: 4, GasTipCap: gwei(2), GasFeeCap: gwei(30), Gas: 50000, To: &to, Data: []byte{0xa9, 0x05}}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw, err := EncodeTransaction(tx)
+			require.NoError(t, err)
+
+			decoded, err := DecodeTransaction(raw)
+
+			require.NoError(t, err)
+			assert.Equal(t, tx.Type(), decoded.Type())
+			assert.Equal(t, tx.Nonce(), decoded.Nonce())
+			assert.Equal(t, tx.To(), decoded.To())
+			assert.Equal(t, tx.Hash(), decoded.Hash())
+			assert.Equal(t, TransactionFees(tx), TransactionFees(decoded))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := DecodeTransaction("0xzz")
+		assert.Error(t, err)
+	})
+}
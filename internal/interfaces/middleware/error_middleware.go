@@ -2,12 +2,40 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
 	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"go.uber.org/zap"
 )
 
+// errorResponse status e código HTTP de um código de erro
+type errorResponse struct {
+	status     string
+	statusCode int
+}
+
+// errorResponses resposta de cada sentinel de pkg/errors; códigos ausentes viram ERROR/500
+var errorResponses = map[string]errorResponse{
+	pkgerrors.ErrInvalidInput.Code:           {"INVALID_INPUT", http.StatusBadRequest},
+	pkgerrors.ErrValidationFailed.Code:       {"VALIDATION_ERROR", http.StatusBadRequest},
+	pkgerrors.ErrChainNotSupported.Code:      {"CHAIN_NOT_SUPPORTED", http.StatusBadRequest},
+	pkgerrors.ErrOperationNotFound.Code:      {"NOT_FOUND", http.StatusNotFound},
+	pkgerrors.ErrNotImplemented.Code:         {"NOT_IMPLEMENTED", http.StatusNotImplemented},
+	pkgerrors.ErrRPCFailed.Code:              {"RPC_ERROR", http.StatusBadGateway},
+	pkgerrors.ErrRPCUnavailable.Code:         {"RPC_UNAVAILABLE", http.StatusServiceUnavailable},
+	pkgerrors.ErrTransactionFailed.Code:      {"TRANSACTION_FAILED", http.StatusUnprocessableEntity},
+	pkgerrors.ErrGasEstimationFailed.Code:    {"GAS_ESTIMATION_FAILED", http.StatusUnprocessableEntity},
+	pkgerrors.ErrInsufficientFunds.Code:      {"INSUFFICIENT_FUNDS", http.StatusUnprocessableEntity},
+	pkgerrors.ErrExecutionReverted.Code:      {"EXECUTION_REVERTED", http.StatusUnprocessableEntity},
+	pkgerrors.ErrNonceTooLow.Code:            {"NONCE_CONFLICT", http.StatusConflict},
+	pkgerrors.ErrReplacementUnderpriced.Code: {"NONCE_CONFLICT", http.StatusConflict},
+	pkgerrors.ErrDatabaseError.Code:          {"DATABASE_ERROR", http.StatusInternalServerError},
+	pkgerrors.ErrDatabaseThrottled.Code:      {"DATABASE_THROTTLED", http.StatusServiceUnavailable},
+	pkgerrors.ErrSQSError.Code:               {"SQS_ERROR", http.StatusInternalServerError},
+}
+
 // ErrorMiddleware middleware para tratamento de erros
 type ErrorMiddleware struct {
 	logger *zap.Logger
@@ -20,33 +48,47 @@ func NewErrorMiddleware(logger *zap.Logger) *ErrorMiddleware {
 	}
 }
 
-// HandleError processa um erro e retorna uma resposta apropriada
+// HandleError processa um erro e retorna uma resposta apropriada; AppErrors encapsulados
+// (fmt.Errorf com %w) são reconhecidos
 func (m *ErrorMiddleware) HandleError(ctx context.Context, err error) (string, int, string) {
 	if err == nil {
 		return "SUCCESS", 200, ""
 	}
 
-	appErr, ok := err.(*pkgerrors.AppError)
-	if !ok {
+	var appErr *pkgerrors.AppError
+	if !errors.As(err, &appErr) {
 		m.logger.Error("unknown error type",
 			zap.Error(err))
 		return "ERROR", 500, fmt.Sprintf("internal server error: %v", err)
 	}
 
-	switch appErr.Code {
-	case pkgerrors.ErrValidationFailed.Code:
-		return "VALIDATION_ERROR", 400, appErr.Message
-
-	case pkgerrors.ErrChainNotSupported.Code:
-		return "CHAIN_NOT_SUPPORTED", 400, appErr.Message
-
-	case pkgerrors.ErrRPCFailed.Code:
-		return "RPC_ERROR", 502, appErr.Message
+	response, ok := errorResponses[appErr.Code]
+	if !ok {
+		response = errorResponse{"ERROR", http.StatusInternalServerError}
+	}
 
-	case pkgerrors.ErrDatabaseError.Code:
-		return "DATABASE_ERROR", 500, appErr.Message
+	if response.statusCode >= http.StatusInternalServerError {
+		m.logger.Error("request failed", append(detailFields(appErr.Details),
+			zap.String("code", appErr.Code),
+			zap.Error(err))...)
+	}
+	return response.status, response.statusCode, appErr.Message
+}
 
-	default:
-		return "ERROR", 500, appErr.Message
+// detailFields campos de log do contexto do erro
+func detailFields(details pkgerrors.Details) []zap.Field {
+	var fields []zap.Field
+	if details.Chain != "" {
+		fields = append(fields, zap.String("chain", details.Chain))
+	}
+	if details.OperationID != "" {
+		fields = append(fields, zap.String("operation_id", details.OperationID))
+	}
+	if details.TxHash != "" {
+		fields = append(fields, zap.String("tx_hash", details.TxHash))
+	}
+	if details.RevertData != "" {
+		fields = append(fields, zap.String("revert_data", details.RevertData))
 	}
+	return fields
 }
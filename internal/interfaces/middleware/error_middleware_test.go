@@ -2,12 +2,15 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	pkgerrors "github.com/gabrielksneiva/ChainEVM/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // contextKey for error middleware tests
@@ -112,9 +115,8 @@ func TestHandleErrorOperationNotFound(t *testing.T) {
 
 	status, code, _ := middleware.HandleError(context.Background(), err)
 
-	// ErrOperationNotFound not explicitly handled, defaults to ERROR/500
-	assert.Equal(t, "ERROR", status)
-	assert.Equal(t, 500, code)
+	assert.Equal(t, "NOT_FOUND", status)
+	assert.Equal(t, 404, code)
 }
 
 func TestHandleErrorInvalidInput(t *testing.T) {
@@ -129,9 +131,8 @@ func TestHandleErrorInvalidInput(t *testing.T) {
 
 	status, code, _ := middleware.HandleError(context.Background(), err)
 
-	// ErrInvalidInput not explicitly handled, defaults to ERROR/500
-	assert.Equal(t, "ERROR", status)
-	assert.Equal(t, 500, code)
+	assert.Equal(t, "INVALID_INPUT", status)
+	assert.Equal(t, 400, code)
 }
 
 func TestHandleErrorWithContext(t *testing.T) {
@@ -243,6 +244,12 @@ func TestHandleErrorAllErrorCodes(t *testing.T) {
 			expectedStatus: "RPC_ERROR",
 			expectedCode:   502,
 		},
+		{
+			name:           "rpc unavailable",
+			errorCode:      pkgerrors.ErrRPCUnavailable.Code,
+			expectedStatus: "RPC_UNAVAILABLE",
+			expectedCode:   503,
+		},
 		{
 			name:           "database error",
 			errorCode:      pkgerrors.ErrDatabaseError.Code,
@@ -285,7 +292,71 @@ func TestHandleErrorInsufficientFunds(t *testing.T) {
 
 	status, code, msg := middleware.HandleError(context.Background(), err)
 
-	assert.Equal(t, "ERROR", status)
-	assert.Equal(t, 500, code)
+	assert.Equal(t, "INSUFFICIENT_FUNDS", status)
+	assert.Equal(t, 422, code)
 	assert.Equal(t, "insufficient funds for transaction", msg)
 }
+
+func TestHandleErrorWrappedAppError(t *testing.T) {
+	logger := zap.NewNop()
+	middleware := NewErrorMiddleware(logger)
+
+	appErr := pkgerrors.NewAppError(pkgerrors.ErrGasEstimationFailed.Code, "failed to estimate gas", errors.New("execution reverted"))
+	err := fmt.Errorf("execute transaction: %w", appErr)
+
+	status, code, msg := middleware.HandleError(context.Background(), err)
+
+	assert.Equal(t, "GAS_ESTIMATION_FAILED", status)
+	assert.Equal(t, 422, code)
+	assert.Equal(t, "failed to estimate gas", msg)
+}
+
+func TestHandleErrorEverySentinelIsMapped(t *testing.T) {
+	logger := zap.NewNop()
+	middleware := NewErrorMiddleware(logger)
+
+	sentinels := []*pkgerrors.AppError{
+		pkgerrors.ErrInvalidInput,
+		pkgerrors.ErrValidationFailed,
+		pkgerrors.ErrRPCFailed,
+		pkgerrors.ErrRPCUnavailable,
+		pkgerrors.ErrTransactionFailed,
+		pkgerrors.ErrChainNotSupported,
+		pkgerrors.ErrOperationNotFound,
+		pkgerrors.ErrNotImplemented,
+		pkgerrors.ErrDatabaseError,
+		pkgerrors.ErrDatabaseThrottled,
+		pkgerrors.ErrSQSError,
+		pkgerrors.ErrGasEstimationFailed,
+		pkgerrors.ErrInsufficientFunds,
+		pkgerrors.ErrNonceTooLow,
+		pkgerrors.ErrReplacementUnderpriced,
+		pkgerrors.ErrExecutionReverted,
+	}
+
+	for _, sentinel := range sentinels {
+		t.Run(sentinel.Code, func(t *testing.T) {
+			status, _, _ := middleware.HandleError(context.Background(), pkgerrors.NewAppError(sentinel.Code, "error message", nil))
+
+			assert.NotEqual(t, "ERROR", status)
+		})
+	}
+}
+
+func TestHandleErrorLogsDetails(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	middleware := NewErrorMiddleware(zap.New(core))
+
+	err := pkgerrors.NewAppError(pkgerrors.ErrDatabaseError.Code, "failed to update transaction", nil).
+		WithDetails(pkgerrors.Details{Chain: "ETHEREUM", OperationID: "op-123", TxHash: "0xabc"})
+
+	_, code, _ := middleware.HandleError(context.Background(), err)
+
+	assert.Equal(t, 500, code)
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	assert.Equal(t, "ETHEREUM", fields["chain"])
+	assert.Equal(t, "op-123", fields["operation_id"])
+	assert.Equal(t, "0xabc", fields["tx_hash"])
+	assert.Equal(t, pkgerrors.ErrDatabaseError.Code, fields["code"])
+}
@@ -1,78 +1,194 @@
 package cache
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"sync"
 	"time"
 )
 
+// ErrSkipStore retornado por um loader do GetOrLoad junto com o valor para entregá-lo sem armazenar
+var ErrSkipStore = errors.New("cache: skip store")
+
+// NoExpiration TTL de entradas que não expiram; TTL 0 usa o padrão do cache
+const NoExpiration time.Duration = -1
+
+// Cache backend do cache de leituras RPC: memória do processo (RPCCache) ou Redis (RedisCache)
+type Cache interface {
+	// Get retorna o valor se existir e não expirou
+	Get(ctx context.Context, key string) (any, bool, error)
+	// Set armazena o valor (ttl 0 = TTL padrão, NoExpiration = não expira)
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	// GetOrLoad retorna o valor em cache ou carrega uma única vez entre chamadas concorrentes da mesma chave;
+	// hit indica que o valor não foi carregado por esta chamada
+	GetOrLoad(ctx context.Context, key string, ttl time.Duration, load LoadFunc) (value any, hit bool, err error)
+	// Delete remove a entrada
+	Delete(ctx context.Context, key string) error
+	// DeletePrefix remove as entradas cujas chaves começam com prefix e retorna quantas foram removidas
+	DeletePrefix(ctx context.Context, prefix string) (int, error)
+	// Close libera os recursos do backend
+	Close() error
+}
+
 // CacheEntry representa uma entrada de cache
 type CacheEntry struct {
-	Value     any
+	Value any
+	// ExpiresAt zero = não expira
 	ExpiresAt time.Time
 }
 
-// RPCCache implementa um cache para chamadas RPC read-only
+func (e *CacheEntry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// Options configuração do cache
+type Options struct {
+	// TTL padrão das entradas (<= 0 = não expiram)
+	TTL time.Duration
+	// MaxSize número máximo de entradas (<= 0 = sem limite)
+	MaxSize int
+	// Policy política de remoção ao atingir MaxSize (padrão LRU)
+	Policy EvictionPolicy
+	// JanitorInterval intervalo da limpeza de entradas expiradas em background (0 = sem janitor)
+	JanitorInterval time.Duration
+}
+
+// Stats estatísticas acumuladas do cache
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+	// Evictions entradas removidas pela política para respeitar MaxSize
+	Evictions uint64
+	// Expirations entradas removidas por TTL
+	Expirations uint64
+	Size        int
+}
+
+// LoadFunc carrega o valor de uma chave ausente
+type LoadFunc func(ctx context.Context) (any, error)
+
+// RPCCache implementa um cache para chamadas RPC read-only, limitado a MaxSize entradas com remoção LRU ou LFU
 type RPCCache struct {
-	mu      sync.RWMutex
-	entries map[string]*CacheEntry
+	mu      sync.Mutex
+	entries map[string]*entry
+	policy  evictionPolicy
 	ttl     time.Duration
 	maxSize int
-}
+	stats   Stats
 
-// NewRPCCache cria um novo cache RPC
-func NewRPCCache(ttl time.Duration, maxSize int) *RPCCache {
-	return &RPCCache{
-		entries: make(map[string]*CacheEntry),
-		ttl:     ttl,
-		maxSize: maxSize,
-	}
+	// loads carregamentos em andamento no GetOrLoad
+	loads loadGroup
+
+	stopJanitor chan struct{}
+	closeOnce   sync.Once
 }
 
-// Get retorna um valor do cache se existir e não expirou
-func (c *RPCCache) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+var _ Cache = (*RPCCache)(nil)
 
-	// Evict all expired entries globally
-	c.evictExpired()
+// NewRPCCache cria um novo cache RPC com remoção LRU e sem janitor
+func NewRPCCache(ttl time.Duration, maxSize int) *RPCCache {
+	return NewRPCCacheWithOptions(Options{TTL: ttl, MaxSize: maxSize})
+}
 
-	entry, ok := c.entries[key]
-	if !ok {
-		return nil, false
+// NewRPCCacheWithOptions cria o cache; com JanitorInterval, Close encerra a limpeza em background
+func NewRPCCacheWithOptions(options Options) *RPCCache {
+	c := &RPCCache{
+		entries: make(map[string]*entry),
+		policy:  newEvictionPolicy(options.Policy),
+		ttl:     options.TTL,
+		maxSize: options.MaxSize,
 	}
 
-	// Verificar se expirou
-	if time.Now().After(entry.ExpiresAt) {
-		return nil, false
+	if options.JanitorInterval > 0 {
+		c.stopJanitor = make(chan struct{})
+		go c.janitor(options.JanitorInterval)
 	}
+	return c
+}
 
-	return entry.Value, true
+// Get retorna um valor do cache se existir e não expirou
+func (c *RPCCache) Get(_ context.Context, key string) (any, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.get(key, time.Now())
+	if ok {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	return value, ok, nil
 }
 
-// Set armazena um valor no cache
-func (c *RPCCache) Set(key string, value any) {
+// Set armazena um valor no cache (ttl 0 = TTL padrão, NoExpiration = não expira)
+func (c *RPCCache) Set(_ context.Context, key string, value any, ttl time.Duration) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.evictExpired()
+	c.set(key, value, ttl)
+	return nil
+}
 
-	// Se cache está cheio, limpar entradas expiradas
-	if len(c.entries) >= c.maxSize {
-		c.evictExpired()
+// GetOrLoad retorna o valor em cache ou chama load uma única vez para as chamadas concorrentes da mesma chave
+// (single-flight), armazenando o resultado com ttl; quem aguardou o carregamento de outra chamada conta como hit.
+// Erros não são armazenados; com ErrSkipStore o valor é entregue sem ir para o cache
+func (c *RPCCache) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loadFn LoadFunc) (any, bool, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key, time.Now()); ok {
+		c.stats.Hits++
+		c.mu.Unlock()
+		return value, true, nil
 	}
+	c.mu.Unlock()
 
-	c.entries[key] = &CacheEntry{
-		Value:     value,
-		ExpiresAt: time.Now().Add(c.ttl),
+	value, shared, err := c.loads.do(ctx, key, func() (any, error) {
+		value, err := loadFn(ctx)
+		if errors.Is(err, ErrSkipStore) {
+			return value, nil
+		}
+		if err == nil {
+			c.mu.Lock()
+			c.set(key, value, ttl)
+			c.mu.Unlock()
+		}
+		return value, err
+	})
+
+	c.mu.Lock()
+	if shared {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
 	}
+	c.mu.Unlock()
+	return value, shared, err
 }
 
 // Delete remove uma entrada do cache
-func (c *RPCCache) Delete(key string) {
+func (c *RPCCache) Delete(_ context.Context, key string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.entries, key)
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	return nil
+}
+
+// DeletePrefix remove as entradas cujas chaves começam com prefix e retorna quantas foram removidas
+func (c *RPCCache) DeletePrefix(_ context.Context, prefix string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key, e := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(e)
+			removed++
+		}
+	}
+	return removed, nil
 }
 
 // Clear limpa todo o cache
@@ -80,25 +196,131 @@ func (c *RPCCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.entries = make(map[string]*CacheEntry)
+	for _, e := range c.entries {
+		c.remove(e)
+	}
+}
+
+// Size retorna o número de entradas válidas (não expiradas) no cache
+func (c *RPCCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size(time.Now())
 }
 
-// evictExpired remove entradas expiradas (deve ser chamado com lock)
-func (c *RPCCache) evictExpired() {
+// Stats retorna as estatísticas acumuladas e o tamanho atual
+func (c *RPCCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Size = c.size(time.Now())
+	return stats
+}
+
+// EvictExpired remove as entradas expiradas e retorna quantas foram removidas (também executado pelo janitor)
+func (c *RPCCache) EvictExpired() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	now := time.Now()
-	for key, entry := range c.entries {
-		if now.After(entry.ExpiresAt) {
-			delete(c.entries, key)
+	removed := 0
+	for _, e := range c.entries {
+		if e.expired(now) {
+			c.remove(e)
+			c.stats.Expirations++
+			removed++
 		}
 	}
+	return removed
 }
 
-// Size retorna o número de entradas no cache
-func (c *RPCCache) Size() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// Close encerra o janitor
+func (c *RPCCache) Close() error {
+	c.closeOnce.Do(func() {
+		if c.stopJanitor != nil {
+			close(c.stopJanitor)
+		}
+	})
+	return nil
+}
 
-	c.evictExpired()
+// janitor remove entradas expiradas periodicamente até Close
+func (c *RPCCache) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	return len(c.entries)
+	for {
+		select {
+		case <-ticker.C:
+			c.EvictExpired()
+		case <-c.stopJanitor:
+			return
+		}
+	}
+}
+
+// get busca a entrada e atualiza a política; remove a entrada se expirou (deve ser chamado com lock)
+func (c *RPCCache) get(key string, now time.Time) (any, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if e.expired(now) {
+		c.remove(e)
+		c.stats.Expirations++
+		return nil, false
+	}
+
+	c.policy.touch(e)
+	return e.Value, true
+}
+
+// set insere ou atualiza a entrada, removendo pela política quando o cache está cheio (deve ser chamado com lock)
+func (c *RPCCache) set(key string, value any, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value, e.ExpiresAt = value, expiresAt
+		c.policy.touch(e)
+		return
+	}
+
+	for c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		victim := c.policy.victim()
+		if victim == nil {
+			break
+		}
+		c.remove(victim)
+		c.stats.Evictions++
+	}
+
+	e := &entry{CacheEntry: CacheEntry{Value: value, ExpiresAt: expiresAt}, key: key}
+	c.entries[key] = e
+	c.policy.add(e)
+}
+
+// remove retira a entrada do mapa e da política (deve ser chamado com lock)
+func (c *RPCCache) remove(e *entry) {
+	delete(c.entries, e.key)
+	c.policy.remove(e)
+}
+
+// size conta as entradas não expiradas sem removê-las (deve ser chamado com lock)
+func (c *RPCCache) size(now time.Time) int {
+	count := 0
+	for _, e := range c.entries {
+		if !e.expired(now) {
+			count++
+		}
+	}
+	return count
 }
@@ -1,7 +1,11 @@
 package cache
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -9,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var ctx = context.Background()
+
 func TestNewRPCCache(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
@@ -19,8 +25,8 @@ func TestNewRPCCache(t *testing.T) {
 func TestCacheSetAndGet(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
-	cache.Set("balance:0x1234", "1000000")
-	value, ok := cache.Get("balance:0x1234")
+	cache.Set(ctx, "balance:0x1234", "1000000", 0)
+	value, ok, _ := cache.Get(ctx, "balance:0x1234")
 
 	require.True(t, ok)
 	assert.Equal(t, "1000000", value)
@@ -29,25 +35,25 @@ func TestCacheSetAndGet(t *testing.T) {
 func TestCacheExpiration(t *testing.T) {
 	cache := NewRPCCache(100*time.Millisecond, 100)
 
-	cache.Set("key", "value")
-	value, ok := cache.Get("key")
+	cache.Set(ctx, "key", "value", 0)
+	value, ok, _ := cache.Get(ctx, "key")
 	require.True(t, ok)
 	assert.Equal(t, "value", value)
 
 	// Aguardar expiração
 	time.Sleep(150 * time.Millisecond)
 
-	_, ok = cache.Get("key")
+	_, ok, _ = cache.Get(ctx, "key")
 	assert.False(t, ok)
 }
 
 func TestCacheDelete(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
-	cache.Set("key", "value")
-	cache.Delete("key")
+	cache.Set(ctx, "key", "value", 0)
+	cache.Delete(ctx, "key")
 
-	_, ok := cache.Get("key")
+	_, ok, _ := cache.Get(ctx, "key")
 	assert.False(t, ok)
 }
 
@@ -55,7 +61,7 @@ func TestCacheGetNonExistent(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
 	// Try to get a key that doesn't exist
-	value, ok := cache.Get("nonexistent")
+	value, ok, _ := cache.Get(ctx, "nonexistent")
 	assert.False(t, ok)
 	assert.Nil(t, value)
 }
@@ -63,8 +69,8 @@ func TestCacheGetNonExistent(t *testing.T) {
 func TestCacheClear(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
-	cache.Set("key1", "value1")
-	cache.Set("key2", "value2")
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Set(ctx, "key2", "value2", 0)
 	assert.Equal(t, 2, cache.Size())
 
 	cache.Clear()
@@ -74,16 +80,16 @@ func TestCacheClear(t *testing.T) {
 func TestCacheMaxSize(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 3)
 
-	cache.Set("key1", "value1")
-	cache.Set("key2", "value2")
-	cache.Set("key3", "value3")
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Set(ctx, "key2", "value2", 0)
+	cache.Set(ctx, "key3", "value3", 0)
 
-	// Ao atingir max size, deve limpar expirados
-	// Como nada expirou, a próxima entrada pode não ser adicionada ou pode remover a mais antiga
-	cache.Set("key4", "value4")
+	// Ao atingir max size, remove a entrada usada há mais tempo (LRU)
+	cache.Set(ctx, "key4", "value4", 0)
 
-	// Verificar que não ultrapassou o tamanho máximo
-	assert.LessOrEqual(t, cache.Size(), 4)
+	assert.Equal(t, 3, cache.Size())
+	_, ok, _ := cache.Get(ctx, "key1")
+	assert.False(t, ok)
 }
 
 func TestCacheMultipleEntries(t *testing.T) {
@@ -92,12 +98,12 @@ func TestCacheMultipleEntries(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		key := "key" + fmt.Sprint(i)
 		val := "value" + fmt.Sprint(i)
-		cache.Set(key, val)
+		cache.Set(ctx, key, val, 0)
 	}
 
 	assert.Equal(t, 10, cache.Size())
 
-	value, ok := cache.Get("key1")
+	value, ok, _ := cache.Get(ctx, "key1")
 	require.True(t, ok)
 	assert.Equal(t, "value1", value)
 }
@@ -106,13 +112,13 @@ func TestCacheEviction(t *testing.T) {
 	// Small cache with max items limit
 	cache := NewRPCCache(10*time.Second, 2)
 
-	cache.Set("key1", "value1")
-	cache.Set("key2", "value2")
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Set(ctx, "key2", "value2", 0)
 
 	assert.Equal(t, 2, cache.Size())
 
 	// This may or may not trigger eviction depending on implementation
-	cache.Set("key3", "value3")
+	cache.Set(ctx, "key3", "value3", 0)
 
 	// Cache size should be > 0
 	size := cache.Size()
@@ -122,7 +128,7 @@ func TestCacheEviction(t *testing.T) {
 func TestCacheNonExistentKey(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
-	_, ok := cache.Get("nonexistent")
+	_, ok, _ := cache.Get(ctx, "nonexistent")
 
 	assert.False(t, ok)
 }
@@ -131,7 +137,7 @@ func TestCacheDeleteNonExistent(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
 	// Should not panic
-	cache.Delete("nonexistent")
+	cache.Delete(ctx, "nonexistent")
 	assert.Equal(t, 0, cache.Size())
 }
 
@@ -139,23 +145,23 @@ func TestCacheSequentialOperations(t *testing.T) {
 	cache := NewRPCCache(10*time.Second, 100)
 
 	// Set
-	cache.Set("addr:0x1111", "100")
+	cache.Set(ctx, "addr:0x1111", "100", 0)
 	assert.Equal(t, 1, cache.Size())
 
 	// Get
-	val, ok := cache.Get("addr:0x1111")
+	val, ok, _ := cache.Get(ctx, "addr:0x1111")
 	assert.True(t, ok)
 	assert.Equal(t, "100", val)
 
 	// Update
-	cache.Set("addr:0x1111", "200")
-	val, ok = cache.Get("addr:0x1111")
+	cache.Set(ctx, "addr:0x1111", "200", 0)
+	val, ok, _ = cache.Get(ctx, "addr:0x1111")
 	assert.True(t, ok)
 	assert.Equal(t, "200", val)
 
 	// Delete
-	cache.Delete("addr:0x1111")
-	_, ok = cache.Get("addr:0x1111")
+	cache.Delete(ctx, "addr:0x1111")
+	_, ok, _ = cache.Get(ctx, "addr:0x1111")
 	assert.False(t, ok)
 }
 
@@ -165,13 +171,13 @@ func TestCacheMultipleKeys(t *testing.T) {
 	keys := []string{"balance", "nonce", "code", "storage"}
 
 	for i, key := range keys {
-		cache.Set(key, fmt.Sprintf("value%d", i))
+		cache.Set(ctx, key, fmt.Sprintf("value%d", i), 0)
 	}
 
 	assert.Equal(t, len(keys), cache.Size())
 
 	for i, key := range keys {
-		val, ok := cache.Get(key)
+		val, ok, _ := cache.Get(ctx, key)
 		assert.True(t, ok)
 		assert.Equal(t, fmt.Sprintf("value%d", i), val)
 	}
@@ -180,8 +186,8 @@ func TestCacheMultipleKeys(t *testing.T) {
 func TestCacheExpirationEdgeCase(t *testing.T) {
 	cache := NewRPCCache(50*time.Millisecond, 100)
 
-	cache.Set("expires", "soon")
-	value, ok := cache.Get("expires")
+	cache.Set(ctx, "expires", "soon", 0)
+	value, ok, _ := cache.Get(ctx, "expires")
 	require.True(t, ok)
 	assert.Equal(t, "soon", value)
 
@@ -189,23 +195,23 @@ func TestCacheExpirationEdgeCase(t *testing.T) {
 	time.Sleep(40 * time.Millisecond)
 
 	// Should still exist
-	_, ok = cache.Get("expires")
+	_, ok, _ = cache.Get(ctx, "expires")
 	assert.True(t, ok)
 
 	// Wait for expiration
 	time.Sleep(30 * time.Millisecond)
 
 	// Should be expired now
-	_, ok = cache.Get("expires")
+	_, ok, _ = cache.Get(ctx, "expires")
 	assert.False(t, ok)
 }
 
 func TestCacheEvictionOnGet(t *testing.T) {
 	cache := NewRPCCache(50*time.Millisecond, 100)
 
-	cache.Set("key1", "value1")
-	cache.Set("key2", "value2")
-	cache.Set("key3", "value3")
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Set(ctx, "key2", "value2", 0)
+	cache.Set(ctx, "key3", "value3", 0)
 
 	// All entries should exist
 	assert.Equal(t, 3, cache.Size())
@@ -214,9 +220,181 @@ func TestCacheEvictionOnGet(t *testing.T) {
 	time.Sleep(60 * time.Millisecond)
 
 	// Calling Get triggers eviction
-	_, ok := cache.Get("key1")
+	_, ok, _ := cache.Get(ctx, "key1")
 	assert.False(t, ok)
 
 	// Cache should now be empty after eviction
 	assert.Equal(t, 0, cache.Size())
 }
+
+func TestCacheSetWithTTL(t *testing.T) {
+	cache := NewRPCCache(10*time.Second, 100)
+
+	cache.Set(ctx, "short", "value", 20*time.Millisecond)
+	cache.Set(ctx, "permanent", "value", NoExpiration)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok, _ := cache.Get(ctx, "short")
+	assert.False(t, ok)
+	value, ok, _ := cache.Get(ctx, "permanent")
+	require.True(t, ok)
+	assert.Equal(t, "value", value)
+}
+
+func TestCacheDeletePrefix(t *testing.T) {
+	cache := NewRPCCache(10*time.Second, 100)
+
+	cache.Set(ctx, "ETHEREUM:block:gas_price", "1", 0)
+	cache.Set(ctx, "ETHEREUM:block:balance:0x1", "2", 0)
+	cache.Set(ctx, "ETHEREUM:chain_id", "1", 0)
+	cache.Set(ctx, "POLYGON:block:gas_price", "3", 0)
+
+	removed, _ := cache.DeletePrefix(ctx, "ETHEREUM:block:")
+
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, 2, cache.Size())
+	_, ok, _ := cache.Get(ctx, "POLYGON:block:gas_price")
+	assert.True(t, ok)
+}
+
+func TestCacheGetOrLoad(t *testing.T) {
+	t.Run("load once for concurrent misses", func(t *testing.T) {
+		cache := NewRPCCache(10*time.Second, 100)
+		var loads int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		results := make([]any, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				value, _, err := cache.GetOrLoad(context.Background(), "chain_id", 0, func(ctx context.Context) (any, error) {
+					atomic.AddInt32(&loads, 1)
+					<-release
+					return "1", nil
+				})
+				assert.NoError(t, err)
+				results[i] = value
+			}(i)
+		}
+
+		// Aguarda a primeira chamada iniciar o carregamento
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&loads) == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+		for _, value := range results {
+			assert.Equal(t, "1", value)
+		}
+		value, hit, err := cache.GetOrLoad(context.Background(), "chain_id", 0, nil)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, "1", value)
+	})
+
+	t.Run("do not store errors", func(t *testing.T) {
+		cache := NewRPCCache(10*time.Second, 100)
+
+		_, hit, err := cache.GetOrLoad(context.Background(), "key", 0, func(ctx context.Context) (any, error) {
+			return nil, errors.New("timeout")
+		})
+		require.Error(t, err)
+		assert.False(t, hit)
+
+		_, ok, _ := cache.Get(ctx, "key")
+		assert.False(t, ok)
+	})
+
+	t.Run("skip store returns value without caching", func(t *testing.T) {
+		cache := NewRPCCache(10*time.Second, 100)
+
+		value, hit, err := cache.GetOrLoad(context.Background(), "key", 0, func(ctx context.Context) (any, error) {
+			return "pending", ErrSkipStore
+		})
+
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.Equal(t, "pending", value)
+		_, ok, _ := cache.Get(ctx, "key")
+		assert.False(t, ok)
+	})
+
+	t.Run("waiter honors context", func(t *testing.T) {
+		cache := NewRPCCache(10*time.Second, 100)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		go func() {
+			_, _, _ = cache.GetOrLoad(context.Background(), "key", 0, func(ctx context.Context) (any, error) {
+				close(started)
+				<-release
+				return "value", nil
+			})
+		}()
+		<-started
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _, err := cache.GetOrLoad(ctx, "key", 0, func(ctx context.Context) (any, error) {
+			t.Fatal("must not load twice")
+			return nil, nil
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestCacheStats(t *testing.T) {
+	cache := NewRPCCache(20*time.Millisecond, 2)
+
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Get(ctx, "key1")
+	cache.Get(ctx, "missing")
+	cache.Set(ctx, "key2", "value2", 0)
+	cache.Set(ctx, "key3", "value3", 0)
+	time.Sleep(30 * time.Millisecond)
+	cache.Get(ctx, "key3")
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, uint64(1), stats.Expirations)
+	assert.Equal(t, 0, stats.Size)
+}
+
+func TestCacheJanitor(t *testing.T) {
+	cache := NewRPCCacheWithOptions(Options{TTL: 10 * time.Millisecond, MaxSize: 100, JanitorInterval: 5 * time.Millisecond})
+	defer func() { _ = cache.Close() }()
+
+	cache.Set(ctx, "key1", "value1", 0)
+	cache.Set(ctx, "key2", "value2", NoExpiration)
+
+	assert.Eventually(t, func() bool { return cache.Stats().Expirations == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, cache.Size())
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	cache := NewRPCCache(time.Millisecond, 50)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				key := fmt.Sprintf("key%d", (i*j)%80)
+				cache.Set(ctx, key, j, 0)
+				cache.Get(ctx, key)
+				cache.Size()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, cache.Size(), 50)
+}
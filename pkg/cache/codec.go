@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tipos serializados pelos backends externos
+const (
+	valueTypeBigInt  = "bigint"
+	valueTypeReceipt = "receipt"
+	valueTypeString  = "string"
+	valueTypeBytes   = "bytes"
+	valueTypeUint64  = "uint64"
+)
+
+// encodedValue formato armazenado: {"type":"bigint","value":"1000"}; receipts usam o JSON do go-ethereum
+type encodedValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// EncodeValue serializa os valores aceitos pelos backends externos: *big.Int, *types.Receipt, string, []byte e uint64
+func EncodeValue(value any) ([]byte, error) {
+	var (
+		valueType string
+		raw       any
+	)
+
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("cannot encode nil *big.Int")
+		}
+		valueType, raw = valueTypeBigInt, v.String()
+	case *types.Receipt:
+		if v == nil {
+			return nil, fmt.Errorf("cannot encode nil *types.Receipt")
+		}
+		// O JSON do receipt exige "logs"; receipts sem logs vêm com slice nil
+		receipt := *v
+		if receipt.Logs == nil {
+			receipt.Logs = []*types.Log{}
+		}
+		valueType, raw = valueTypeReceipt, &receipt
+	case string:
+		valueType, raw = valueTypeString, v
+	case []byte:
+		valueType, raw = valueTypeBytes, v
+	case uint64:
+		valueType, raw = valueTypeUint64, v
+	default:
+		return nil, fmt.Errorf("unsupported cache value type %T", value)
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s cache value: %w", valueType, err)
+	}
+	return json.Marshal(encodedValue{Type: valueType, Value: encoded})
+}
+
+// DecodeValue desserializa um valor gravado por EncodeValue
+func DecodeValue(data []byte) (any, error) {
+	var encoded encodedValue
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to decode cache value: %w", err)
+	}
+
+	switch encoded.Type {
+	case valueTypeBigInt:
+		var text string
+		if err := json.Unmarshal(encoded.Value, &text); err != nil {
+			return nil, fmt.Errorf("failed to decode bigint cache value: %w", err)
+		}
+		value, ok := new(big.Int).SetString(text, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid bigint cache value: %s", text)
+		}
+		return value, nil
+	case valueTypeReceipt:
+		receipt := new(types.Receipt)
+		if err := json.Unmarshal(encoded.Value, receipt); err != nil {
+			return nil, fmt.Errorf("failed to decode receipt cache value: %w", err)
+		}
+		return receipt, nil
+	case valueTypeString:
+		var value string
+		err := json.Unmarshal(encoded.Value, &value)
+		return value, err
+	case valueTypeBytes:
+		var value []byte
+		err := json.Unmarshal(encoded.Value, &value)
+		return value, err
+	case valueTypeUint64:
+		var value uint64
+		err := json.Unmarshal(encoded.Value, &value)
+		return value, err
+	default:
+		return nil, fmt.Errorf("unknown cache value type: %s", encoded.Type)
+	}
+}
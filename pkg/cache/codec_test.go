@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeValue(t *testing.T) {
+	t.Run("big int", func(t *testing.T) {
+		balance, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+		data, err := EncodeValue(balance)
+		require.NoError(t, err)
+		decoded, err := DecodeValue(data)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, balance.Cmp(decoded.(*big.Int)))
+	})
+
+	t.Run("receipt", func(t *testing.T) {
+		receipt := &types.Receipt{
+			Type:              types.DynamicFeeTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 42000,
+			GasUsed:           21000,
+			TxHash:            common.HexToHash("0x01"),
+			BlockHash:         common.HexToHash("0xaa"),
+			BlockNumber:       big.NewInt(100),
+			EffectiveGasPrice: big.NewInt(30_000_000_000),
+			TransactionIndex:  3,
+		}
+
+		data, err := EncodeValue(receipt)
+		require.NoError(t, err)
+		decoded, err := DecodeValue(data)
+		require.NoError(t, err)
+
+		result := decoded.(*types.Receipt)
+		assert.Equal(t, receipt.Status, result.Status)
+		assert.Equal(t, receipt.GasUsed, result.GasUsed)
+		assert.Equal(t, receipt.TxHash, result.TxHash)
+		assert.Equal(t, receipt.BlockHash, result.BlockHash)
+		assert.Equal(t, receipt.BlockNumber, result.BlockNumber)
+		assert.Equal(t, receipt.EffectiveGasPrice, result.EffectiveGasPrice)
+		assert.Equal(t, receipt.TransactionIndex, result.TransactionIndex)
+		assert.Nil(t, receipt.Logs, "original receipt must not be changed")
+	})
+
+	t.Run("primitive values", func(t *testing.T) {
+		for _, value := range []any{"value", []byte{0x01, 0x02}, uint64(7)} {
+			data, err := EncodeValue(value)
+			require.NoError(t, err)
+			decoded, err := DecodeValue(data)
+			require.NoError(t, err)
+			assert.Equal(t, value, decoded)
+		}
+	})
+
+	t.Run("reject unsupported and invalid values", func(t *testing.T) {
+		_, err := EncodeValue(struct{}{})
+		assert.Error(t, err)
+
+		_, err = DecodeValue([]byte(`{"type":"unknown","value":1}`))
+		assert.Error(t, err)
+
+		_, err = DecodeValue([]byte(`{"type":"bigint","value":"abc"}`))
+		assert.Error(t, err)
+	})
+}
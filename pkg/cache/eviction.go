@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"container/heap"
+	"container/list"
+	"fmt"
+	"strings"
+)
+
+// EvictionPolicy política usada para escolher a entrada removida quando o cache está cheio
+type EvictionPolicy string
+
+const (
+	// EvictionLRU remove a entrada acessada há mais tempo
+	EvictionLRU EvictionPolicy = "lru"
+	// EvictionLFU remove a entrada com menos acessos (empate: a acessada há mais tempo)
+	EvictionLFU EvictionPolicy = "lfu"
+)
+
+// NewEvictionPolicy valida a política configurada (vazia = lru)
+func NewEvictionPolicy(value string) (EvictionPolicy, error) {
+	switch policy := EvictionPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return EvictionLRU, nil
+	case EvictionLRU, EvictionLFU:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid cache eviction policy: %s", value)
+	}
+}
+
+// entry entrada interna com os dados de controle da política
+type entry struct {
+	CacheEntry
+	key string
+
+	// element posição na lista LRU
+	element *list.Element
+	// frequency, tick e index ordenação no heap LFU
+	frequency uint64
+	tick      uint64
+	index     int
+}
+
+// evictionPolicy mantém a ordem de remoção das entradas (chamado com o lock do cache)
+type evictionPolicy interface {
+	add(e *entry)
+	touch(e *entry)
+	remove(e *entry)
+	victim() *entry
+}
+
+func newEvictionPolicy(policy EvictionPolicy) evictionPolicy {
+	if policy == EvictionLFU {
+		return &lfuPolicy{}
+	}
+	return &lruPolicy{order: list.New()}
+}
+
+// lruPolicy lista com a entrada mais recente na frente
+type lruPolicy struct {
+	order *list.List
+}
+
+func (p *lruPolicy) add(e *entry) {
+	e.element = p.order.PushFront(e)
+}
+
+func (p *lruPolicy) touch(e *entry) {
+	p.order.MoveToFront(e.element)
+}
+
+func (p *lruPolicy) remove(e *entry) {
+	p.order.Remove(e.element)
+}
+
+func (p *lruPolicy) victim() *entry {
+	if back := p.order.Back(); back != nil {
+		return back.Value.(*entry)
+	}
+	return nil
+}
+
+// lfuPolicy heap mínimo por número de acessos e, no empate, pelo acesso mais antigo
+type lfuPolicy struct {
+	entries lfuHeap
+	tick    uint64
+}
+
+func (p *lfuPolicy) add(e *entry) {
+	p.tick++
+	e.frequency, e.tick = 1, p.tick
+	heap.Push(&p.entries, e)
+}
+
+func (p *lfuPolicy) touch(e *entry) {
+	p.tick++
+	e.frequency++
+	e.tick = p.tick
+	heap.Fix(&p.entries, e.index)
+}
+
+func (p *lfuPolicy) remove(e *entry) {
+	heap.Remove(&p.entries, e.index)
+}
+
+func (p *lfuPolicy) victim() *entry {
+	if len(p.entries) == 0 {
+		return nil
+	}
+	return p.entries[0]
+}
+
+// lfuHeap implementa heap.Interface
+type lfuHeap []*entry
+
+func (h lfuHeap) Len() int { return len(h) }
+
+func (h lfuHeap) Less(i, j int) bool {
+	if h[i].frequency != h[j].frequency {
+		return h[i].frequency < h[j].frequency
+	}
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap) Push(x any) {
+	e := x.(*entry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *lfuHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
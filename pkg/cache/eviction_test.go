@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEvictionPolicy(t *testing.T) {
+	policy, err := NewEvictionPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, EvictionLRU, policy)
+
+	policy, err = NewEvictionPolicy(" LFU ")
+	require.NoError(t, err)
+	assert.Equal(t, EvictionLFU, policy)
+
+	_, err = NewEvictionPolicy("fifo")
+	assert.Error(t, err)
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := NewRPCCacheWithOptions(Options{TTL: time.Minute, MaxSize: 3, Policy: EvictionLRU})
+
+	cache.Set(ctx, "a", 1, 0)
+	cache.Set(ctx, "b", 2, 0)
+	cache.Set(ctx, "c", 3, 0)
+	// "a" passa a ser a mais recente; "b" é a usada há mais tempo
+	_, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+
+	cache.Set(ctx, "d", 4, 0)
+
+	_, ok, _ = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	for _, key := range []string{"a", "c", "d"} {
+		_, ok, _ := cache.Get(ctx, key)
+		assert.True(t, ok, key)
+	}
+	assert.Equal(t, uint64(1), cache.Stats().Evictions)
+}
+
+func TestLFUEviction(t *testing.T) {
+	cache := NewRPCCacheWithOptions(Options{TTL: time.Minute, MaxSize: 3, Policy: EvictionLFU})
+
+	cache.Set(ctx, "a", 1, 0)
+	cache.Set(ctx, "b", 2, 0)
+	cache.Set(ctx, "c", 3, 0)
+	for i := 0; i < 3; i++ {
+		cache.Get(ctx, "a")
+		cache.Get(ctx, "c")
+	}
+	cache.Get(ctx, "b")
+
+	// "b" tem menos acessos
+	cache.Set(ctx, "d", 4, 0)
+	_, ok, _ := cache.Get(ctx, "b")
+	assert.False(t, ok)
+
+	// "d" acabou de entrar e tem um único acesso
+	cache.Set(ctx, "e", 5, 0)
+	_, ok, _ = cache.Get(ctx, "d")
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok, _ = cache.Get(ctx, "c")
+	assert.True(t, ok)
+}
+
+func TestUpdateExistingKeyDoesNotEvict(t *testing.T) {
+	cache := NewRPCCacheWithOptions(Options{TTL: time.Minute, MaxSize: 2})
+
+	cache.Set(ctx, "a", 1, 0)
+	cache.Set(ctx, "b", 2, 0)
+	cache.Set(ctx, "a", 3, 0)
+
+	value, ok, _ := cache.Get(ctx, "a")
+	require.True(t, ok)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, uint64(0), cache.Stats().Evictions)
+}
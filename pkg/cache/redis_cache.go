@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisScanCount chaves por iteração do SCAN no DeletePrefix
+const redisScanCount = 500
+
+// RedisOptions configuração do cache Redis
+type RedisOptions struct {
+	// Prefix namespace de todas as chaves (ex.: "chainevm:rpc:")
+	Prefix string
+	// TTL padrão das entradas (<= 0 = não expiram)
+	TTL time.Duration
+}
+
+// RedisCache cache compartilhado entre containers em um servidor com protocolo Redis; os valores são
+// serializados com EncodeValue. Falhas do Redis no GetOrLoad viram misses, sem impedir a leitura
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+	loads  loadGroup
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	backendErrors atomic.Uint64
+}
+
+var _ Cache = (*RedisCache)(nil)
+
+// NewRedisCache cria o cache sobre o cliente informado
+func NewRedisCache(client redis.UniversalClient, options RedisOptions) *RedisCache {
+	return &RedisCache{
+		client: client,
+		prefix: options.Prefix,
+		ttl:    options.TTL,
+	}
+}
+
+// NewRedisCacheFromURL conecta ao servidor (redis://[:senha@]host:porta/db ou rediss://) e verifica a conexão
+func NewRedisCacheFromURL(ctx context.Context, url string, options RedisOptions) (*RedisCache, error) {
+	redisOptions, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	client := redis.NewClient(redisOptions)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	return NewRedisCache(client, options), nil
+}
+
+// Get retorna o valor se existir
+func (c *RedisCache) Get(ctx context.Context, key string) (any, bool, error) {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		c.misses.Add(1)
+		return nil, false, nil
+	}
+	if err != nil {
+		c.backendErrors.Add(1)
+		return nil, false, fmt.Errorf("redis get %s: %w", key, err)
+	}
+
+	value, err := DecodeValue(data)
+	if err != nil {
+		c.backendErrors.Add(1)
+		return nil, false, err
+	}
+	c.hits.Add(1)
+	return value, true, nil
+}
+
+// Set armazena o valor serializado (ttl 0 = TTL padrão, NoExpiration = não expira)
+func (c *RedisCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := EncodeValue(value)
+	if err != nil {
+		return err
+	}
+
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	if err := c.client.Set(ctx, c.prefix+key, data, ttl).Err(); err != nil {
+		c.backendErrors.Add(1)
+		return fmt.Errorf("redis set %s: %w", key, err)
+	}
+	return nil
+}
+
+// GetOrLoad retorna o valor do Redis ou carrega uma única vez por chave neste processo; erros do Redis
+// (leitura ou escrita) não impedem o carregamento nem o retorno do valor
+func (c *RedisCache) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loadFn LoadFunc) (any, bool, error) {
+	if value, ok, err := c.Get(ctx, key); err == nil && ok {
+		return value, true, nil
+	}
+
+	return c.loads.do(ctx, key, func() (any, error) {
+		value, err := loadFn(ctx)
+		if errors.Is(err, ErrSkipStore) {
+			return value, nil
+		}
+		if err == nil {
+			_ = c.Set(ctx, key, value, ttl)
+		}
+		return value, err
+	})
+}
+
+// Delete remove a entrada
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, c.prefix+key).Err(); err != nil {
+		c.backendErrors.Add(1)
+		return fmt.Errorf("redis del %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeletePrefix remove as entradas com o prefixo usando SCAN (não bloqueia o servidor como KEYS)
+func (c *RedisCache) DeletePrefix(ctx context.Context, prefix string) (int, error) {
+	pattern := escapeRedisPattern(c.prefix+prefix) + "*"
+
+	removed := 0
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, pattern, redisScanCount).Result()
+		if err != nil {
+			c.backendErrors.Add(1)
+			return removed, fmt.Errorf("redis scan %s: %w", prefix, err)
+		}
+		if len(keys) > 0 {
+			deleted, err := c.client.Del(ctx, keys...).Result()
+			if err != nil {
+				c.backendErrors.Add(1)
+				return removed, fmt.Errorf("redis del %s: %w", prefix, err)
+			}
+			removed += int(deleted)
+		}
+		if next == 0 {
+			return removed, nil
+		}
+		cursor = next
+	}
+}
+
+// Stats retorna hits e misses das leituras deste processo (Size não é calculado)
+func (c *RedisCache) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+// BackendErrors falhas de comunicação ou serialização com o Redis
+func (c *RedisCache) BackendErrors() uint64 {
+	return c.backendErrors.Load()
+}
+
+// Close fecha o cliente Redis
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
+
+// escapeRedisPattern escapa os caracteres especiais do glob do SCAN MATCH
+func escapeRedisPattern(value string) string {
+	var builder strings.Builder
+	for _, r := range value {
+		switch r {
+		case '*', '?', '[', ']', '^', '\\':
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
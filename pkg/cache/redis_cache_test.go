@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisCache(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	cache := NewRedisCache(client, RedisOptions{Prefix: "chainevm:rpc:", TTL: time.Minute})
+	t.Cleanup(func() { _ = cache.Close() })
+	return cache, server
+}
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("set and get with namespace", func(t *testing.T) {
+		cache, server := newTestRedisCache(t)
+
+		require.NoError(t, cache.Set(ctx, "ETHEREUM:chain_id", big.NewInt(1), NoExpiration))
+		value, ok, err := cache.Get(ctx, "ETHEREUM:chain_id")
+
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, big.NewInt(1), value)
+		assert.True(t, server.Exists("chainevm:rpc:ETHEREUM:chain_id"))
+		assert.Equal(t, time.Duration(0), server.TTL("chainevm:rpc:ETHEREUM:chain_id"))
+	})
+
+	t.Run("expire entries by ttl", func(t *testing.T) {
+		cache, server := newTestRedisCache(t)
+
+		require.NoError(t, cache.Set(ctx, "gas_price", big.NewInt(10), 5*time.Second))
+		require.NoError(t, cache.Set(ctx, "balance", big.NewInt(20), 0))
+		assert.Equal(t, 5*time.Second, server.TTL("chainevm:rpc:gas_price"))
+		assert.Equal(t, time.Minute, server.TTL("chainevm:rpc:balance"))
+
+		server.FastForward(6 * time.Second)
+
+		_, ok, err := cache.Get(ctx, "gas_price")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = cache.Get(ctx, "balance")
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("delete by prefix only within chain", func(t *testing.T) {
+		cache, _ := newTestRedisCache(t)
+		require.NoError(t, cache.Set(ctx, "ETHEREUM:block:gas_price", big.NewInt(1), 0))
+		require.NoError(t, cache.Set(ctx, "ETHEREUM:block:balance:0x1:100", big.NewInt(2), 0))
+		require.NoError(t, cache.Set(ctx, "ETHEREUM:chain_id", big.NewInt(1), 0))
+		require.NoError(t, cache.Set(ctx, "POLYGON:block:gas_price", big.NewInt(3), 0))
+
+		removed, err := cache.DeletePrefix(ctx, "ETHEREUM:block:")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, removed)
+		_, ok, _ := cache.Get(ctx, "ETHEREUM:chain_id")
+		assert.True(t, ok)
+		_, ok, _ = cache.Get(ctx, "POLYGON:block:gas_price")
+		assert.True(t, ok)
+	})
+
+	t.Run("get or load stores value", func(t *testing.T) {
+		cache, _ := newTestRedisCache(t)
+		loads := 0
+		load := func(ctx context.Context) (any, error) {
+			loads++
+			return big.NewInt(42), nil
+		}
+
+		value, hit, err := cache.GetOrLoad(ctx, "balance", 0, load)
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.Equal(t, big.NewInt(42), value)
+
+		value, hit, err = cache.GetOrLoad(ctx, "balance", 0, load)
+		require.NoError(t, err)
+		assert.True(t, hit)
+		assert.Equal(t, big.NewInt(42), value)
+		assert.Equal(t, 1, loads)
+		assert.Equal(t, Stats{Hits: 1, Misses: 1}, cache.Stats())
+	})
+
+	t.Run("get or load falls back to loader when redis is down", func(t *testing.T) {
+		cache, server := newTestRedisCache(t)
+		server.Close()
+
+		value, hit, err := cache.GetOrLoad(ctx, "balance", 0, func(ctx context.Context) (any, error) {
+			return big.NewInt(42), nil
+		})
+
+		require.NoError(t, err)
+		assert.False(t, hit)
+		assert.Equal(t, big.NewInt(42), value)
+		assert.Positive(t, cache.BackendErrors())
+	})
+
+	t.Run("get or load does not store errors or skipped values", func(t *testing.T) {
+		cache, server := newTestRedisCache(t)
+
+		_, _, err := cache.GetOrLoad(ctx, "failed", 0, func(ctx context.Context) (any, error) {
+			return nil, errors.New("timeout")
+		})
+		require.Error(t, err)
+		value, _, err := cache.GetOrLoad(ctx, "pending", 0, func(ctx context.Context) (any, error) {
+			return "pending", ErrSkipStore
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "pending", value)
+		assert.False(t, server.Exists("chainevm:rpc:failed"))
+		assert.False(t, server.Exists("chainevm:rpc:pending"))
+	})
+
+	t.Run("escape glob characters in prefix", func(t *testing.T) {
+		cache, _ := newTestRedisCache(t)
+		require.NoError(t, cache.Set(ctx, "a*:key", "1", 0))
+		require.NoError(t, cache.Set(ctx, "ab:key", "2", 0))
+
+		removed, err := cache.DeletePrefix(ctx, "a*:")
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		_, ok, _ := cache.Get(ctx, "ab:key")
+		assert.True(t, ok)
+	})
+}
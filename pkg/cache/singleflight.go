@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// loadGroup executa um único carregamento por chave entre chamadas concorrentes (single-flight)
+type loadGroup struct {
+	mu    sync.Mutex
+	loads map[string]*load
+}
+
+// load carregamento compartilhado entre as chamadas concorrentes de uma chave
+type load struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// do executa fn se não houver carregamento da chave em andamento; caso contrário aguarda o resultado dele
+// (shared = true) ou o cancelamento de ctx
+func (g *loadGroup) do(ctx context.Context, key string, fn func() (any, error)) (value any, shared bool, err error) {
+	g.mu.Lock()
+	if g.loads == nil {
+		g.loads = make(map[string]*load)
+	}
+	if inFlight, ok := g.loads[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-inFlight.done:
+			return inFlight.value, true, inFlight.err
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		}
+	}
+
+	current := &load{done: make(chan struct{})}
+	g.loads[key] = current
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.loads, key)
+		g.mu.Unlock()
+		close(current.done)
+	}()
+
+	current.value, current.err = fn()
+	return current.value, false, current.err
+}
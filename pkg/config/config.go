@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,9 +18,22 @@ type Config struct {
 
 	// AWS DynamoDB
 	DynamoDBTableName string
+	// NonceTableName tabela de reserva de nonces (vazio = gerenciador em memória)
+	NonceTableName string
 
 	// EVM RPC URLs
 	EVMRPCURLs map[string]string
+	// RPCConfigs endpoints (em ordem de prioridade) e seleção por chain
+	RPCConfigs map[string]RPCConfig
+
+	// Estratégia de taxas por chain
+	FeeConfigs map[string]FeeConfig
+
+	// Margens da estimativa de gas por chain
+	GasConfigs map[string]GasConfig
+
+	// Chain IDs esperados por chain (validados contra o nó RPC na inicialização)
+	ChainIDs map[string]int64
 
 	// Timeouts
 	RequestTimeout time.Duration
@@ -27,6 +41,186 @@ type Config struct {
 
 	// Blockchain confirmations
 	RequiredConfirmations int
+	// ConfirmationDepths confirmações exigidas por chain (padrão RequiredConfirmations)
+	ConfirmationDepths map[string]int
+	// ConfirmationSweepLimit operações SUBMITTED verificadas por varredura
+	ConfirmationSweepLimit int
+
+	// Substituição de transações presas no mempool
+	Replacement ReplacementConfig
+
+	// Circuit breaker de cada endpoint RPC
+	CircuitBreaker CircuitBreakerConfig
+
+	// Cache das leituras RPC
+	RPCCache RPCCacheConfig
+
+	// Processamento concorrente dos registros de um lote SQS
+	Batch BatchConfig
+
+	// Worker (cmd/worker, long polling da fila fora da Lambda)
+	Worker WorkerConfig
+
+	// Política de retry das mensagens
+	Retry RetryConfig
+
+	// Publicação dos eventos de domínio
+	Events EventsConfig
+
+	// Key management (keystore | env | file | kms)
+	KeyProvider          string
+	KeystoreDir          string
+	KeystorePasswordFile string
+	SignerKeyFile        string
+	KMSKeyIDs            []string
+}
+
+// FeeConfig estratégia de taxas de uma chain
+type FeeConfig struct {
+	// DefaultSpeed slow | standard | fast
+	DefaultSpeed string
+	// Percentis de eth_feeHistory usados para a gorjeta de cada perfil
+	SlowPercentile     float64
+	StandardPercentile float64
+	FastPercentile     float64
+	// BaseFeeMultiplier margem aplicada ao base fee atual
+	BaseFeeMultiplier int64
+	// MaxFeeGwei teto de maxFeePerGas/gasPrice em gwei (0 = sem teto)
+	MaxFeeGwei int64
+	// LegacyOnly força transações legadas
+	LegacyOnly bool
+}
+
+// BatchConfig processamento dos registros de um lote SQS
+type BatchConfig struct {
+	// Concurrency registros processados em paralelo; mensagens do mesmo remetente na mesma chain
+	// (ou do mesmo MessageGroupId em filas FIFO) continuam em sequência
+	Concurrency int
+	// DeadlineMargin antecedência ao timeout da Lambda em que novos registros deixam de ser iniciados
+	DeadlineMargin time.Duration
+}
+
+// WorkerConfig consumo da fila pelo worker (cmd/worker)
+type WorkerConfig struct {
+	// Concurrency mensagens processadas em paralelo
+	Concurrency int
+	// WaitTime duração do long polling de cada ReceiveMessage (máximo de 20s)
+	WaitTime time.Duration
+	// VisibilityTimeout visibilidade das mensagens recebidas, renovada por heartbeat enquanto estão em processamento
+	VisibilityTimeout time.Duration
+	// HeartbeatInterval intervalo de renovação da visibilidade das mensagens em processamento
+	HeartbeatInterval time.Duration
+	// ShutdownTimeout espera pelas mensagens em processamento após o SIGTERM
+	ShutdownTimeout time.Duration
+	// ConfirmationSweepInterval intervalo da varredura de confirmações (papel do agendamento do EventBridge
+	// na Lambda); 0 desativa
+	ConfirmationSweepInterval time.Duration
+}
+
+// RetryConfig política de retry das mensagens com falha transitória
+type RetryConfig struct {
+	// MaxRetries novas tentativas após a primeira falha
+	MaxRetries int
+	// CategoryMaxRetries limite por categoria de erro (RETRY_MAX_RETRIES_<CATEGORIA>)
+	CategoryMaxRetries map[string]int
+	// MaxElapsedTime tempo máximo desde a primeira tentativa (0 = sem limite)
+	MaxElapsedTime time.Duration
+	// Mode visibility (espera pela visibilidade da mensagem na SQS) | in_process (espera na própria execução)
+	Mode string
+	// Jitter none | full | decorrelated
+	Jitter            string
+	BackoffMultiplier float64
+	// InitialBackoff e MaxBackoff esperas no processo
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// InitialVisibility e MaxVisibility esperas pela visibilidade da mensagem
+	InitialVisibility time.Duration
+	MaxVisibility     time.Duration
+}
+
+// EventsConfig publicação dos eventos de domínio (envelope JSON versionado)
+type EventsConfig struct {
+	// Publisher none | sns | sqs | eventbridge
+	Publisher string
+	// TopicARN tópico SNS (Publisher sns)
+	TopicARN string
+	// QueueURL fila SQS (Publisher sqs)
+	QueueURL string
+	// BusName event bus do EventBridge (Publisher eventbridge; vazio = bus default)
+	BusName string
+	// Source origem registrada nos envelopes (source no EventBridge)
+	Source string
+}
+
+// retryCategories categorias de erro retentáveis com limite próprio configurável
+var retryCategories = []string{"RPC_TRANSIENT", "NONCE_CONFLICT", "DB_THROTTLING", "INTERNAL"}
+
+// ReplacementConfig política de substituição (replace-by-fee) de transações presas
+type ReplacementConfig struct {
+	// StuckTimeout tempo sem mineração após o envio para considerar a transação presa
+	StuckTimeout time.Duration
+	// FeeBumpPercent aumento das taxas a cada substituição (mínimo de 10% exigido pelos nodes)
+	FeeBumpPercent int64
+	// MaxReplacements substituições por operação
+	MaxReplacements int
+}
+
+// RPCConfig endpoints RPC de uma chain
+type RPCConfig struct {
+	// URLs em ordem de prioridade (RPC_URLS_<CHAIN>, padrão RPC_URL_<CHAIN>)
+	URLs []string
+	// Strategy priority | round_robin | lowest_latency
+	Strategy string
+	// MaxBlockLag blocos de atraso para um endpoint ser evitado (0 = não verifica)
+	MaxBlockLag uint64
+	// HealthCheckInterval intervalo entre as consultas de altura de todos os endpoints
+	HealthCheckInterval time.Duration
+	// QuorumSize respostas iguais exigidas nas leituras de saldo e receipt (0 = desativado)
+	QuorumSize int
+	// QuorumMaxBlockSkew diferença máxima de altura entre os endpoints que participam do quórum
+	QuorumMaxBlockSkew uint64
+}
+
+// CircuitBreakerConfig circuit breaker aplicado a cada endpoint RPC
+type CircuitBreakerConfig struct {
+	// FailureThreshold falhas consecutivas para abrir o circuito (0 = desativado)
+	FailureThreshold int
+	// SuccessThreshold sucessos em HALF_OPEN para fechar o circuito
+	SuccessThreshold int
+	// OpenTimeout tempo em OPEN antes de liberar chamadas de teste
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls chamadas de teste simultâneas em HALF_OPEN
+	HalfOpenMaxCalls int
+}
+
+// RPCCacheConfig cache de leituras RPC, compartilhado entre as chains
+type RPCCacheConfig struct {
+	// Backend memory (por container) | redis (compartilhado entre containers)
+	Backend string
+	// RedisURL e RedisPrefix servidor e namespace das chaves com Backend redis
+	RedisURL    string
+	RedisPrefix string
+	// MaxSize entradas no cache em memória (0 = desativado)
+	MaxSize int
+	// EvictionPolicy lru | lfu
+	EvictionPolicy string
+	// JanitorInterval intervalo da remoção de entradas expiradas em background
+	JanitorInterval time.Duration
+	// GasPriceTTL, BalanceTTL e ReceiptTTL TTL de cada leitura; o chain ID não expira
+	GasPriceTTL time.Duration
+	BalanceTTL  time.Duration
+	ReceiptTTL  time.Duration
+	// HeadTTL idade máxima da altura que chaveia os saldos antes de nova consulta
+	HeadTTL time.Duration
+}
+
+// GasConfig margens da estimativa de gas de uma chain
+type GasConfig struct {
+	// Multiplier margem sobre eth_estimateGas (1.2 = +20%)
+	Multiplier float64
+	// MinGasLimit e MaxGasLimit limites do gas limit enviado (MaxGasLimit 0 = sem teto)
+	MinGasLimit uint64
+	MaxGasLimit uint64
 }
 
 // LoadConfig carrega configuração a partir de variáveis de ambiente
@@ -44,22 +238,208 @@ func LoadConfig() *Config {
 		"AVALANCHE": getEnv("RPC_URL_AVALANCHE", "https://avax-mainnet.g.alchemy.com/v2/demo"),
 	}
 
+	chainIDs := map[string]int64{
+		"ETHEREUM":  getEnvInt64("CHAIN_ID_ETHEREUM", 1),
+		"POLYGON":   getEnvInt64("CHAIN_ID_POLYGON", 137),
+		"BSC":       getEnvInt64("CHAIN_ID_BSC", 56),
+		"ARBITRUM":  getEnvInt64("CHAIN_ID_ARBITRUM", 42161),
+		"OPTIMISM":  getEnvInt64("CHAIN_ID_OPTIMISM", 10),
+		"AVALANCHE": getEnvInt64("CHAIN_ID_AVALANCHE", 43114),
+	}
+
+	feeConfigs := make(map[string]FeeConfig, len(evmRPCURLs))
+	gasConfigs := make(map[string]GasConfig, len(evmRPCURLs))
+	confirmationDepths := make(map[string]int, len(evmRPCURLs))
+	rpcConfigs := make(map[string]RPCConfig, len(evmRPCURLs))
+	for chainName := range evmRPCURLs {
+		rpcConfigs[chainName] = loadRPCConfig(chainName, evmRPCURLs[chainName])
+		if urls := rpcConfigs[chainName].URLs; len(urls) > 0 {
+			evmRPCURLs[chainName] = urls[0]
+		}
+		confirmationDepths[chainName] = int(getEnvInt64("REQUIRED_CONFIRMATIONS_"+chainName, int64(requiredConfirmations)))
+		feeConfigs[chainName] = loadFeeConfig(chainName)
+		gasConfigs[chainName] = GasConfig{
+			Multiplier:  getEnvFloat("GAS_LIMIT_MULTIPLIER_"+chainName, 1.2),
+			MinGasLimit: uint64(getEnvInt64("MIN_GAS_LIMIT_"+chainName, 21000)),
+			MaxGasLimit: uint64(getEnvInt64("MAX_GAS_LIMIT_"+chainName, 0)),
+		}
+	}
+
+	var kmsKeyIDs []string
+	for _, keyID := range strings.Split(getEnv("KMS_KEY_IDS", ""), ",") {
+		if keyID = strings.TrimSpace(keyID); keyID != "" {
+			kmsKeyIDs = append(kmsKeyIDs, keyID)
+		}
+	}
+
 	return &Config{
-		Environment:           getEnv("ENVIRONMENT", "development"),
-		AWSRegion:             getEnv("AWS_REGION", "us-east-1"),
-		SQSQueueURL:           getEnv("SQS_QUEUE_URL", ""),
-		SQSQueueDLQURL:        getEnv("SQS_QUEUE_DLQ_URL", ""),
-		DynamoDBTableName:     getEnv("DYNAMODB_TABLE_NAME", "evm-transactions"),
-		EVMRPCURLs:            evmRPCURLs,
-		RequestTimeout:        time.Duration(requestTimeout) * time.Second,
-		RPCTimeout:            time.Duration(rpcTimeout) * time.Second,
-		RequiredConfirmations: requiredConfirmations,
+		Environment:            getEnv("ENVIRONMENT", "development"),
+		AWSRegion:              getEnv("AWS_REGION", "us-east-1"),
+		SQSQueueURL:            getEnv("SQS_QUEUE_URL", ""),
+		SQSQueueDLQURL:         getEnv("SQS_QUEUE_DLQ_URL", ""),
+		DynamoDBTableName:      getEnv("DYNAMODB_TABLE_NAME", "evm-transactions"),
+		NonceTableName:         getEnv("NONCE_TABLE_NAME", ""),
+		EVMRPCURLs:             evmRPCURLs,
+		RPCConfigs:             rpcConfigs,
+		ChainIDs:               chainIDs,
+		FeeConfigs:             feeConfigs,
+		GasConfigs:             gasConfigs,
+		RequestTimeout:         time.Duration(requestTimeout) * time.Second,
+		RPCTimeout:             time.Duration(rpcTimeout) * time.Second,
+		RequiredConfirmations:  requiredConfirmations,
+		ConfirmationDepths:     confirmationDepths,
+		ConfirmationSweepLimit: int(getEnvInt64("CONFIRMATION_SWEEP_LIMIT", 100)),
+		Replacement: ReplacementConfig{
+			StuckTimeout:    time.Duration(getEnvInt64("STUCK_TX_TIMEOUT_SECONDS", 180)) * time.Second,
+			FeeBumpPercent:  getEnvInt64("FEE_BUMP_PERCENT", 12),
+			MaxReplacements: int(getEnvInt64("MAX_TX_REPLACEMENTS", 3)),
+		},
+		CircuitBreaker: CircuitBreakerConfig{
+			FailureThreshold: int(getEnvInt64("CIRCUIT_BREAKER_FAILURE_THRESHOLD", 5)),
+			SuccessThreshold: int(getEnvInt64("CIRCUIT_BREAKER_SUCCESS_THRESHOLD", 2)),
+			OpenTimeout:      time.Duration(getEnvInt64("CIRCUIT_BREAKER_OPEN_TIMEOUT_SECONDS", 30)) * time.Second,
+			HalfOpenMaxCalls: int(getEnvInt64("CIRCUIT_BREAKER_HALF_OPEN_MAX_CALLS", 1)),
+		},
+		RPCCache: RPCCacheConfig{
+			Backend:         getEnv("RPC_CACHE_BACKEND", "memory"),
+			RedisURL:        getEnv("RPC_CACHE_REDIS_URL", ""),
+			RedisPrefix:     getEnv("RPC_CACHE_REDIS_PREFIX", "chainevm:rpc:"),
+			MaxSize:         int(getEnvInt64("RPC_CACHE_MAX_SIZE", 10000)),
+			EvictionPolicy:  getEnv("RPC_CACHE_EVICTION_POLICY", "lru"),
+			JanitorInterval: time.Duration(getEnvInt64("RPC_CACHE_JANITOR_INTERVAL_SECONDS", 60)) * time.Second,
+			GasPriceTTL:     time.Duration(getEnvInt64("RPC_CACHE_GAS_PRICE_TTL_SECONDS", 5)) * time.Second,
+			BalanceTTL:      time.Duration(getEnvInt64("RPC_CACHE_BALANCE_TTL_SECONDS", 15)) * time.Second,
+			ReceiptTTL:      time.Duration(getEnvInt64("RPC_CACHE_RECEIPT_TTL_SECONDS", 3600)) * time.Second,
+			HeadTTL:         time.Duration(getEnvInt64("RPC_CACHE_HEAD_TTL_SECONDS", 2)) * time.Second,
+		},
+		Batch: BatchConfig{
+			Concurrency:    int(getEnvInt64("BATCH_CONCURRENCY", 4)),
+			DeadlineMargin: time.Duration(getEnvInt64("BATCH_DEADLINE_MARGIN_SECONDS", 10)) * time.Second,
+		},
+		Worker: WorkerConfig{
+			Concurrency:               int(getEnvInt64("WORKER_CONCURRENCY", 10)),
+			WaitTime:                  time.Duration(getEnvInt64("WORKER_WAIT_TIME_SECONDS", 20)) * time.Second,
+			VisibilityTimeout:         time.Duration(getEnvInt64("WORKER_VISIBILITY_TIMEOUT_SECONDS", 60)) * time.Second,
+			HeartbeatInterval:         time.Duration(getEnvInt64("WORKER_HEARTBEAT_INTERVAL_SECONDS", 20)) * time.Second,
+			ShutdownTimeout:           time.Duration(getEnvInt64("WORKER_SHUTDOWN_TIMEOUT_SECONDS", 30)) * time.Second,
+			ConfirmationSweepInterval: time.Duration(getEnvInt64("WORKER_CONFIRMATION_SWEEP_INTERVAL_SECONDS", 60)) * time.Second,
+		},
+		Retry: loadRetryConfig(),
+		Events: EventsConfig{
+			Publisher: getEnv("EVENT_PUBLISHER", "none"),
+			TopicARN:  getEnv("EVENT_TOPIC_ARN", ""),
+			QueueURL:  getEnv("EVENT_QUEUE_URL", ""),
+			BusName:   getEnv("EVENT_BUS_NAME", ""),
+			Source:    getEnv("EVENT_SOURCE", "chainevm"),
+		},
+		KeyProvider:          getEnv("KEY_PROVIDER", "env"),
+		KeystoreDir:          getEnv("KEYSTORE_DIR", ""),
+		KeystorePasswordFile: getEnv("KEYSTORE_PASSWORD_FILE", ""),
+		SignerKeyFile:        getEnv("SIGNER_KEY_FILE", ""),
+		KMSKeyIDs:            kmsKeyIDs,
+	}
+}
+
+// loadRPCConfig lê RPC_URLS_<CHAIN> (separadas por vírgula), RPC_STRATEGY_<CHAIN>, RPC_MAX_BLOCK_LAG_<CHAIN> e RPC_QUORUM_<CHAIN>
+func loadRPCConfig(chainName string, defaultURL string) RPCConfig {
+	var urls []string
+	for _, rpcURL := range strings.Split(getEnv("RPC_URLS_"+chainName, defaultURL), ",") {
+		if rpcURL = strings.TrimSpace(rpcURL); rpcURL != "" {
+			urls = append(urls, rpcURL)
+		}
+	}
+
+	return RPCConfig{
+		URLs:                urls,
+		Strategy:            getEnv("RPC_STRATEGY_"+chainName, getEnv("RPC_STRATEGY", "priority")),
+		MaxBlockLag:         uint64(getEnvInt64("RPC_MAX_BLOCK_LAG_"+chainName, getEnvInt64("RPC_MAX_BLOCK_LAG", 5))),
+		HealthCheckInterval: time.Duration(getEnvInt64("RPC_HEALTH_CHECK_INTERVAL_SECONDS", 15)) * time.Second,
+		QuorumSize:          int(getEnvInt64("RPC_QUORUM_"+chainName, getEnvInt64("RPC_QUORUM", 0))),
+		QuorumMaxBlockSkew:  uint64(getEnvInt64("RPC_QUORUM_MAX_BLOCK_SKEW", 2)),
+	}
+}
+
+// loadRetryConfig lê RETRY_* e RETRY_MAX_RETRIES_<CATEGORIA> (ex.: RETRY_MAX_RETRIES_NONCE_CONFLICT)
+func loadRetryConfig() RetryConfig {
+	maxRetries := int(getEnvInt64("RETRY_MAX_RETRIES", 3))
+	categoryMaxRetries := make(map[string]int)
+	for _, category := range retryCategories {
+		if limit := getEnvInt64("RETRY_MAX_RETRIES_"+category, -1); limit >= 0 {
+			categoryMaxRetries[category] = int(limit)
+		}
+	}
+
+	return RetryConfig{
+		MaxRetries:         maxRetries,
+		CategoryMaxRetries: categoryMaxRetries,
+		MaxElapsedTime:     time.Duration(getEnvInt64("RETRY_MAX_ELAPSED_SECONDS", 0)) * time.Second,
+		Mode:               getEnv("RETRY_MODE", "visibility"),
+		Jitter:             getEnv("RETRY_JITTER", "full"),
+		BackoffMultiplier:  getEnvFloat("RETRY_BACKOFF_MULTIPLIER", 2.0),
+		InitialBackoff:     time.Duration(getEnvInt64("RETRY_INITIAL_BACKOFF_MS", 100)) * time.Millisecond,
+		MaxBackoff:         time.Duration(getEnvInt64("RETRY_MAX_BACKOFF_MS", 5000)) * time.Millisecond,
+		InitialVisibility:  time.Duration(getEnvInt64("RETRY_INITIAL_VISIBILITY_SECONDS", 30)) * time.Second,
+		MaxVisibility:      time.Duration(getEnvInt64("RETRY_MAX_VISIBILITY_SECONDS", 900)) * time.Second,
 	}
 }
 
+// loadFeeConfig lê FEE_SPEED_<CHAIN>, FEE_PERCENTILES_<CHAIN>, BASE_FEE_MULTIPLIER_<CHAIN>, MAX_FEE_GWEI_<CHAIN> e LEGACY_TX_<CHAIN>
+func loadFeeConfig(chainName string) FeeConfig {
+	feeConfig := FeeConfig{
+		DefaultSpeed:       getEnv("FEE_SPEED_"+chainName, "standard"),
+		SlowPercentile:     10,
+		StandardPercentile: 50,
+		FastPercentile:     90,
+		BaseFeeMultiplier:  getEnvInt64("BASE_FEE_MULTIPLIER_"+chainName, 2),
+		MaxFeeGwei:         getEnvInt64("MAX_FEE_GWEI_"+chainName, 0),
+		LegacyOnly:         getEnv("LEGACY_TX_"+chainName, "false") == "true",
+	}
+
+	if raw := getEnv("FEE_PERCENTILES_"+chainName, ""); raw != "" {
+		parts := strings.Split(raw, ",")
+		if len(parts) == 3 {
+			var percentiles [3]float64
+			valid := true
+			for i, part := range parts {
+				value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+				if err != nil || value < 0 || value > 100 {
+					valid = false
+					break
+				}
+				percentiles[i] = value
+			}
+			if valid {
+				feeConfig.SlowPercentile = percentiles[0]
+				feeConfig.StandardPercentile = percentiles[1]
+				feeConfig.FastPercentile = percentiles[2]
+			}
+		}
+	}
+
+	return feeConfig
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
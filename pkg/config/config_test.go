@@ -32,9 +32,21 @@ func TestLoadConfig(t *testing.T) {
 		assert.Equal(t, "development", cfg.Environment)
 		assert.Equal(t, "us-east-1", cfg.AWSRegion)
 		assert.Equal(t, "evm-transactions", cfg.DynamoDBTableName)
+		assert.Empty(t, cfg.NonceTableName)
+		assert.Equal(t, 3*time.Minute, cfg.Replacement.StuckTimeout)
+		assert.Equal(t, int64(12), cfg.Replacement.FeeBumpPercent)
+		assert.Equal(t, 3, cfg.Replacement.MaxReplacements)
+		assert.Equal(t, CircuitBreakerConfig{
+			FailureThreshold: 5,
+			SuccessThreshold: 2,
+			OpenTimeout:      30 * time.Second,
+			HalfOpenMaxCalls: 1,
+		}, cfg.CircuitBreaker)
 		assert.Equal(t, 30*time.Second, cfg.RequestTimeout)
 		assert.Equal(t, 10*time.Second, cfg.RPCTimeout)
 		assert.Equal(t, 12, cfg.RequiredConfirmations)
+		assert.Equal(t, 12, cfg.ConfirmationDepths["ETHEREUM"])
+		assert.Equal(t, 100, cfg.ConfirmationSweepLimit)
 	})
 
 	t.Run("load config with environment variables", func(t *testing.T) {
@@ -119,4 +131,200 @@ func TestLoadConfig(t *testing.T) {
 		assert.Contains(t, cfg.EVMRPCURLs, "OPTIMISM")
 		assert.Contains(t, cfg.EVMRPCURLs, "AVALANCHE")
 	})
+
+	t.Run("load rpc endpoints per chain", func(t *testing.T) {
+		t.Setenv("RPC_URL_POLYGON", "https://polygon.example.com")
+		t.Setenv("RPC_URLS_ETHEREUM", "https://a.example.com, https://b.example.com,,https://c.example.com")
+		t.Setenv("RPC_STRATEGY_ETHEREUM", "lowest_latency")
+		t.Setenv("RPC_MAX_BLOCK_LAG_ETHEREUM", "3")
+		t.Setenv("RPC_QUORUM_ETHEREUM", "2")
+
+		cfg := LoadConfig()
+
+		ethereum := cfg.RPCConfigs["ETHEREUM"]
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com", "https://c.example.com"}, ethereum.URLs)
+		assert.Equal(t, "lowest_latency", ethereum.Strategy)
+		assert.Equal(t, uint64(3), ethereum.MaxBlockLag)
+		assert.Equal(t, 15*time.Second, ethereum.HealthCheckInterval)
+		assert.Equal(t, 2, ethereum.QuorumSize)
+		assert.Equal(t, uint64(2), ethereum.QuorumMaxBlockSkew)
+		assert.Equal(t, "https://a.example.com", cfg.EVMRPCURLs["ETHEREUM"])
+
+		polygon := cfg.RPCConfigs["POLYGON"]
+		assert.Equal(t, []string{"https://polygon.example.com"}, polygon.URLs)
+		assert.Equal(t, "priority", polygon.Strategy)
+		assert.Equal(t, uint64(5), polygon.MaxBlockLag)
+		assert.Equal(t, 0, polygon.QuorumSize)
+	})
+
+	t.Run("load circuit breaker config", func(t *testing.T) {
+		t.Setenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD", "3")
+		t.Setenv("CIRCUIT_BREAKER_OPEN_TIMEOUT_SECONDS", "10")
+		t.Setenv("CIRCUIT_BREAKER_HALF_OPEN_MAX_CALLS", "2")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, 3, cfg.CircuitBreaker.FailureThreshold)
+		assert.Equal(t, 2, cfg.CircuitBreaker.SuccessThreshold)
+		assert.Equal(t, 10*time.Second, cfg.CircuitBreaker.OpenTimeout)
+		assert.Equal(t, 2, cfg.CircuitBreaker.HalfOpenMaxCalls)
+	})
+
+	t.Run("load rpc cache config", func(t *testing.T) {
+		t.Setenv("RPC_CACHE_GAS_PRICE_TTL_SECONDS", "3")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, "memory", cfg.RPCCache.Backend)
+		assert.Equal(t, "chainevm:rpc:", cfg.RPCCache.RedisPrefix)
+		assert.Equal(t, 10000, cfg.RPCCache.MaxSize)
+		assert.Equal(t, "lru", cfg.RPCCache.EvictionPolicy)
+		assert.Equal(t, time.Minute, cfg.RPCCache.JanitorInterval)
+		assert.Equal(t, 3*time.Second, cfg.RPCCache.GasPriceTTL)
+		assert.Equal(t, 15*time.Second, cfg.RPCCache.BalanceTTL)
+		assert.Equal(t, time.Hour, cfg.RPCCache.ReceiptTTL)
+		assert.Equal(t, 2*time.Second, cfg.RPCCache.HeadTTL)
+	})
+
+	t.Run("load batch config", func(t *testing.T) {
+		t.Setenv("BATCH_CONCURRENCY", "8")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, 8, cfg.Batch.Concurrency)
+		assert.Equal(t, 10*time.Second, cfg.Batch.DeadlineMargin)
+	})
+
+	t.Run("load worker config", func(t *testing.T) {
+		t.Setenv("WORKER_CONCURRENCY", "3")
+		t.Setenv("WORKER_HEARTBEAT_INTERVAL_SECONDS", "5")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, 3, cfg.Worker.Concurrency)
+		assert.Equal(t, 5*time.Second, cfg.Worker.HeartbeatInterval)
+		assert.Equal(t, 60*time.Second, cfg.Worker.VisibilityTimeout)
+		assert.Equal(t, 20*time.Second, cfg.Worker.WaitTime)
+		assert.Equal(t, 30*time.Second, cfg.Worker.ShutdownTimeout)
+		assert.Equal(t, 60*time.Second, cfg.Worker.ConfirmationSweepInterval)
+	})
+
+	t.Run("load key management config", func(t *testing.T) {
+		t.Setenv("KEY_PROVIDER", "kms")
+		t.Setenv("KMS_KEY_IDS", "alias/signer-1, alias/signer-2,")
+		t.Setenv("KEYSTORE_DIR", "/var/keystore")
+		t.Setenv("KEYSTORE_PASSWORD_FILE", "/run/secrets/keystore-password")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, "kms", cfg.KeyProvider)
+		assert.Equal(t, []string{"alias/signer-1", "alias/signer-2"}, cfg.KMSKeyIDs)
+		assert.Equal(t, "/var/keystore", cfg.KeystoreDir)
+		assert.Equal(t, "/run/secrets/keystore-password", cfg.KeystorePasswordFile)
+	})
+
+	t.Run("load expected chain IDs", func(t *testing.T) {
+		t.Setenv("CHAIN_ID_ETHEREUM", "11155111")
+		t.Setenv("CHAIN_ID_POLYGON", "invalid")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, int64(11155111), cfg.ChainIDs["ETHEREUM"])
+		assert.Equal(t, int64(137), cfg.ChainIDs["POLYGON"])
+		assert.Equal(t, int64(56), cfg.ChainIDs["BSC"])
+		assert.Len(t, cfg.ChainIDs, len(cfg.EVMRPCURLs))
+	})
+
+	t.Run("load fee strategy per chain", func(t *testing.T) {
+		t.Setenv("FEE_SPEED_POLYGON", "fast")
+		t.Setenv("FEE_PERCENTILES_POLYGON", "20, 60, 95")
+		t.Setenv("MAX_FEE_GWEI_POLYGON", "500")
+		t.Setenv("LEGACY_TX_BSC", "true")
+		t.Setenv("FEE_PERCENTILES_ARBITRUM", "10,50")
+
+		cfg := LoadConfig()
+
+		polygon := cfg.FeeConfigs["POLYGON"]
+		assert.Equal(t, "fast", polygon.DefaultSpeed)
+		assert.Equal(t, 20.0, polygon.SlowPercentile)
+		assert.Equal(t, 60.0, polygon.StandardPercentile)
+		assert.Equal(t, 95.0, polygon.FastPercentile)
+		assert.Equal(t, int64(500), polygon.MaxFeeGwei)
+		assert.False(t, polygon.LegacyOnly)
+
+		assert.True(t, cfg.FeeConfigs["BSC"].LegacyOnly)
+
+		ethereum := cfg.FeeConfigs["ETHEREUM"]
+		assert.Equal(t, "standard", ethereum.DefaultSpeed)
+		assert.Equal(t, int64(2), ethereum.BaseFeeMultiplier)
+		assert.Equal(t, int64(0), ethereum.MaxFeeGwei)
+
+		// Lista inválida mantém os percentis padrão
+		assert.Equal(t, 90.0, cfg.FeeConfigs["ARBITRUM"].FastPercentile)
+	})
+
+	t.Run("load gas estimation bounds per chain", func(t *testing.T) {
+		t.Setenv("GAS_LIMIT_MULTIPLIER_ETHEREUM", "1.5")
+		t.Setenv("MAX_GAS_LIMIT_ETHEREUM", "15000000")
+		t.Setenv("MIN_GAS_LIMIT_POLYGON", "30000")
+		t.Setenv("GAS_LIMIT_MULTIPLIER_BSC", "invalid")
+
+		cfg := LoadConfig()
+
+		ethereum := cfg.GasConfigs["ETHEREUM"]
+		assert.Equal(t, 1.5, ethereum.Multiplier)
+		assert.Equal(t, uint64(21000), ethereum.MinGasLimit)
+		assert.Equal(t, uint64(15000000), ethereum.MaxGasLimit)
+
+		assert.Equal(t, uint64(30000), cfg.GasConfigs["POLYGON"].MinGasLimit)
+		assert.Equal(t, 1.2, cfg.GasConfigs["BSC"].Multiplier)
+		assert.Len(t, cfg.GasConfigs, len(cfg.EVMRPCURLs))
+	})
+	t.Run("load confirmation depth per chain", func(t *testing.T) {
+		t.Setenv("REQUIRED_CONFIRMATIONS", "6")
+		t.Setenv("REQUIRED_CONFIRMATIONS_POLYGON", "128")
+		t.Setenv("REQUIRED_CONFIRMATIONS_BSC", "invalid")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, 128, cfg.ConfirmationDepths["POLYGON"])
+		assert.Equal(t, 6, cfg.ConfirmationDepths["ETHEREUM"])
+		assert.Equal(t, 6, cfg.ConfirmationDepths["BSC"])
+		assert.Len(t, cfg.ConfirmationDepths, len(cfg.EVMRPCURLs))
+	})
+
+	t.Run("load retry policy", func(t *testing.T) {
+		t.Setenv("RETRY_MAX_RETRIES", "4")
+		t.Setenv("RETRY_MAX_RETRIES_NONCE_CONFLICT", "8")
+		t.Setenv("RETRY_MODE", "in_process")
+		t.Setenv("RETRY_JITTER", "decorrelated")
+		t.Setenv("RETRY_MAX_ELAPSED_SECONDS", "1800")
+
+		cfg := LoadConfig()
+
+		assert.Equal(t, 4, cfg.Retry.MaxRetries)
+		assert.Equal(t, map[string]int{"NONCE_CONFLICT": 8}, cfg.Retry.CategoryMaxRetries)
+		assert.Equal(t, "in_process", cfg.Retry.Mode)
+		assert.Equal(t, "decorrelated", cfg.Retry.Jitter)
+		assert.Equal(t, 30*time.Minute, cfg.Retry.MaxElapsedTime)
+		assert.Equal(t, 100*time.Millisecond, cfg.Retry.InitialBackoff)
+		assert.Equal(t, 30*time.Second, cfg.Retry.InitialVisibility)
+		assert.Equal(t, 15*time.Minute, cfg.Retry.MaxVisibility)
+	})
+
+	t.Run("load event publisher", func(t *testing.T) {
+		cfg := LoadConfig()
+		assert.Equal(t, "none", cfg.Events.Publisher)
+		assert.Equal(t, "chainevm", cfg.Events.Source)
+
+		t.Setenv("EVENT_PUBLISHER", "sns")
+		t.Setenv("EVENT_TOPIC_ARN", "arn:aws:sns:us-east-1:123456789012:events")
+		t.Setenv("EVENT_SOURCE", "chainevm.staging")
+
+		cfg = LoadConfig()
+
+		assert.Equal(t, "sns", cfg.Events.Publisher)
+		assert.Equal(t, "arn:aws:sns:us-east-1:123456789012:events", cfg.Events.TopicARN)
+		assert.Equal(t, "chainevm.staging", cfg.Events.Source)
+	})
 }
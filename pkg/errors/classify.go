@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// nodeErrors mensagens de erro do go-ethereum e dos nodes mapeadas para erros tipados
+var nodeErrors = []struct {
+	fragment string
+	err      *AppError
+}{
+	{"nonce too low", ErrNonceTooLow},
+	{"replacement transaction underpriced", ErrReplacementUnderpriced},
+	{"insufficient funds", ErrInsufficientFunds},
+	{"execution reverted", ErrExecutionReverted},
+}
+
+// throttlingCodes códigos de erro da AWS para requisições limitadas pelo DynamoDB
+var throttlingCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+}
+
+// FromNodeError converte um erro retornado pelo node em erro tipado com a mesma mensagem;
+// ok = false quando a mensagem não é reconhecida
+func FromNodeError(message string, err error) (*AppError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	text := strings.ToLower(err.Error())
+	for _, nodeErr := range nodeErrors {
+		if strings.Contains(text, nodeErr.fragment) {
+			return NewAppError(nodeErr.err.Code, message, err), true
+		}
+	}
+	return nil, false
+}
+
+// FromDatabaseError erro de banco; throttling do DynamoDB vira ErrDatabaseThrottled (retentável)
+func FromDatabaseError(message string, err error) *AppError {
+	var apiErr interface{ ErrorCode() string }
+	if errors.As(err, &apiErr) && throttlingCodes[apiErr.ErrorCode()] {
+		return NewAppError(ErrDatabaseThrottled.Code, message, err)
+	}
+	return NewAppError(ErrDatabaseError.Code, message, err)
+}
+
+// IsRetryable indica se a falha pode ter sucesso em uma nova tentativa; erros que não são AppError
+// são considerados transitórios
+func IsRetryable(err error) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.IsRetryable()
+	}
+	return err != nil
+}
+
+// DetailsOf contexto do AppError na cadeia de err
+func DetailsOf(err error) Details {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Details
+	}
+	return Details{}
+}
+
+// CategoryOfError categoria do AppError na cadeia de err (CategoryInternal quando não há)
+func CategoryOfError(err error) Category {
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr.Category != "" {
+		return appErr.Category
+	}
+	return CategoryInternal
+}
@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiError simula o smithy.APIError retornado pelo SDK da AWS
+type apiError struct {
+	code string
+}
+
+func (e *apiError) Error() string     { return e.code }
+func (e *apiError) ErrorCode() string { return e.code }
+
+func TestFromNodeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{"nonce too low", errors.New("nonce too low: next nonce 5, tx nonce 4"), "NONCE_TOO_LOW"},
+		{"replacement underpriced", errors.New("replacement transaction underpriced"), "REPLACEMENT_UNDERPRICED"},
+		{"insufficient funds", errors.New("insufficient funds for gas * price + value"), "INSUFFICIENT_FUNDS"},
+		{"execution reverted", errors.New("execution reverted: Ownable: caller is not the owner"), "EXECUTION_REVERTED"},
+		{"wrapped and uppercase", fmt.Errorf("send: %w", errors.New("Nonce Too Low")), "NONCE_TOO_LOW"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			appErr, ok := FromNodeError("failed to send", tt.err)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.wantCode, appErr.Code)
+			assert.Equal(t, "failed to send", appErr.Message)
+			assert.Equal(t, tt.err, appErr.Err)
+		})
+	}
+
+	t.Run("unknown message", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := FromNodeError("failed to send", errors.New("connection reset by peer"))
+		assert.False(t, ok)
+	})
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := FromNodeError("failed to send", nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestFromDatabaseError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("throttling is retryable", func(t *testing.T) {
+		t.Parallel()
+
+		err := FromDatabaseError("failed to save transaction",
+			fmt.Errorf("failed to save transaction: %w", &apiError{code: "ProvisionedThroughputExceededException"}))
+
+		assert.Equal(t, "DATABASE_THROTTLED", err.Code)
+		assert.Equal(t, CategoryDBThrottling, err.Category)
+		assert.True(t, err.IsRetryable())
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := FromDatabaseError("failed to save transaction", &apiError{code: "ResourceNotFoundException"})
+
+		assert.Equal(t, "DATABASE_ERROR", err.Code)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsRetryable(NewAppError(ErrValidationFailed.Code, "invalid address", nil)))
+	assert.False(t, IsRetryable(fmt.Errorf("execute: %w", NewAppError(ErrInsufficientFunds.Code, "insufficient funds", nil))))
+	assert.True(t, IsRetryable(NewAppError(ErrRPCFailed.Code, "timeout", nil)))
+	assert.True(t, IsRetryable(errors.New("unknown error")))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestCategoryOfError(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, CategoryNonceConflict, CategoryOfError(NewAppError(ErrNonceTooLow.Code, "nonce too low", nil)))
+	assert.Equal(t, CategoryInternal, CategoryOfError(errors.New("unknown error")))
+}
@@ -2,11 +2,45 @@ package errors
 
 import "fmt"
 
+// Category categoria do erro, usada pelo pipeline para decidir se a falha deve ser retentada
+type Category string
+
+const (
+	CategoryValidation    Category = "VALIDATION"
+	CategoryRPCTransient  Category = "RPC_TRANSIENT"
+	CategoryRPCPermanent  Category = "RPC_PERMANENT"
+	CategoryNonceConflict Category = "NONCE_CONFLICT"
+	CategoryDBThrottling  Category = "DB_THROTTLING"
+	CategoryInternal      Category = "INTERNAL"
+)
+
+// Retryability classe de retry do erro
+type Retryability string
+
+const (
+	// Retryable a mesma mensagem pode ter sucesso em uma nova tentativa
+	Retryable Retryability = "RETRYABLE"
+	// Permanent nenhuma nova tentativa muda o resultado; a transação vai direto para FAILED
+	Permanent Retryability = "PERMANENT"
+)
+
+// Details contexto estruturado anexado ao erro
+type Details struct {
+	Chain       string
+	OperationID string
+	TxHash      string
+	// RevertData dados brutos do revert em hex (0x...)
+	RevertData string
+}
+
 // AppError erro customizado da aplicação
 type AppError struct {
-	Code    string
-	Message string
-	Err     error
+	Code         string
+	Message      string
+	Err          error
+	Category     Category
+	Retryability Retryability
+	Details      Details
 }
 
 func (e *AppError) Error() string {
@@ -16,26 +50,110 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
-// NewAppError cria um novo erro da aplicação
+// Unwrap expõe o erro original para errors.Is/As
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is compara pelo código, para que errors.Is(err, ErrRPCFailed) reconheça qualquer AppError com o mesmo código
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// NewAppError cria um novo erro da aplicação; categoria e classe de retry vêm do código
 func NewAppError(code, message string, err error) *AppError {
+	category := CategoryOf(code)
 	return &AppError{
-		Code:    code,
-		Message: message,
-		Err:     err,
+		Code:         code,
+		Message:      message,
+		Err:          err,
+		Category:     category,
+		Retryability: category.Retryability(),
+	}
+}
+
+// WithDetails anexa o contexto ao erro; campos vazios não sobrescrevem os já preenchidos
+func (e *AppError) WithDetails(details Details) *AppError {
+	if details.Chain != "" {
+		e.Details.Chain = details.Chain
+	}
+	if details.OperationID != "" {
+		e.Details.OperationID = details.OperationID
+	}
+	if details.TxHash != "" {
+		e.Details.TxHash = details.TxHash
+	}
+	if details.RevertData != "" {
+		e.Details.RevertData = details.RevertData
+	}
+	return e
+}
+
+// WithCategory reclassifica o erro (ex.: falha de estimativa causada pela rede e não por revert)
+func (e *AppError) WithCategory(category Category) *AppError {
+	e.Category = category
+	e.Retryability = category.Retryability()
+	return e
+}
+
+// IsRetryable indica se uma nova tentativa pode ter sucesso; erros sem classe são retentáveis
+func (e *AppError) IsRetryable() bool {
+	return e.Retryability != Permanent
+}
+
+// Retryability classe de retry padrão da categoria
+func (c Category) Retryability() Retryability {
+	switch c {
+	case CategoryValidation, CategoryRPCPermanent:
+		return Permanent
+	default:
+		return Retryable
+	}
+}
+
+// codeCategories categoria de cada código conhecido; códigos ausentes são CategoryInternal
+var codeCategories = map[string]Category{
+	"INVALID_INPUT":           CategoryValidation,
+	"VALIDATION_FAILED":       CategoryValidation,
+	"CHAIN_NOT_SUPPORTED":     CategoryValidation,
+	"OPERATION_NOT_FOUND":     CategoryValidation,
+	"NOT_IMPLEMENTED":         CategoryValidation,
+	"RPC_FAILED":              CategoryRPCTransient,
+	"RPC_UNAVAILABLE":         CategoryRPCTransient,
+	"TRANSACTION_FAILED":      CategoryRPCPermanent,
+	"GAS_ESTIMATION_FAILED":   CategoryRPCPermanent,
+	"INSUFFICIENT_FUNDS":      CategoryRPCPermanent,
+	"EXECUTION_REVERTED":      CategoryRPCPermanent,
+	"NONCE_TOO_LOW":           CategoryNonceConflict,
+	"REPLACEMENT_UNDERPRICED": CategoryNonceConflict,
+	"DATABASE_THROTTLED":      CategoryDBThrottling,
+}
+
+// CategoryOf categoria padrão de um código de erro
+func CategoryOf(code string) Category {
+	if category, ok := codeCategories[code]; ok {
+		return category
 	}
+	return CategoryInternal
 }
 
 // Erros comuns
 var (
-	ErrInvalidInput        = &AppError{Code: "INVALID_INPUT", Message: "invalid input"}
-	ErrValidationFailed    = &AppError{Code: "VALIDATION_FAILED", Message: "validation failed"}
-	ErrRPCFailed           = &AppError{Code: "RPC_FAILED", Message: "RPC call failed"}
-	ErrTransactionFailed   = &AppError{Code: "TRANSACTION_FAILED", Message: "transaction execution failed"}
-	ErrChainNotSupported   = &AppError{Code: "CHAIN_NOT_SUPPORTED", Message: "chain type not supported"}
-	ErrOperationNotFound   = &AppError{Code: "OPERATION_NOT_FOUND", Message: "operation not found"}
-	ErrNotImplemented      = &AppError{Code: "NOT_IMPLEMENTED", Message: "feature not implemented"}
-	ErrDatabaseError       = &AppError{Code: "DATABASE_ERROR", Message: "database error"}
-	ErrSQSError            = &AppError{Code: "SQS_ERROR", Message: "SQS error"}
-	ErrGasEstimationFailed = &AppError{Code: "GAS_ESTIMATION_FAILED", Message: "gas estimation failed"}
-	ErrInsufficientFunds   = &AppError{Code: "INSUFFICIENT_FUNDS", Message: "insufficient funds for transaction"}
+	ErrInvalidInput           = NewAppError("INVALID_INPUT", "invalid input", nil)
+	ErrValidationFailed       = NewAppError("VALIDATION_FAILED", "validation failed", nil)
+	ErrRPCFailed              = NewAppError("RPC_FAILED", "RPC call failed", nil)
+	ErrRPCUnavailable         = NewAppError("RPC_UNAVAILABLE", "RPC endpoint unavailable", nil)
+	ErrTransactionFailed      = NewAppError("TRANSACTION_FAILED", "transaction execution failed", nil)
+	ErrChainNotSupported      = NewAppError("CHAIN_NOT_SUPPORTED", "chain type not supported", nil)
+	ErrOperationNotFound      = NewAppError("OPERATION_NOT_FOUND", "operation not found", nil)
+	ErrNotImplemented         = NewAppError("NOT_IMPLEMENTED", "feature not implemented", nil)
+	ErrDatabaseError          = NewAppError("DATABASE_ERROR", "database error", nil)
+	ErrDatabaseThrottled      = NewAppError("DATABASE_THROTTLED", "database request throttled", nil)
+	ErrSQSError               = NewAppError("SQS_ERROR", "SQS error", nil)
+	ErrGasEstimationFailed    = NewAppError("GAS_ESTIMATION_FAILED", "gas estimation failed", nil)
+	ErrInsufficientFunds      = NewAppError("INSUFFICIENT_FUNDS", "insufficient funds for transaction", nil)
+	ErrNonceTooLow            = NewAppError("NONCE_TOO_LOW", "nonce too low", nil)
+	ErrReplacementUnderpriced = NewAppError("REPLACEMENT_UNDERPRICED", "replacement transaction underpriced", nil)
+	ErrExecutionReverted      = NewAppError("EXECUTION_REVERTED", "execution reverted", nil)
 )
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,6 +51,7 @@ func TestPredefinedErrors(t *testing.T) {
 		{"ErrInvalidInput", ErrInvalidInput, "INVALID_INPUT"},
 		{"ErrValidationFailed", ErrValidationFailed, "VALIDATION_FAILED"},
 		{"ErrRPCFailed", ErrRPCFailed, "RPC_FAILED"},
+		{"ErrRPCUnavailable", ErrRPCUnavailable, "RPC_UNAVAILABLE"},
 		{"ErrTransactionFailed", ErrTransactionFailed, "TRANSACTION_FAILED"},
 		{"ErrChainNotSupported", ErrChainNotSupported, "CHAIN_NOT_SUPPORTED"},
 		{"ErrOperationNotFound", ErrOperationNotFound, "OPERATION_NOT_FOUND"},
@@ -58,6 +60,10 @@ func TestPredefinedErrors(t *testing.T) {
 		{"ErrSQSError", ErrSQSError, "SQS_ERROR"},
 		{"ErrGasEstimationFailed", ErrGasEstimationFailed, "GAS_ESTIMATION_FAILED"},
 		{"ErrInsufficientFunds", ErrInsufficientFunds, "INSUFFICIENT_FUNDS"},
+		{"ErrDatabaseThrottled", ErrDatabaseThrottled, "DATABASE_THROTTLED"},
+		{"ErrNonceTooLow", ErrNonceTooLow, "NONCE_TOO_LOW"},
+		{"ErrReplacementUnderpriced", ErrReplacementUnderpriced, "REPLACEMENT_UNDERPRICED"},
+		{"ErrExecutionReverted", ErrExecutionReverted, "EXECUTION_REVERTED"},
 	}
 
 	for _, tt := range tests {
@@ -102,3 +108,95 @@ func TestAppErrorError(t *testing.T) {
 		assert.Contains(t, errMsg, "root cause")
 	})
 }
+
+func TestAppErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		err           *AppError
+		wantCategory  Category
+		wantRetryable bool
+	}{
+		{"validation", ErrValidationFailed, CategoryValidation, false},
+		{"chain not supported", ErrChainNotSupported, CategoryValidation, false},
+		{"rpc failed", ErrRPCFailed, CategoryRPCTransient, true},
+		{"rpc unavailable", ErrRPCUnavailable, CategoryRPCTransient, true},
+		{"insufficient funds", ErrInsufficientFunds, CategoryRPCPermanent, false},
+		{"execution reverted", ErrExecutionReverted, CategoryRPCPermanent, false},
+		{"nonce too low", ErrNonceTooLow, CategoryNonceConflict, true},
+		{"replacement underpriced", ErrReplacementUnderpriced, CategoryNonceConflict, true},
+		{"database throttled", ErrDatabaseThrottled, CategoryDBThrottling, true},
+		{"database error", ErrDatabaseError, CategoryInternal, true},
+		{"unknown code", NewAppError("UNKNOWN", "unknown", nil), CategoryInternal, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.wantCategory, tt.err.Category)
+			assert.Equal(t, tt.wantRetryable, tt.err.IsRetryable())
+		})
+	}
+
+	t.Run("reclassify with category", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewAppError(ErrGasEstimationFailed.Code, "failed to estimate gas", nil).WithCategory(CategoryRPCTransient)
+
+		assert.Equal(t, CategoryRPCTransient, err.Category)
+		assert.Equal(t, Retryable, err.Retryability)
+	})
+
+	t.Run("error without class is retryable", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, (&AppError{Code: "TEST"}).IsRetryable())
+	})
+}
+
+func TestAppErrorWrapping(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unwrap exposes underlying error", func(t *testing.T) {
+		t.Parallel()
+
+		cause := errors.New("connection refused")
+		err := fmt.Errorf("execute: %w", NewAppError(ErrRPCFailed.Code, "failed to get balance", cause))
+
+		assert.ErrorIs(t, err, cause)
+	})
+
+	t.Run("is matches sentinel by code", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("execute: %w", NewAppError(ErrRPCFailed.Code, "failed to get balance", nil))
+
+		assert.ErrorIs(t, err, ErrRPCFailed)
+		assert.NotErrorIs(t, err, ErrRPCUnavailable)
+	})
+
+	t.Run("as finds wrapped app error", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("execute: %w", NewAppError(ErrInsufficientFunds.Code, "insufficient funds", nil))
+
+		var appErr *AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, ErrInsufficientFunds.Code, appErr.Code)
+	})
+}
+
+func TestAppErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	err := NewAppError(ErrTransactionFailed.Code, "execution reverted", nil).
+		WithDetails(Details{Chain: "ETHEREUM", OperationID: "op-123"}).
+		WithDetails(Details{TxHash: "0xabc", RevertData: "0x08c379a0"})
+
+	assert.Equal(t, Details{Chain: "ETHEREUM", OperationID: "op-123", TxHash: "0xabc", RevertData: "0x08c379a0"}, err.Details)
+	assert.Equal(t, err.Details, DetailsOf(fmt.Errorf("execute: %w", err)))
+	assert.Equal(t, Details{}, DetailsOf(errors.New("unknown error")))
+}
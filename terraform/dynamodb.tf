@@ -55,6 +55,26 @@ resource "aws_dynamodb_table" "transactions" {
   }
 }
 
+# DynamoDB Table for nonce reservation per (chain, address)
+resource "aws_dynamodb_table" "nonces" {
+  name         = var.nonce_table_name
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "nonce_key"
+
+  attribute {
+    name = "nonce_key"
+    type = "S"
+  }
+
+  point_in_time_recovery {
+    enabled = true
+  }
+
+  tags = {
+    Description = "EVM nonce reservations"
+  }
+}
+
 # CloudWatch Alarm for item count
 resource "aws_cloudwatch_metric_alarm" "dynamodb_item_count" {
   alarm_name          = "${var.dynamodb_table_name}-item-count-high"
@@ -0,0 +1,25 @@
+# Event bus dos eventos de domínio (EVENT_PUBLISHER=eventbridge); o bus já deve existir (default da conta)
+data "aws_cloudwatch_event_bus" "events" {
+  count = var.event_publisher == "eventbridge" ? 1 : 0
+
+  name = var.event_bus_name
+}
+
+# IAM Policy for Lambda - EventBridge PutEvents
+resource "aws_iam_role_policy" "lambda_eventbridge_policy" {
+  count = var.event_publisher == "eventbridge" ? 1 : 0
+
+  name = "${var.lambda_function_name}-eventbridge-policy"
+  role = aws_iam_role.lambda_role.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["events:PutEvents"]
+        Resource = data.aws_cloudwatch_event_bus.events[0].arn
+      }
+    ]
+  })
+}
@@ -39,6 +39,11 @@ resource "aws_iam_role_policy" "lambda_sqs_policy" {
           "sqs:ChangeMessageVisibility"
         ]
         Resource = local.evm_queue_arn
+      },
+      {
+        Effect   = "Allow"
+        Action   = ["sqs:SendMessage"]
+        Resource = local.evm_dlq_arn
       }
     ]
   })
@@ -60,7 +65,33 @@ resource "aws_iam_role_policy" "lambda_dynamodb_policy" {
           "dynamodb:UpdateItem",
           "dynamodb:Query"
         ]
-        Resource = aws_dynamodb_table.transactions.arn
+        Resource = [
+          aws_dynamodb_table.transactions.arn,
+          "${aws_dynamodb_table.transactions.arn}/index/*",
+          aws_dynamodb_table.nonces.arn
+        ]
+      }
+    ]
+  })
+}
+
+# IAM Policy for Lambda - KMS signing (KEY_PROVIDER=kms)
+resource "aws_iam_role_policy" "lambda_kms_policy" {
+  count = var.key_provider == "kms" ? 1 : 0
+
+  name = "${var.lambda_function_name}-kms-policy"
+  role = aws_iam_role.lambda_role.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "kms:Sign",
+          "kms:GetPublicKey"
+        ]
+        Resource = var.kms_key_arns
       }
     ]
   })
@@ -78,19 +109,34 @@ resource "aws_lambda_function" "evm_executor" {
   source_code_hash = fileexists(var.lambda_file_path) ? filebase64sha256(var.lambda_file_path) : ""
 
   environment {
-    variables = {
-      DYNAMODB_TABLE_NAME     = aws_dynamodb_table.transactions.name
-      SQS_QUEUE_URL           = local.evm_queue_url
-      RPC_URL_ETHEREUM        = var.rpc_url_ethereum
-      RPC_URL_POLYGON         = var.rpc_url_polygon
-      RPC_URL_BSC             = var.rpc_url_bsc
-      RPC_URL_ARBITRUM        = var.rpc_url_arbitrum
-      RPC_URL_OPTIMISM        = var.rpc_url_optimism
-      RPC_URL_AVALANCHE       = var.rpc_url_avalanche
-      RPC_TIMEOUT_SECONDS     = var.rpc_timeout_seconds
-      REQUEST_TIMEOUT_SECONDS = 30
-      REQUIRED_CONFIRMATIONS  = var.required_confirmations
-    }
+    variables = merge({
+      DYNAMODB_TABLE_NAME      = aws_dynamodb_table.transactions.name
+      NONCE_TABLE_NAME         = aws_dynamodb_table.nonces.name
+      SQS_QUEUE_URL            = local.evm_queue_url
+      RPC_URL_ETHEREUM         = var.rpc_url_ethereum
+      RPC_URL_POLYGON          = var.rpc_url_polygon
+      RPC_URL_BSC              = var.rpc_url_bsc
+      RPC_URL_ARBITRUM         = var.rpc_url_arbitrum
+      RPC_URL_OPTIMISM         = var.rpc_url_optimism
+      RPC_URL_AVALANCHE        = var.rpc_url_avalanche
+      RPC_TIMEOUT_SECONDS      = var.rpc_timeout_seconds
+      REQUEST_TIMEOUT_SECONDS  = 30
+      REQUIRED_CONFIRMATIONS   = var.required_confirmations
+      CONFIRMATION_SWEEP_LIMIT = var.confirmation_sweep_limit
+      BATCH_CONCURRENCY        = var.batch_concurrency
+      RETRY_MAX_RETRIES        = var.retry_max_retries
+      RETRY_MODE               = var.retry_mode
+      RETRY_JITTER             = var.retry_jitter
+      EVENT_PUBLISHER          = var.event_publisher
+      EVENT_TOPIC_ARN          = try(aws_sns_topic.events[0].arn, "")
+      EVENT_BUS_NAME           = var.event_bus_name
+      KEY_PROVIDER             = var.key_provider
+      KMS_KEY_IDS              = join(",", var.kms_key_arns)
+      }, {
+      for chain, depth in var.confirmation_depths : "REQUIRED_CONFIRMATIONS_${chain}" => depth
+      }, {
+      for chain, id in var.chain_ids : "CHAIN_ID_${chain}" => id
+    })
   }
 
   depends_on = [
@@ -104,13 +150,33 @@ resource "aws_lambda_function" "evm_executor" {
 resource "aws_lambda_event_source_mapping" "sqs_trigger" {
   event_source_arn                   = local.evm_queue_arn
   function_name                      = aws_lambda_function.evm_executor.arn
-  batch_size                         = 1
+  batch_size                         = var.sqs_batch_size
   maximum_batching_window_in_seconds = 5
 
   # On error, send to DLQ
   function_response_types = ["ReportBatchItemFailures"]
 }
 
+# Scheduled confirmation sweep (SUBMITTED -> CONFIRMED)
+resource "aws_cloudwatch_event_rule" "confirmation_sweep" {
+  name                = "${var.lambda_function_name}-confirmation-sweep"
+  description         = "Checks receipts of submitted transactions"
+  schedule_expression = var.confirmation_sweep_schedule
+}
+
+resource "aws_cloudwatch_event_target" "confirmation_sweep" {
+  rule = aws_cloudwatch_event_rule.confirmation_sweep.name
+  arn  = aws_lambda_function.evm_executor.arn
+}
+
+resource "aws_lambda_permission" "confirmation_sweep" {
+  statement_id  = "AllowConfirmationSweep"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.evm_executor.function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.confirmation_sweep.arn
+}
+
 # CloudWatch Log Group for Lambda
 resource "aws_cloudwatch_log_group" "lambda_logs" {
   name              = "/aws/lambda/${var.lambda_function_name}"
@@ -53,6 +53,11 @@ output "dynamodb_table_stream_arn" {
   value       = aws_dynamodb_table.transactions.stream_arn
 }
 
+output "events_topic_arn" {
+  description = "ARN of the SNS topic with the domain events (empty when event_publisher is not sns)"
+  value       = try(aws_sns_topic.events[0].arn, "")
+}
+
 output "cloudwatch_log_group" {
   description = "CloudWatch log group for Lambda"
   value       = aws_cloudwatch_log_group.lambda_logs.name
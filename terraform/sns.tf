@@ -0,0 +1,30 @@
+# Tópico SNS dos eventos de domínio (EVENT_PUBLISHER=sns); os serviços consumidores assinam com filas
+# próprias e podem filtrar pelos atributos EventType e EventVersion
+resource "aws_sns_topic" "events" {
+  count = var.event_publisher == "sns" ? 1 : 0
+
+  name = "${var.lambda_function_name}-events"
+
+  tags = {
+    Description = "EVM transaction domain events"
+  }
+}
+
+# IAM Policy for Lambda - SNS publish
+resource "aws_iam_role_policy" "lambda_sns_policy" {
+  count = var.event_publisher == "sns" ? 1 : 0
+
+  name = "${var.lambda_function_name}-sns-policy"
+  role = aws_iam_role.lambda_role.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["sns:Publish"]
+        Resource = aws_sns_topic.events[0].arn
+      }
+    ]
+  })
+}
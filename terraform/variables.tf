@@ -66,6 +66,12 @@ variable "dynamodb_table_name" {
   default     = "evm-transactions"
 }
 
+variable "nonce_table_name" {
+  description = "DynamoDB table name for nonce reservations"
+  type        = string
+  default     = "evm-nonces"
+}
+
 variable "dynamodb_ttl_attribute" {
   description = "DynamoDB TTL attribute name"
   type        = string
@@ -114,6 +120,19 @@ variable "rpc_url_avalanche" {
   default     = ""
 }
 
+variable "chain_ids" {
+  description = "Chain ID expected from each RPC (CHAIN_ID_<CHAIN>); defaults to the testnets of the deployment (Sepolia, Amoy, ...)"
+  type        = map(number)
+  default = {
+    ETHEREUM  = 11155111
+    POLYGON   = 80002
+    BSC       = 97
+    ARBITRUM  = 421614
+    OPTIMISM  = 11155420
+    AVALANCHE = 43113
+  }
+}
+
 variable "rpc_timeout_seconds" {
   description = "RPC timeout in seconds"
   type        = number
@@ -125,3 +144,75 @@ variable "required_confirmations" {
   type        = number
   default     = 12
 }
+
+variable "confirmation_depths" {
+  description = "Required confirmations per chain (overrides required_confirmations), e.g. { POLYGON = 128 }"
+  type        = map(number)
+  default     = {}
+}
+
+variable "confirmation_sweep_schedule" {
+  description = "EventBridge schedule of the confirmation sweep"
+  type        = string
+  default     = "rate(1 minute)"
+}
+
+variable "confirmation_sweep_limit" {
+  description = "Submitted operations checked per confirmation sweep"
+  type        = number
+  default     = 100
+}
+
+variable "sqs_batch_size" {
+  description = "SQS messages delivered per Lambda invocation"
+  type        = number
+  default     = 10
+}
+
+variable "batch_concurrency" {
+  description = "Records of a batch processed in parallel (same sender stays in order)"
+  type        = number
+  default     = 4
+}
+
+variable "retry_max_retries" {
+  description = "Retries of a transient failure before the message goes to the DLQ"
+  type        = number
+  default     = 3
+}
+
+variable "retry_mode" {
+  description = "Backoff between retries: visibility (SQS message visibility) or in_process (sleep inside the invocation)"
+  type        = string
+  default     = "visibility"
+}
+
+variable "retry_jitter" {
+  description = "Jitter applied to retry backoff: none, full or decorrelated"
+  type        = string
+  default     = "full"
+}
+
+variable "event_publisher" {
+  description = "Backend for domain events: none, sns (creates the events topic) or eventbridge"
+  type        = string
+  default     = "none"
+}
+
+variable "event_bus_name" {
+  description = "EventBridge bus of the domain events (event_publisher = eventbridge)"
+  type        = string
+  default     = "default"
+}
+
+variable "key_provider" {
+  description = "Signing key backend: env, file, keystore or kms"
+  type        = string
+  default     = "env"
+}
+
+variable "kms_key_arns" {
+  description = "ARNs of the ECC_SECG_P256K1 KMS keys used to sign (key_provider = kms)"
+  type        = list(string)
+  default     = []
+}